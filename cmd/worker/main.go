@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+
+	"github.com/ItsXomyak/scam-list/config"
+	"github.com/ItsXomyak/scam-list/internal/app"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+)
+
+const (
+	serviceName = "scam-list-worker"
+	configPath  = ".env"
+)
+
+func main() {
+	ctx := context.Background()
+
+	// Initialize logger
+	logger := logger.InitLogger(serviceName, logger.LevelDebug)
+
+	// Load configuration
+	cfg, err := config.New(configPath)
+	if err != nil {
+		logger.Error(ctx, "failed to load config", err)
+		return
+	}
+
+	// Create and run the worker
+	worker, err := app.NewWorker(ctx, cfg, logger)
+	if err != nil {
+		logger.Error(ctx, "failed to create worker", err)
+		return
+	}
+
+	if err := worker.Run(ctx); err != nil {
+		logger.Error(ctx, "worker run failed", err)
+		return
+	}
+}
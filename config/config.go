@@ -2,6 +2,7 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
 	"time"
 
 	"github.com/caarlos0/env/v10"
@@ -12,6 +13,21 @@ type (
 	Config struct {
 		HTTPServer HTTPServer
 		Postgres   Postgres
+		Scoring    Scoring
+		Status     Status
+		Pipeline   Pipeline
+		Redirects  Redirects
+		Outbound   Outbound
+		Checkers   Checkers
+		Feeds      Feeds
+		Jobs       Jobs
+		Recheck    Recheck
+
+		ScamDetector ScamDetector
+		DNS          DNS
+		WHOIS        WHOIS
+		RDAP         RDAP
+		Content      Content
 	}
 
 	HTTPServer struct {
@@ -30,6 +46,122 @@ type (
 		ConnAttempts int           `env:"POSTGRES_CONN_ATTEMPTS" envDefault:"5"`
 		ConnTimeout  time.Duration `env:"POSTGRES_CONN_TIMEOUT" envDefault:"5s"`
 	}
+
+	// Scoring configures how checker results are combined into risk_score.
+	// SCORING_WEIGHTS format: "scamdetector:3,lexical:1"
+	Scoring struct {
+		Weights       map[string]float64 `env:"SCORING_WEIGHTS"`
+		DefaultWeight float64            `env:"SCORING_DEFAULT_WEIGHT" envDefault:"1"`
+	}
+
+	// Status sets the risk_score bands: score <= STATUS_VERIFIED_MAX is verified,
+	// score <= STATUS_SUSPICIOUS_MAX is suspicious, anything above is scam.
+	Status struct {
+		VerifiedMax   float64 `env:"STATUS_VERIFIED_MAX" envDefault:"30"`
+		SuspiciousMax float64 `env:"STATUS_SUSPICIOUS_MAX" envDefault:"70"`
+	}
+
+	Pipeline struct {
+		CheckerTimeout        time.Duration `env:"PIPELINE_CHECKER_TIMEOUT" envDefault:"15s"`
+		MinSuccessfulCheckers int           `env:"PIPELINE_MIN_SUCCESSFUL_CHECKERS" envDefault:"1"`
+	}
+
+	// Redirects controls how the pipeline follows redirects of submitted links.
+	Redirects struct {
+		Disabled bool          `env:"REDIRECTS_DISABLED" envDefault:"false"`
+		MaxHops  int           `env:"REDIRECTS_MAX_HOPS" envDefault:"10"`
+		Timeout  time.Duration `env:"REDIRECTS_TIMEOUT" envDefault:"5s"`
+	}
+
+	// Feeds configures blocklist imports, the feeds themselves are listed
+	// in the JSON file FEEDS_FILE. No file means no imports.
+	Feeds struct {
+		File    string        `env:"FEEDS_FILE"`
+		Timeout time.Duration `env:"FEEDS_TIMEOUT" envDefault:"2m"`
+		MaxSize int64         `env:"FEEDS_MAX_SIZE" envDefault:"268435456"`
+		// how often processes that do not import feeds, like cmd/worker,
+		// reload the blocklist index from the database
+		IndexReload time.Duration `env:"FEEDS_INDEX_RELOAD" envDefault:"10m"`
+	}
+
+	// Jobs configures the verification queue and its workers. cmd/worker
+	// runs JOBS_WORKERS workers, the API runs them too unless JOBS_WORKERS=0.
+	// JOBS_VISIBILITY_TIMEOUT must exceed JOBS_TIMEOUT.
+	Jobs struct {
+		Workers           int           `env:"JOBS_WORKERS" envDefault:"4"`
+		PollInterval      time.Duration `env:"JOBS_POLL_INTERVAL" envDefault:"1s"`
+		Timeout           time.Duration `env:"JOBS_TIMEOUT" envDefault:"2m"`
+		MaxAttempts       int           `env:"JOBS_MAX_ATTEMPTS" envDefault:"5"`
+		RetryBackoff      time.Duration `env:"JOBS_RETRY_BACKOFF" envDefault:"10s"`
+		MaxBackoff        time.Duration `env:"JOBS_MAX_BACKOFF" envDefault:"10m"`
+		VisibilityTimeout time.Duration `env:"JOBS_VISIBILITY_TIMEOUT" envDefault:"5m"`
+	}
+
+	// Recheck configures background rechecks of automatic verdicts. A fresh
+	// verdict expires after the interval of its status in RECHECK_AFTER and
+	// the domain_recheck cron job then queues a recheck, a status without
+	// one never expires.
+	// RECHECK_AFTER format: "suspicious:24h,scam:168h,verified:720h"
+	Recheck struct {
+		Disabled bool                     `env:"RECHECK_DISABLED" envDefault:"false"`
+		After    map[string]time.Duration `env:"RECHECK_AFTER" envDefault:"suspicious:24h,scam:168h,verified:720h"`
+		Schedule string                   `env:"RECHECK_SCHEDULE" envDefault:"* * * * *"`
+		Batch    int                      `env:"RECHECK_BATCH" envDefault:"100"`
+		Lease    time.Duration            `env:"RECHECK_LEASE" envDefault:"1h"`
+	}
+
+	// Outbound configures requests to user supplied hosts (pkg/safehttp).
+	// Private and loopback ranges are refused unless listed in OUTBOUND_ALLOW_CIDRS.
+	Outbound struct {
+		UserAgent  string         `env:"OUTBOUND_USER_AGENT"`
+		AllowCIDRs []netip.Prefix `env:"OUTBOUND_ALLOW_CIDRS" envSeparator:","`
+	}
+
+	// Checkers selects active checker modules by name.
+	// Empty CHECKERS_ENABLED means every registered module.
+	// CHECKER_TIMEOUTS format: "scamdetector:40s,dns:5s"
+	Checkers struct {
+		Enabled  []string                 `env:"CHECKERS_ENABLED" envSeparator:","`
+		Disabled []string                 `env:"CHECKERS_DISABLED" envSeparator:","`
+		Timeouts map[string]time.Duration `env:"CHECKER_TIMEOUTS"`
+	}
+
+	// DNS configures the dns checker module.
+	DNS struct {
+		Resolver          string        `env:"DNS_RESOLVER" envDefault:"1.1.1.1:53"`
+		Timeout           time.Duration `env:"DNS_TIMEOUT" envDefault:"3s"`
+		AbusedNameservers []string      `env:"DNS_ABUSED_NAMESERVERS" envSeparator:","`
+	}
+
+	// WHOIS configures the port 43 client of the domainage module.
+	// WHOIS_SERVERS format: "kz:whois.nic.kz,com:whois.verisign-grs.com"
+	WHOIS struct {
+		Timeout time.Duration     `env:"WHOIS_TIMEOUT" envDefault:"10s"`
+		Servers map[string]string `env:"WHOIS_SERVERS" envDefault:"kz:whois.nic.kz"`
+	}
+
+	// RDAP configures the RDAP client of the domainage module, it is asked
+	// before WHOIS. The bootstrap file is fetched from BootstrapURL unless
+	// a local BootstrapFile is set.
+	RDAP struct {
+		Disabled      bool          `env:"RDAP_DISABLED" envDefault:"false"`
+		BootstrapURL  string        `env:"RDAP_BOOTSTRAP_URL" envDefault:"https://data.iana.org/rdap/dns.json"`
+		BootstrapFile string        `env:"RDAP_BOOTSTRAP_FILE"`
+		Timeout       time.Duration `env:"RDAP_TIMEOUT" envDefault:"10s"`
+	}
+
+	// Content configures how the content module fetches landing pages.
+	Content struct {
+		Timeout time.Duration `env:"CONTENT_TIMEOUT" envDefault:"10s"`
+		MaxBody int64         `env:"CONTENT_MAX_BODY" envDefault:"1048576"`
+	}
+
+	// ScamDetector is the scamcheck-parser service (pkg/scamcheck-parser).
+	ScamDetector struct {
+		Host    string        `env:"SCAM_DETECTOR_HOST" envDefault:"localhost"`
+		Port    int           `env:"SCAM_DETECTOR_PORT" envDefault:"4000"`
+		Timeout time.Duration `env:"SCAM_DETECTOR_TIMEOUT" envDefault:"40s"`
+	}
 )
 
 func (c Postgres) GetDsn() string {
@@ -42,6 +174,10 @@ func (c Postgres) GetDsn() string {
 	)
 }
 
+func (c ScamDetector) GetBaseURL() string {
+	return fmt.Sprintf("http://%s:%d", c.Host, c.Port)
+}
+
 func New(path string) (Config, error) {
 	var config Config
 
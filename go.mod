@@ -6,7 +6,6 @@ toolchain go1.23.0
 
 require (
 	github.com/gin-gonic/gin v1.10.1
-	github.com/lib/pq v1.10.9
 	go.mongodb.org/mongo-driver v1.17.4
 )
 
@@ -41,7 +40,6 @@ require (
 	github.com/xdg-go/stringprep v1.0.4 // indirect
 	github.com/youmark/pkcs8 v0.0.0-20240726163527-a2c0da244d78 // indirect
 	golang.org/x/arch v0.8.0 // indirect
-	golang.org/x/net v0.33.0 // indirect
 	golang.org/x/sync v0.10.0 // indirect
 	golang.org/x/sys v0.29.0 // indirect
 	google.golang.org/protobuf v1.36.1 // indirect
@@ -55,5 +53,6 @@ require (
 	github.com/jackc/pgx/v5 v5.5.5
 	github.com/joho/godotenv v1.5.1
 	golang.org/x/crypto v0.32.0 // indirect
-	golang.org/x/text v0.21.0 // indirect
+	golang.org/x/net v0.33.0
+	golang.org/x/text v0.21.0
 )
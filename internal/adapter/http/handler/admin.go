@@ -2,7 +2,11 @@ package handler
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/ItsXomyak/scam-list/internal/adapter/http/handler/dto"
 	"github.com/ItsXomyak/scam-list/internal/domain/entity"
@@ -20,15 +24,23 @@ type DomainRepository interface {
 	DeleteDomain(ctx context.Context, domain string) error
 }
 
+// StatusPolicy derives domain status from risk_score.
+type StatusPolicy interface {
+	Resolve(requested string, score *float64, override bool) (status string, overridden bool, err error)
+	OverrideRecord(status string, score float64, by string, at time.Time) (json.RawMessage, error)
+}
+
 type AdminPanel struct {
-	domain DomainRepository
-	log    logger.Logger
+	domain   DomainRepository
+	statuses StatusPolicy
+	log      logger.Logger
 }
 
-func NewAdminPanel(domain DomainRepository, log logger.Logger) *AdminPanel {
+func NewAdminPanel(domain DomainRepository, statuses StatusPolicy, log logger.Logger) *AdminPanel {
 	return &AdminPanel{
-		domain: domain,
-		log:    log,
+		domain:   domain,
+		statuses: statuses,
+		log:      log,
 	}
 }
 
@@ -42,8 +54,8 @@ func (h *AdminPanel) CreateDomain(c *gin.Context) {
 		return
 	}
 
-	// Extracting domain from the request
-	cleanDomain, err := utils.ExtractDomain(req.Domain)
+	// Extracting domain from the request, international names are stored as punycode
+	cleanDomain, err := utils.NormalizeDomain(req.Domain)
 	if err != nil {
 		badRequestResponse(c, err.Error())
 		return
@@ -60,6 +72,17 @@ func (h *AdminPanel) CreateDomain(c *gin.Context) {
 		return
 	}
 
+	status, override, err := h.resolveStatus(ctx, createReq.Status, createReq.RiskScore, req.StatusOverride, createReq.VerifiedBy)
+	if err != nil {
+		h.statusErrorResponse(ctx, c, err)
+		return
+	}
+	createReq.Status = status
+	if override != nil {
+		createReq.Metadata = append(createReq.Metadata, override)
+		createReq.VerificationMethod = manualMethod()
+	}
+
 	r, err := h.domain.CreateDomain(ctx, createReq)
 	if err != nil {
 		h.log.Error(logger.ErrorCtx(ctx, err), "failed to create domain", err)
@@ -175,6 +198,25 @@ func (h *AdminPanel) PatchDomain(c *gin.Context) {
 		cur.Metadata = req.Metadata
 	}
 
+	// status follows risk_score unless the admin overrides it
+	if req.Status != nil || req.RiskScore != nil {
+		requested := ""
+		if req.Status != nil {
+			requested = *req.Status
+		}
+
+		status, override, err := h.resolveStatus(ctx, requested, cur.RiskScore, req.StatusOverride, cur.VerifiedBy)
+		if err != nil {
+			h.statusErrorResponse(ctx, c, err)
+			return
+		}
+		cur.Status = status
+		if override != nil {
+			cur.Metadata = append(cur.Metadata, override)
+			cur.VerificationMethod = manualMethod()
+		}
+	}
+
 	v := validator.New()
 	dto.ValidatePatchDomain(v, cur)
 
@@ -214,3 +256,42 @@ func (h *AdminPanel) DeleteDomain(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"status": "ok"})
 }
+
+// resolveStatus applies the status policy. An explicit override is returned
+// as a metadata entry, so it stays visible next to the module results.
+func (h *AdminPanel) resolveStatus(ctx context.Context, requested string, score *float64, override bool, verifiedBy *string) (string, json.RawMessage, error) {
+	status, overridden, err := h.statuses.Resolve(requested, score, override)
+	if err != nil || !overridden {
+		return status, nil, err
+	}
+
+	by := "admin"
+	if verifiedBy != nil && *verifiedBy != "" {
+		by = *verifiedBy
+	}
+
+	record, err := h.statuses.OverrideRecord(status, *score, by, time.Now())
+	if err != nil {
+		return "", nil, err
+	}
+	h.log.Info(ctx, "derived status overridden", "status", status, "risk_score", *score, "by", by)
+
+	return status, record, nil
+}
+
+func (h *AdminPanel) statusErrorResponse(ctx context.Context, c *gin.Context, err error) {
+	if errors.Is(err, entity.ErrStatusMismatch) || errors.Is(err, entity.ErrStatusRequired) {
+		badRequestResponse(c, map[string]string{"status": err.Error()})
+		return
+	}
+
+	h.log.Error(logger.ErrorCtx(ctx, err), "failed to resolve status", err)
+	internalErrorResponse(c, fmt.Sprintf("failed to resolve status: %v", err))
+}
+
+// manualMethod marks an overridden verdict as manual, so the pipeline does
+// not replace it. A method sent along with the override is ignored.
+func manualMethod() *string {
+	m := entity.VerificationManual
+	return &m
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+)
+
+// stubDomains knows auto.xyz with an automatic verdict and records writes.
+type stubDomains struct {
+	created []*entity.CreateDomainParams
+	updated []*entity.Domain
+}
+
+func (s *stubDomains) CreateDomain(_ context.Context, params *entity.CreateDomainParams) (*entity.Domain, error) {
+	s.created = append(s.created, params)
+	return &entity.Domain{Domain: params.Domain, Status: params.Status, VerificationMethod: params.VerificationMethod}, nil
+}
+
+func (s *stubDomains) GetAllDomains(context.Context) ([]*entity.Domain, error) {
+	return nil, nil
+}
+
+func (s *stubDomains) GetDomain(_ context.Context, domain string) (*entity.Domain, error) {
+	if domain != "auto.xyz" {
+		return nil, entity.ErrDomainNotFound
+	}
+	score, method := 80.0, entity.VerificationAutomatic
+	return &entity.Domain{Domain: domain, Status: entity.StatusScam, RiskScore: &score, VerificationMethod: &method}, nil
+}
+
+func (s *stubDomains) UpdateDomain(_ context.Context, updated *entity.Domain) (*entity.Domain, error) {
+	s.updated = append(s.updated, updated)
+	return updated, nil
+}
+
+func (s *stubDomains) DeleteDomain(context.Context, string) error {
+	return nil
+}
+
+// overridePolicy accepts any requested status when asked to override.
+type overridePolicy struct{}
+
+func (overridePolicy) Resolve(requested string, _ *float64, override bool) (string, bool, error) {
+	return requested, override, nil
+}
+
+func (overridePolicy) OverrideRecord(status string, _ float64, by string, _ time.Time) (json.RawMessage, error) {
+	return json.Marshal(map[string]string{"status_override": status, "by": by})
+}
+
+func newAdminRouter(domains DomainRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := NewAdminPanel(domains, overridePolicy{}, logger.InitLogger("handler-test", logger.LevelError))
+
+	r := gin.New()
+	r.POST("/admin/domain/create", h.CreateDomain)
+	r.PATCH("/admin/domain/:domain", h.PatchDomain)
+	return r
+}
+
+func TestStatusOverrideIsManual(t *testing.T) {
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+	}{
+		{
+			name:   "create",
+			method: http.MethodPost,
+			path:   "/admin/domain/create",
+			body:   `{"domain":"new.xyz","status":"verified","risk_score":80,"status_override":true,"verification_method":"automatic"}`,
+		},
+		{
+			name:   "patch",
+			method: http.MethodPatch,
+			path:   "/admin/domain/auto.xyz",
+			body:   `{"status":"verified","status_override":true,"verification_method":"automatic"}`,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			domains := &stubDomains{}
+			w := httptest.NewRecorder()
+			newAdminRouter(domains).ServeHTTP(w, httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body)))
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("code = %d; want %d, body %s", w.Code, http.StatusOK, w.Body)
+			}
+
+			var method *string
+			switch {
+			case len(domains.created) == 1:
+				method = domains.created[0].VerificationMethod
+			case len(domains.updated) == 1:
+				method = domains.updated[0].VerificationMethod
+			default:
+				t.Fatal("domain was not saved")
+			}
+			if method == nil || *method != entity.VerificationManual {
+				t.Errorf("verification_method = %v; want %q", method, entity.VerificationManual)
+			}
+		})
+	}
+}
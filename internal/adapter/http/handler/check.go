@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ItsXomyak/scam-list/internal/adapter/http/handler/dto"
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+)
+
+const (
+	defaultChecksLimit = 50
+	maxChecksLimit     = 500
+)
+
+type CheckRepository interface {
+	GetDomainChecks(ctx context.Context, arg *entity.GetDomainChecksParams) ([]*entity.DomainCheck, error)
+}
+
+type CheckQueue interface {
+	Enqueue(ctx context.Context, target, trigger string) (*entity.VerifyJob, error)
+}
+
+type Checks struct {
+	checks CheckRepository
+	queue  CheckQueue
+	log    logger.Logger
+}
+
+func NewChecks(checks CheckRepository, queue CheckQueue, log logger.Logger) *Checks {
+	return &Checks{
+		checks: checks,
+		queue:  queue,
+		log:    log,
+	}
+}
+
+// ListChecks returns the pipeline runs of a domain, newest first. The
+// started_at of the last check is passed as before to get the next page.
+func (h *Checks) ListChecks(c *gin.Context) {
+	ctx := logger.WithAction(c.Request.Context(), "admin_list_domain_checks")
+
+	domain, err := normalizeDomain(c.Param("domain"))
+	if err != nil {
+		badRequestResponse(c, err.Error())
+		return
+	}
+
+	limit, err := queryInt(c, "limit", defaultChecksLimit, 1, maxChecksLimit)
+	if err != nil {
+		badRequestResponse(c, err.Error())
+		return
+	}
+
+	arg := &entity.GetDomainChecksParams{
+		Domain: domain,
+		Limit:  int32(limit),
+	}
+	if raw := c.Query("before"); raw != "" {
+		before, err := time.Parse(time.RFC3339Nano, raw)
+		if err != nil {
+			badRequestResponse(c, "before must be an RFC 3339 time")
+			return
+		}
+		arg.Before = before
+	}
+
+	checks, err := h.checks.GetDomainChecks(ctx, arg)
+	if err != nil {
+		h.log.Error(logger.ErrorCtx(ctx, err), "failed to get domain checks", err, "domain", domain)
+		internalErrorResponse(c, "internal server error")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"checks": dto.ToDomainChecksResponse(checks),
+		"metadata": gin.H{
+			"total": len(checks),
+		},
+	})
+}
+
+// RunCheck queues a fresh run of the checkers for a domain, the run is
+// recorded with the admin trigger.
+func (h *Checks) RunCheck(c *gin.Context) {
+	ctx := logger.WithAction(c.Request.Context(), "admin_run_domain_check")
+
+	domain, err := normalizeDomain(c.Param("domain"))
+	if err != nil {
+		badRequestResponse(c, err.Error())
+		return
+	}
+
+	job, err := h.queue.Enqueue(ctx, domain, entity.CheckTriggerAdmin)
+	if err != nil {
+		h.log.Error(logger.ErrorCtx(ctx, err), "error queueing domain check", err, "domain", domain)
+		internalErrorResponse(c, "internal server error")
+		return
+	}
+
+	c.Header("Location", "/api/verify/jobs/"+job.ID)
+	c.JSON(http.StatusAccepted, gin.H{
+		"job": dto.ToVerifyJobResponse(job),
+	})
+}
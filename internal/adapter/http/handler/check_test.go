@@ -0,0 +1,176 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+)
+
+type stubChecks struct {
+	params  []*entity.GetDomainChecksParams
+	err     error
+	queued  []string
+	trigger string
+}
+
+func (s *stubChecks) GetDomainChecks(_ context.Context, arg *entity.GetDomainChecksParams) ([]*entity.DomainCheck, error) {
+	s.params = append(s.params, arg)
+	if s.err != nil {
+		return nil, s.err
+	}
+	score := 82.5
+	started := time.Date(2025, 3, 1, 12, 0, 0, 123456789, time.UTC)
+	return []*entity.DomainCheck{
+		{
+			ID:        "check-2",
+			Domain:    arg.Domain,
+			Trigger:   entity.CheckTriggerRecheck,
+			Status:    entity.StatusScam,
+			RiskScore: &score,
+			ModuleResults: []*entity.ModuleResult{
+				{ModuleName: "whois", Status: entity.ModuleStatusOK, RiskScore: 90},
+				{ModuleName: "blocklist", Status: entity.ModuleStatusTimeout, Error: "timed out after 15s"},
+			},
+			StartedAt:  started,
+			FinishedAt: started.Add(1500 * time.Millisecond),
+		},
+		{
+			ID:         "check-1",
+			Domain:     arg.Domain,
+			Trigger:    entity.CheckTriggerUser,
+			Status:     entity.StatusInsufficientData,
+			StartedAt:  started.Add(-24 * time.Hour),
+			FinishedAt: started.Add(-24 * time.Hour),
+		},
+	}, nil
+}
+
+func (s *stubChecks) Enqueue(_ context.Context, target, trigger string) (*entity.VerifyJob, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.queued = append(s.queued, target)
+	s.trigger = trigger
+	return &entity.VerifyJob{ID: "job-1", Target: target, Status: entity.JobStatusQueued, Trigger: trigger}, nil
+}
+
+func newChecksRouter(checks *stubChecks) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := NewChecks(checks, checks, logger.InitLogger("handler-test", logger.LevelError))
+
+	r := gin.New()
+	r.GET("/admin/domain/:domain/checks", h.ListChecks)
+	r.POST("/admin/domain/:domain/checks", h.RunCheck)
+	return r
+}
+
+func TestListChecks(t *testing.T) {
+	checks := &stubChecks{}
+	w := httptest.NewRecorder()
+	newChecksRouter(checks).ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+		"/admin/domain/Kaspi-Bonus.XYZ/checks?limit=2&before=2025-03-02T00:00:00.5Z", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("code = %d; want %d, body %s", w.Code, http.StatusOK, w.Body)
+	}
+
+	want := entity.GetDomainChecksParams{
+		Domain: "kaspi-bonus.xyz",
+		Before: time.Date(2025, 3, 2, 0, 0, 0, 500000000, time.UTC),
+		Limit:  2,
+	}
+	if got := checks.params[0]; got.Domain != want.Domain || !got.Before.Equal(want.Before) || got.Limit != want.Limit {
+		t.Errorf("GetDomainChecks() params = %+v; want %+v", got, want)
+	}
+
+	var body struct {
+		Checks []struct {
+			ID            string           `json:"id"`
+			RiskScore     *float64         `json:"risk_score"`
+			ModuleResults []map[string]any `json:"module_results"`
+			StartedAt     string           `json:"started_at"`
+			DurationMs    int64            `json:"duration_ms"`
+		} `json:"checks"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid body %s: %v", w.Body, err)
+	}
+	if len(body.Checks) != 2 {
+		t.Fatalf("checks = %d; want 2", len(body.Checks))
+	}
+	first := body.Checks[0]
+	if first.ID != "check-2" || first.RiskScore == nil || *first.RiskScore != 82.5 || first.DurationMs != 1500 {
+		t.Errorf("checks[0] = %+v", first)
+	}
+	if first.StartedAt != "2025-03-01T12:00:00.123456789Z" {
+		t.Errorf("started_at = %s; want nanoseconds for the next page cursor", first.StartedAt)
+	}
+	if len(first.ModuleResults) != 2 || first.ModuleResults[1]["error"] != "timed out after 15s" {
+		t.Errorf("module_results = %v; want the failed module with its error", first.ModuleResults)
+	}
+	if body.Checks[1].RiskScore != nil {
+		t.Errorf("checks[1].risk_score = %v; want null for insufficient data", *body.Checks[1].RiskScore)
+	}
+}
+
+func TestListChecksDefaults(t *testing.T) {
+	checks := &stubChecks{}
+	w := httptest.NewRecorder()
+	newChecksRouter(checks).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/domain/kaspi-bonus.xyz/checks", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("code = %d; want %d", w.Code, http.StatusOK)
+	}
+	if got := checks.params[0]; got.Limit != defaultChecksLimit || !got.Before.IsZero() {
+		t.Errorf("GetDomainChecks() params = %+v; want the default limit from the latest check", got)
+	}
+}
+
+func TestListChecksErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		err      error
+		wantCode int
+	}{
+		{name: "invalid domain", path: "/admin/domain/not_a_domain/checks", wantCode: http.StatusBadRequest},
+		{name: "zero limit", path: "/admin/domain/kaspi-bonus.xyz/checks?limit=0", wantCode: http.StatusBadRequest},
+		{name: "limit too big", path: "/admin/domain/kaspi-bonus.xyz/checks?limit=501", wantCode: http.StatusBadRequest},
+		{name: "invalid before", path: "/admin/domain/kaspi-bonus.xyz/checks?before=yesterday", wantCode: http.StatusBadRequest},
+		{name: "repository", path: "/admin/domain/kaspi-bonus.xyz/checks", err: errors.New("connection refused"), wantCode: http.StatusInternalServerError},
+	}
+
+	for _, tc := range tests {
+		w := httptest.NewRecorder()
+		newChecksRouter(&stubChecks{err: tc.err}).ServeHTTP(w, httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+		if w.Code != tc.wantCode {
+			t.Errorf("%s: code = %d; want %d", tc.name, w.Code, tc.wantCode)
+		}
+	}
+}
+
+func TestRunCheck(t *testing.T) {
+	checks := &stubChecks{}
+	w := httptest.NewRecorder()
+	newChecksRouter(checks).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/domain/kaspi-bonus.xyz/checks", nil))
+
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("code = %d; want %d", w.Code, http.StatusAccepted)
+	}
+	if len(checks.queued) != 1 || checks.trigger != entity.CheckTriggerAdmin {
+		t.Errorf("queued %v with trigger %q; want one admin check", checks.queued, checks.trigger)
+	}
+	if loc := w.Header().Get("Location"); loc != "/api/verify/jobs/job-1" {
+		t.Errorf("Location = %q", loc)
+	}
+}
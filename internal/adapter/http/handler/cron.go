@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ItsXomyak/scam-list/internal/adapter/http/handler/dto"
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+)
+
+type CronScheduler interface {
+	Jobs(ctx context.Context) ([]*entity.CronJob, error)
+	Trigger(ctx context.Context, name string) error
+}
+
+type Cron struct {
+	cron CronScheduler
+	log  logger.Logger
+}
+
+func NewCron(cron CronScheduler, log logger.Logger) *Cron {
+	return &Cron{
+		cron: cron,
+		log:  log,
+	}
+}
+
+// ListJobs returns the scheduled jobs with their last runs.
+func (h *Cron) ListJobs(c *gin.Context) {
+	ctx := logger.WithAction(c.Request.Context(), "admin_list_cron_jobs")
+
+	jobs, err := h.cron.Jobs(ctx)
+	if err != nil {
+		h.log.Error(logger.ErrorCtx(ctx, err), "failed to list cron jobs", err)
+		internalErrorResponse(c, "internal server error")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"jobs": dto.ToCronJobsResponse(jobs),
+	})
+}
+
+// RunJob starts a job out of schedule. The run is recorded like a
+// scheduled one and shows up in ListJobs.
+func (h *Cron) RunJob(c *gin.Context) {
+	ctx := logger.WithAction(c.Request.Context(), "admin_run_cron_job")
+
+	name := c.Param("name")
+	err := h.cron.Trigger(ctx, name)
+	switch {
+	case errors.Is(err, entity.ErrCronJobNotFound):
+		notFoundResponse(c, "cron job not found")
+		return
+	case errors.Is(err, entity.ErrCronJobRunning):
+		errorResponse(c, http.StatusConflict, "cron job is already running")
+		return
+	case err != nil:
+		h.log.Error(logger.ErrorCtx(ctx, err), "failed to run cron job", err, "job", name)
+		internalErrorResponse(c, "internal server error")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"status": "started"})
+}
@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+)
+
+type stubCron struct {
+	triggered []string
+}
+
+func (s *stubCron) Jobs(context.Context) ([]*entity.CronJob, error) {
+	finished := time.Date(2025, 3, 1, 12, 0, 5, 0, time.UTC)
+	return []*entity.CronJob{
+		{Name: "domain_recheck", Schedule: "* * * * *", NextRun: time.Date(2025, 3, 1, 12, 1, 0, 0, time.UTC)},
+		{Name: "feed_refresh:openphish", Schedule: "@every 6h0m0s", LastRun: &entity.CronRun{
+			Name:       "feed_refresh:openphish",
+			Trigger:    entity.CronTriggerManual,
+			Status:     entity.CronStatusFailed,
+			StartedAt:  time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC),
+			FinishedAt: &finished,
+			Error:      "openphish: feed has no valid entries",
+			Instance:   "api-1/7",
+		}},
+	}, nil
+}
+
+func (s *stubCron) Trigger(_ context.Context, name string) error {
+	switch name {
+	case "domain_recheck":
+		s.triggered = append(s.triggered, name)
+		return nil
+	case "feed_refresh:openphish":
+		return entity.ErrCronJobRunning
+	default:
+		return entity.ErrCronJobNotFound
+	}
+}
+
+func newCronRouter(cron CronScheduler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := NewCron(cron, logger.InitLogger("handler-test", logger.LevelError))
+
+	r := gin.New()
+	r.GET("/admin/cron", h.ListJobs)
+	r.POST("/admin/cron/:name/run", h.RunJob)
+	return r
+}
+
+func TestListCronJobs(t *testing.T) {
+	w := httptest.NewRecorder()
+	newCronRouter(&stubCron{}).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/cron", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("code = %d; want %d", w.Code, http.StatusOK)
+	}
+
+	var body struct {
+		Jobs []struct {
+			Name    string          `json:"name"`
+			NextRun string          `json:"next_run"`
+			LastRun json.RawMessage `json:"last_run"`
+		} `json:"jobs"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid body %s: %v", w.Body, err)
+	}
+	if len(body.Jobs) != 2 {
+		t.Fatalf("jobs = %d; want 2", len(body.Jobs))
+	}
+	if body.Jobs[0].NextRun != "2025-03-01T12:01:00Z" || string(body.Jobs[0].LastRun) != "null" {
+		t.Errorf("jobs[0] = %+v; want next run and no last run", body.Jobs[0])
+	}
+
+	var last map[string]any
+	if err := json.Unmarshal(body.Jobs[1].LastRun, &last); err != nil {
+		t.Fatalf("invalid last_run %s: %v", body.Jobs[1].LastRun, err)
+	}
+	if last["status"] != entity.CronStatusFailed || last["error"] == nil || last["finished_at"] != "2025-03-01T12:00:05Z" {
+		t.Errorf("last_run = %v", last)
+	}
+}
+
+func TestRunCronJob(t *testing.T) {
+	tests := []struct {
+		name     string
+		wantCode int
+	}{
+		{name: "domain_recheck", wantCode: http.StatusAccepted},
+		{name: "feed_refresh:openphish", wantCode: http.StatusConflict},
+		{name: "unknown", wantCode: http.StatusNotFound},
+	}
+
+	for _, tc := range tests {
+		cron := &stubCron{}
+		w := httptest.NewRecorder()
+		newCronRouter(cron).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/cron/"+tc.name+"/run", nil))
+
+		if w.Code != tc.wantCode {
+			t.Errorf("POST %s: code = %d; want %d", tc.name, w.Code, tc.wantCode)
+		}
+	}
+}
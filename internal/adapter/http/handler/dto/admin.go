@@ -19,6 +19,9 @@ type CreateDomainRequest struct {
 	RiskScore          *float64          `json:"risk_score,omitempty"`
 	Reasons            []string          `json:"reasons,omitempty"`
 	Metadata           []json.RawMessage `json:"metadata,omitempty"`
+
+	// StatusOverride stores Status even if risk_score gives another one.
+	StatusOverride bool `json:"status_override,omitempty"`
 }
 
 type DomainResponse struct {
@@ -48,6 +51,9 @@ type UpdateDomainRequest struct {
 	RiskScore          *float64          `json:"risk_score,omitempty"`
 	Reasons            []string          `json:"reasons,omitempty"`
 	Metadata           []json.RawMessage `json:"metadata,omitempty"` // или *[]byte[] если оставляешь [][]byte
+
+	// StatusOverride stores Status even if risk_score gives another one.
+	StatusOverride bool `json:"status_override,omitempty"`
 }
 
 func FromCreateRequestToInternal(req *CreateDomainRequest) *entity.CreateDomainParams {
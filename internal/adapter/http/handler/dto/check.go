@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+)
+
+type DomainCheckResponse struct {
+	ID            string                 `json:"id"`
+	Domain        string                 `json:"domain"`
+	Trigger       string                 `json:"trigger"`
+	Status        string                 `json:"status"`
+	RiskScore     *float64               `json:"risk_score"`
+	Reasons       []string               `json:"reasons"`
+	ModuleResults []*entity.ModuleResult `json:"module_results"`
+	// with nanoseconds, it is the before cursor of the next page
+	StartedAt  string `json:"started_at"`
+	FinishedAt string `json:"finished_at"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+func ToDomainChecksResponse(checks []*entity.DomainCheck) []*DomainCheckResponse {
+	out := make([]*DomainCheckResponse, 0, len(checks))
+	for _, c := range checks {
+		out = append(out, &DomainCheckResponse{
+			ID:            c.ID,
+			Domain:        c.Domain,
+			Trigger:       c.Trigger,
+			Status:        c.Status,
+			RiskScore:     c.RiskScore,
+			Reasons:       c.Reasons,
+			ModuleResults: c.ModuleResults,
+			StartedAt:     c.StartedAt.Format(time.RFC3339Nano),
+			FinishedAt:    c.FinishedAt.Format(time.RFC3339Nano),
+			DurationMs:    c.FinishedAt.Sub(c.StartedAt).Milliseconds(),
+		})
+	}
+	return out
+}
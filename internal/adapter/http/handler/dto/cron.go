@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+)
+
+type CronJobResponse struct {
+	Name     string           `json:"name"`
+	Schedule string           `json:"schedule"`
+	NextRun  string           `json:"next_run"`
+	Running  bool             `json:"running"`
+	LastRun  *CronRunResponse `json:"last_run"`
+}
+
+type CronRunResponse struct {
+	Trigger     string  `json:"trigger"`
+	Status      string  `json:"status"`
+	ScheduledAt *string `json:"scheduled_at,omitempty"`
+	StartedAt   string  `json:"started_at"`
+	FinishedAt  *string `json:"finished_at,omitempty"`
+	Error       string  `json:"error,omitempty"`
+	Instance    string  `json:"instance"`
+}
+
+func ToCronJobsResponse(jobs []*entity.CronJob) []*CronJobResponse {
+	out := make([]*CronJobResponse, 0, len(jobs))
+	for _, j := range jobs {
+		res := &CronJobResponse{
+			Name:     j.Name,
+			Schedule: j.Schedule,
+			NextRun:  j.NextRun.Format(time.RFC3339),
+			Running:  j.Running,
+		}
+		if r := j.LastRun; r != nil {
+			res.LastRun = &CronRunResponse{
+				Trigger:     r.Trigger,
+				Status:      r.Status,
+				ScheduledAt: formatTime(r.ScheduledAt),
+				StartedAt:   r.StartedAt.Format(time.RFC3339),
+				FinishedAt:  formatTime(r.FinishedAt),
+				Error:       r.Error,
+				Instance:    r.Instance,
+			}
+		}
+		out = append(out, res)
+	}
+	return out
+}
@@ -7,6 +7,8 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
 )
 
 // HTTPError represent http error response
@@ -43,6 +45,8 @@ func FromError(err error) *HTTPError {
 	switch {
 	case errors.Is(err, sql.ErrNoRows) || errors.Is(err, pgx.ErrNoRows):
 		return ErrResourceNotFoundResponse
+	case errors.Is(err, entity.ErrDomainNotFound):
+		return ErrResourceNotFoundResponse
 	}
 
 	var pgErr *pgconn.PgError
@@ -0,0 +1,58 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+)
+
+// jobFailedMessage replaces the internal error of a failed job in responses.
+const jobFailedMessage = "verification failed, try again later"
+
+// VerifyJobRequest takes either a domain or a full link.
+type VerifyJobRequest struct {
+	Domain string `json:"domain,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+type VerifyJobResponse struct {
+	ID         string                     `json:"id"`
+	Target     string                     `json:"target"`
+	Status     string                     `json:"status"`
+	Result     *entity.VerifyDomainResult `json:"result,omitempty"`
+	Error      string                     `json:"error,omitempty"`
+	Attempts   int                        `json:"attempts"`
+	CreatedAt  string                     `json:"created_at"`
+	StartedAt  *string                    `json:"started_at,omitempty"`
+	FinishedAt *string                    `json:"finished_at,omitempty"`
+}
+
+func ToVerifyJobResponse(j *entity.VerifyJob) *VerifyJobResponse {
+	if j == nil {
+		return nil
+	}
+
+	res := &VerifyJobResponse{
+		ID:         j.ID,
+		Target:     j.Target,
+		Status:     j.Status,
+		Result:     j.Result,
+		Attempts:   j.Attempts,
+		CreatedAt:  j.CreatedAt.Format(time.RFC3339),
+		StartedAt:  formatTime(j.StartedAt),
+		FinishedAt: formatTime(j.FinishedAt),
+	}
+	if j.Status == entity.JobStatusDead {
+		res.Error = jobFailedMessage
+	}
+
+	return res
+}
+
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(time.RFC3339)
+	return &s
+}
@@ -0,0 +1,106 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/validator"
+)
+
+var (
+	ValidModerationStatus     = []string{entity.ModerationStatusPending, entity.ModerationStatusInProgress, entity.ModerationStatusResolved}
+	ValidModerationResolution = []string{entity.StatusVerified, entity.StatusScam}
+)
+
+type ClaimModerationRequest struct {
+	Moderator string `json:"moderator"`
+}
+
+type ResolveModerationRequest struct {
+	Moderator string  `json:"moderator"`
+	Status    string  `json:"status"` // verified or scam
+	Notes     *string `json:"notes,omitempty"`
+}
+
+type ModerationPriorityRequest struct {
+	Priority *int `json:"priority"`
+}
+
+type ModerationTaskResponse struct {
+	Domain        string   `json:"domain"`
+	CheckID       *string  `json:"check_id"`
+	Status        string   `json:"status"`
+	Priority      int      `json:"priority"`
+	Reasons       []string `json:"reasons"`
+	SourceModules []string `json:"source_modules"`
+	AssignedTo    *string  `json:"assigned_to"`
+	Resolution    *string  `json:"resolution"`
+	ResolvedBy    *string  `json:"resolved_by"`
+	Notes         *string  `json:"notes"`
+	RiskScore     *float64 `json:"risk_score"`
+	CompanyName   *string  `json:"company_name"`
+	Country       *string  `json:"country"`
+	SubmittedAt   string   `json:"submitted_at"`
+	ClaimedAt     *string  `json:"claimed_at"`
+	ResolvedAt    *string  `json:"resolved_at"`
+	UpdatedAt     string   `json:"updated_at"`
+}
+
+func ValidateClaimModeration(v *validator.Validator, r *ClaimModerationRequest) {
+	validateModerator(v, r.Moderator)
+}
+
+func ValidateResolveModeration(v *validator.Validator, r *ResolveModerationRequest) {
+	validateModerator(v, r.Moderator)
+	v.Check(validator.PermittedValue(r.Status, ValidModerationResolution...), "status",
+		fmt.Sprintf("invalid status, available: %s", strings.Join(ValidModerationResolution, ", ")))
+}
+
+func ValidateModerationPriority(v *validator.Validator, r *ModerationPriorityRequest) {
+	v.Check(r.Priority != nil, "priority", "must be provided")
+	if r.Priority != nil {
+		v.Check(*r.Priority >= entity.ModerationPriorityHighest && *r.Priority <= entity.ModerationPriorityLowest, "priority",
+			fmt.Sprintf("must be between %d and %d", entity.ModerationPriorityHighest, entity.ModerationPriorityLowest))
+	}
+}
+
+func validateModerator(v *validator.Validator, moderator string) {
+	v.Check(moderator != "", "moderator", "must be provided")
+	v.Check(len(moderator) <= 100, "moderator", "must be at most 100 characters")
+}
+
+func ToModerationTasksResponse(tasks []*entity.ModerationTask) []*ModerationTaskResponse {
+	out := make([]*ModerationTaskResponse, 0, len(tasks))
+	for _, t := range tasks {
+		out = append(out, ToModerationTaskResponse(t))
+	}
+	return out
+}
+
+func ToModerationTaskResponse(t *entity.ModerationTask) *ModerationTaskResponse {
+	if t == nil {
+		return nil
+	}
+
+	return &ModerationTaskResponse{
+		Domain:        t.Domain,
+		CheckID:       t.CheckID,
+		Status:        t.Status,
+		Priority:      t.Priority,
+		Reasons:       t.Reasons,
+		SourceModules: t.SourceModules,
+		AssignedTo:    t.AssignedTo,
+		Resolution:    t.Resolution,
+		ResolvedBy:    t.ResolvedBy,
+		Notes:         t.Notes,
+		RiskScore:     t.RiskScore,
+		CompanyName:   t.CompanyName,
+		Country:       t.Country,
+		SubmittedAt:   t.SubmittedAt.Format(time.RFC3339),
+		ClaimedAt:     formatTime(t.ClaimedAt),
+		ResolvedAt:    formatTime(t.ResolvedAt),
+		UpdatedAt:     t.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -16,8 +16,10 @@ var (
 )
 
 func ValidateCreateDomain(v *validator.Validator, r *entity.CreateDomainParams) {
-	validateDomain(v, r.Domain)      // required
-	validateStatusValue(v, r.Status) // required
+	validateDomain(v, r.Domain) // required
+	if r.Status != "" || r.RiskScore == nil {
+		validateStatusValue(v, r.Status) // required unless derived from risk_score
+	}
 
 	validateCompanyName(v, r.CompanyName)               // optional
 	validateCountry(v, r.Country)                       // optional
@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -23,3 +25,16 @@ func notFoundResponse(c *gin.Context, message any) {
 func badRequestResponse(c *gin.Context, message any) {
 	errorResponse(c, http.StatusBadRequest, message)
 }
+
+// queryInt reads an optional integer query parameter within [lo, hi].
+func queryInt(c *gin.Context, name string, def, lo, hi int) (int, error) {
+	raw := c.Query(name)
+	if raw == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < lo || n > hi {
+		return 0, fmt.Errorf("%s must be a number between %d and %d", name, lo, hi)
+	}
+	return n, nil
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ItsXomyak/scam-list/internal/adapter/http/handler/dto"
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+)
+
+var jobIDRe = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+type JobService interface {
+	Submit(ctx context.Context, target string) (*entity.VerifyJob, error)
+	Get(ctx context.Context, id string) (*entity.VerifyJob, error)
+}
+
+type Jobs struct {
+	jobs JobService
+	log  logger.Logger
+}
+
+func NewJobs(jobs JobService, log logger.Logger) *Jobs {
+	return &Jobs{
+		jobs: jobs,
+		log:  log,
+	}
+}
+
+// SubmitVerify queues a verification and returns its job right away.
+// The result is polled from GET /api/verify/jobs/:id.
+func (h *Jobs) SubmitVerify(c *gin.Context) {
+	ctx := logger.WithAction(c.Request.Context(), "handler_submit_verify")
+
+	req := &dto.VerifyJobRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		badRequestResponse(c, err.Error())
+		return
+	}
+
+	target, err := jobTarget(req)
+	if err != nil {
+		badRequestResponse(c, err.Error())
+		return
+	}
+
+	job, err := h.jobs.Submit(ctx, target)
+	if err != nil {
+		h.log.Error(logger.ErrorCtx(ctx, err), "error queueing verify job", err, "target", target)
+		internalErrorResponse(c, "internal server error")
+		return
+	}
+
+	c.Header("Location", "/api/verify/jobs/"+job.ID)
+	c.JSON(http.StatusAccepted, gin.H{
+		"job": dto.ToVerifyJobResponse(job),
+	})
+}
+
+// GetVerifyJob returns the job status and, once it is done, the verdict.
+func (h *Jobs) GetVerifyJob(c *gin.Context) {
+	ctx := logger.WithAction(c.Request.Context(), "handler_get_verify_job")
+
+	id := c.Param("id")
+	if !jobIDRe.MatchString(id) {
+		badRequestResponse(c, "invalid job id")
+		return
+	}
+
+	job, err := h.jobs.Get(ctx, id)
+	switch {
+	case errors.Is(err, entity.ErrJobNotFound):
+		notFoundResponse(c, "job not found")
+		return
+	case err != nil:
+		h.log.Error(logger.ErrorCtx(ctx, err), "error getting verify job", err, "job_id", id)
+		internalErrorResponse(c, "internal server error")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"job": dto.ToVerifyJobResponse(job),
+	})
+}
+
+// jobTarget normalizes the domain or URL of a request, exactly one must be set.
+func jobTarget(req *dto.VerifyJobRequest) (string, error) {
+	switch {
+	case req.Domain != "" && req.URL != "":
+		return "", errors.New("provide either domain or url, not both")
+	case req.URL != "":
+		return normalizeURL(req.URL)
+	default:
+		return normalizeDomain(req.Domain)
+	}
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ItsXomyak/scam-list/internal/adapter/http/handler/dto"
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+)
+
+const testJobID = "0b6a3e4c-8d2f-4a51-9c77-1f0e2d3c4b5a"
+
+type stubJobs struct {
+	submitted string
+}
+
+func (s *stubJobs) Submit(_ context.Context, target string) (*entity.VerifyJob, error) {
+	s.submitted = target
+	return &entity.VerifyJob{ID: testJobID, Target: target, Status: entity.JobStatusQueued, CreatedAt: time.Now()}, nil
+}
+
+func (s *stubJobs) Get(_ context.Context, id string) (*entity.VerifyJob, error) {
+	if id != testJobID {
+		return nil, entity.ErrJobNotFound
+	}
+	return &entity.VerifyJob{ID: id, Status: entity.JobStatusDead, Error: "pq: connection refused", CreatedAt: time.Now()}, nil
+}
+
+func newJobsRouter(jobs JobService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := NewJobs(jobs, logger.InitLogger("handler-test", logger.LevelError))
+
+	r := gin.New()
+	r.POST("/api/verify", h.SubmitVerify)
+	r.GET("/api/verify/jobs/:id", h.GetVerifyJob)
+	return r
+}
+
+func TestSubmitVerify(t *testing.T) {
+	tests := []struct {
+		body       string
+		wantCode   int
+		wantTarget string
+	}{
+		{body: `{"domain": "Kaspi-Bonus.xyz"}`, wantCode: http.StatusAccepted, wantTarget: "kaspi-bonus.xyz"},
+		{body: `{"url": "bit.ly/abc"}`, wantCode: http.StatusAccepted, wantTarget: "https://bit.ly/abc"},
+		{body: `{"domain": "a.kz", "url": "https://b.kz"}`, wantCode: http.StatusBadRequest},
+		{body: `{}`, wantCode: http.StatusBadRequest},
+		{body: `{"domain": "127.0.0.1"}`, wantCode: http.StatusBadRequest},
+		{body: `not json`, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tc := range tests {
+		jobs := &stubJobs{}
+		w := httptest.NewRecorder()
+		newJobsRouter(jobs).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/verify", strings.NewReader(tc.body)))
+
+		if w.Code != tc.wantCode {
+			t.Errorf("POST %s: code = %d; want %d", tc.body, w.Code, tc.wantCode)
+			continue
+		}
+		if jobs.submitted != tc.wantTarget {
+			t.Errorf("POST %s: submitted %q; want %q", tc.body, jobs.submitted, tc.wantTarget)
+		}
+		if tc.wantCode == http.StatusAccepted && w.Header().Get("Location") != "/api/verify/jobs/"+testJobID {
+			t.Errorf("POST %s: Location = %q", tc.body, w.Header().Get("Location"))
+		}
+	}
+}
+
+func TestGetVerifyJob(t *testing.T) {
+	tests := []struct {
+		id       string
+		wantCode int
+	}{
+		{id: testJobID, wantCode: http.StatusOK},
+		{id: "7f1d2c3b-0000-4000-8000-000000000000", wantCode: http.StatusNotFound},
+		{id: "not-a-uuid", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tc := range tests {
+		w := httptest.NewRecorder()
+		newJobsRouter(&stubJobs{}).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/verify/jobs/"+tc.id, nil))
+
+		if w.Code != tc.wantCode {
+			t.Errorf("GET %s: code = %d; want %d", tc.id, w.Code, tc.wantCode)
+		}
+	}
+}
+
+func TestVerifyJobResponseHidesError(t *testing.T) {
+	job := &entity.VerifyJob{ID: testJobID, Status: entity.JobStatusDead, Error: "pq: connection refused"}
+
+	if got := dto.ToVerifyJobResponse(job).Error; strings.Contains(got, "pq") || got == "" {
+		t.Errorf("Error = %q; want a generic message", got)
+	}
+}
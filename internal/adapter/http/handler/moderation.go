@@ -0,0 +1,220 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ItsXomyak/scam-list/internal/adapter/http/handler/dto"
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+	"github.com/ItsXomyak/scam-list/pkg/validator"
+)
+
+const (
+	defaultModerationLimit = 50
+	maxModerationLimit     = 500
+)
+
+type ModerationService interface {
+	List(ctx context.Context, arg *entity.GetModerationTasksParams) ([]*entity.ModerationTask, error)
+	Get(ctx context.Context, domain string) (*entity.ModerationTask, error)
+	Claim(ctx context.Context, domain, moderator string) (*entity.ModerationTask, error)
+	Resolve(ctx context.Context, arg *entity.ResolveModerationTaskParams) (*entity.ModerationTask, error)
+	SetPriority(ctx context.Context, domain string, priority int) (*entity.ModerationTask, error)
+}
+
+type Moderation struct {
+	moderation ModerationService
+	log        logger.Logger
+}
+
+func NewModeration(moderation ModerationService, log logger.Logger) *Moderation {
+	return &Moderation{
+		moderation: moderation,
+		log:        log,
+	}
+}
+
+// ListTasks returns the open tasks most urgent first, or the tasks with
+// the given status. assigned_to narrows them to one moderator.
+func (h *Moderation) ListTasks(c *gin.Context) {
+	ctx := logger.WithAction(c.Request.Context(), "admin_list_moderation_tasks")
+
+	arg := &entity.GetModerationTasksParams{
+		Status:     c.Query("status"),
+		AssignedTo: c.Query("assigned_to"),
+	}
+	if arg.Status != "" && !validator.PermittedValue(arg.Status, dto.ValidModerationStatus...) {
+		badRequestResponse(c, fmt.Sprintf("invalid status, available: %s", strings.Join(dto.ValidModerationStatus, ", ")))
+		return
+	}
+
+	limit, err := queryInt(c, "limit", defaultModerationLimit, 1, maxModerationLimit)
+	if err != nil {
+		badRequestResponse(c, err.Error())
+		return
+	}
+	offset, err := queryInt(c, "offset", 0, 0, math.MaxInt32)
+	if err != nil {
+		badRequestResponse(c, err.Error())
+		return
+	}
+	arg.Limit, arg.Offset = int32(limit), int32(offset)
+
+	tasks, err := h.moderation.List(ctx, arg)
+	if err != nil {
+		h.log.Error(logger.ErrorCtx(ctx, err), "failed to list moderation tasks", err)
+		internalErrorResponse(c, "internal server error")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tasks": dto.ToModerationTasksResponse(tasks),
+		"metadata": gin.H{
+			"total": len(tasks),
+		},
+	})
+}
+
+func (h *Moderation) GetTask(c *gin.Context) {
+	ctx := logger.WithAction(c.Request.Context(), "admin_get_moderation_task")
+
+	domain, err := normalizeDomain(c.Param("domain"))
+	if err != nil {
+		badRequestResponse(c, err.Error())
+		return
+	}
+
+	task, err := h.moderation.Get(ctx, domain)
+	if err != nil {
+		h.errorResponse(ctx, c, err, domain)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"task": dto.ToModerationTaskResponse(task),
+	})
+}
+
+// ClaimTask assigns a pending task to the moderator, a task claimed by
+// someone else gives 409.
+func (h *Moderation) ClaimTask(c *gin.Context) {
+	ctx := logger.WithAction(c.Request.Context(), "admin_claim_moderation_task")
+
+	domain, err := normalizeDomain(c.Param("domain"))
+	if err != nil {
+		badRequestResponse(c, err.Error())
+		return
+	}
+
+	req := &dto.ClaimModerationRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		badRequestResponse(c, err.Error())
+		return
+	}
+	v := validator.New()
+	if dto.ValidateClaimModeration(v, req); !v.Valid() {
+		badRequestResponse(c, v.Errors)
+		return
+	}
+
+	task, err := h.moderation.Claim(ctx, domain, req.Moderator)
+	if err != nil {
+		h.errorResponse(ctx, c, err, domain)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"task": dto.ToModerationTaskResponse(task),
+	})
+}
+
+// ResolveTask closes a task claimed by the moderator and stores the
+// verdict as a manual one.
+func (h *Moderation) ResolveTask(c *gin.Context) {
+	ctx := logger.WithAction(c.Request.Context(), "admin_resolve_moderation_task")
+
+	domain, err := normalizeDomain(c.Param("domain"))
+	if err != nil {
+		badRequestResponse(c, err.Error())
+		return
+	}
+
+	req := &dto.ResolveModerationRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		badRequestResponse(c, err.Error())
+		return
+	}
+	v := validator.New()
+	if dto.ValidateResolveModeration(v, req); !v.Valid() {
+		badRequestResponse(c, v.Errors)
+		return
+	}
+
+	task, err := h.moderation.Resolve(ctx, &entity.ResolveModerationTaskParams{
+		Domain:     domain,
+		Moderator:  req.Moderator,
+		Resolution: req.Status,
+		Notes:      req.Notes,
+	})
+	if err != nil {
+		h.errorResponse(ctx, c, err, domain)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"task": dto.ToModerationTaskResponse(task),
+	})
+}
+
+// SetPriority re-prioritises an open task.
+func (h *Moderation) SetPriority(c *gin.Context) {
+	ctx := logger.WithAction(c.Request.Context(), "admin_set_moderation_priority")
+
+	domain, err := normalizeDomain(c.Param("domain"))
+	if err != nil {
+		badRequestResponse(c, err.Error())
+		return
+	}
+
+	req := &dto.ModerationPriorityRequest{}
+	if err := c.ShouldBindJSON(req); err != nil {
+		badRequestResponse(c, err.Error())
+		return
+	}
+	v := validator.New()
+	if dto.ValidateModerationPriority(v, req); !v.Valid() {
+		badRequestResponse(c, v.Errors)
+		return
+	}
+
+	task, err := h.moderation.SetPriority(ctx, domain, *req.Priority)
+	if err != nil {
+		h.errorResponse(ctx, c, err, domain)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"task": dto.ToModerationTaskResponse(task),
+	})
+}
+
+func (h *Moderation) errorResponse(ctx context.Context, c *gin.Context, err error, domain string) {
+	switch {
+	case errors.Is(err, entity.ErrModerationNotFound):
+		notFoundResponse(c, err.Error())
+	case errors.Is(err, entity.ErrModerationClaimed),
+		errors.Is(err, entity.ErrModerationNotClaimed),
+		errors.Is(err, entity.ErrModerationResolved):
+		errorResponse(c, http.StatusConflict, err.Error())
+	default:
+		h.log.Error(logger.ErrorCtx(ctx, err), "moderation request failed", err, "domain", domain)
+		internalErrorResponse(c, "internal server error")
+	}
+}
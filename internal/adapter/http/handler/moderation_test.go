@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+)
+
+// stubModeration knows pending.xyz, taken.xyz claimed by alice and done.xyz.
+type stubModeration struct {
+	listed   []*entity.GetModerationTasksParams
+	resolved []*entity.ResolveModerationTaskParams
+}
+
+func (s *stubModeration) task(domain string) (*entity.ModerationTask, error) {
+	alice := "alice"
+	switch domain {
+	case "pending.xyz":
+		return &entity.ModerationTask{Domain: domain, Status: entity.ModerationStatusPending, Priority: 4, SubmittedAt: time.Now()}, nil
+	case "taken.xyz":
+		return &entity.ModerationTask{Domain: domain, Status: entity.ModerationStatusInProgress, AssignedTo: &alice}, nil
+	case "done.xyz":
+		return &entity.ModerationTask{Domain: domain, Status: entity.ModerationStatusResolved}, nil
+	default:
+		return nil, entity.ErrModerationNotFound
+	}
+}
+
+func (s *stubModeration) List(_ context.Context, arg *entity.GetModerationTasksParams) ([]*entity.ModerationTask, error) {
+	s.listed = append(s.listed, arg)
+	task, _ := s.task("pending.xyz")
+	return []*entity.ModerationTask{task}, nil
+}
+
+func (s *stubModeration) Get(_ context.Context, domain string) (*entity.ModerationTask, error) {
+	return s.task(domain)
+}
+
+func (s *stubModeration) Claim(_ context.Context, domain, moderator string) (*entity.ModerationTask, error) {
+	task, err := s.task(domain)
+	switch {
+	case err != nil:
+		return nil, err
+	case task.Status == entity.ModerationStatusResolved:
+		return nil, entity.ErrModerationResolved
+	case task.AssignedTo != nil && *task.AssignedTo != moderator:
+		return nil, entity.ErrModerationClaimed
+	}
+	task.Status, task.AssignedTo = entity.ModerationStatusInProgress, &moderator
+	return task, nil
+}
+
+func (s *stubModeration) Resolve(_ context.Context, arg *entity.ResolveModerationTaskParams) (*entity.ModerationTask, error) {
+	task, err := s.task(arg.Domain)
+	switch {
+	case err != nil:
+		return nil, err
+	case task.AssignedTo == nil || *task.AssignedTo != arg.Moderator:
+		return nil, entity.ErrModerationNotClaimed
+	}
+	s.resolved = append(s.resolved, arg)
+	task.Status, task.Resolution = entity.ModerationStatusResolved, &arg.Resolution
+	return task, nil
+}
+
+func (s *stubModeration) SetPriority(_ context.Context, domain string, priority int) (*entity.ModerationTask, error) {
+	task, err := s.task(domain)
+	if err != nil {
+		return nil, err
+	}
+	task.Priority = priority
+	return task, nil
+}
+
+func newModerationRouter(moderation ModerationService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	h := NewModeration(moderation, logger.InitLogger("handler-test", logger.LevelError))
+
+	r := gin.New()
+	r.GET("/admin/moderation", h.ListTasks)
+	r.GET("/admin/moderation/:domain", h.GetTask)
+	r.POST("/admin/moderation/:domain/claim", h.ClaimTask)
+	r.POST("/admin/moderation/:domain/resolve", h.ResolveTask)
+	r.PATCH("/admin/moderation/:domain/priority", h.SetPriority)
+	return r
+}
+
+func TestListModerationTasks(t *testing.T) {
+	moderation := &stubModeration{}
+	w := httptest.NewRecorder()
+	newModerationRouter(moderation).ServeHTTP(w, httptest.NewRequest(http.MethodGet,
+		"/admin/moderation?status=in_progress&assigned_to=alice&limit=10&offset=20", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("code = %d; want %d, body %s", w.Code, http.StatusOK, w.Body)
+	}
+	want := entity.GetModerationTasksParams{Status: entity.ModerationStatusInProgress, AssignedTo: "alice", Limit: 10, Offset: 20}
+	if got := moderation.listed[0]; *got != want {
+		t.Errorf("List() params = %+v; want %+v", *got, want)
+	}
+
+	var body struct {
+		Tasks []struct {
+			Domain   string `json:"domain"`
+			Priority int    `json:"priority"`
+		} `json:"tasks"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid body %s: %v", w.Body, err)
+	}
+	if len(body.Tasks) != 1 || body.Tasks[0].Domain != "pending.xyz" || body.Tasks[0].Priority != 4 {
+		t.Errorf("tasks = %+v", body.Tasks)
+	}
+
+	for _, query := range []string{"status=approved", "limit=0", "offset=-1"} {
+		w := httptest.NewRecorder()
+		newModerationRouter(moderation).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/moderation?"+query, nil))
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("GET ?%s: code = %d; want %d", query, w.Code, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestModerationActions(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantCode int
+	}{
+		{name: "get", method: http.MethodGet, path: "/admin/moderation/pending.xyz", wantCode: http.StatusOK},
+		{name: "get unknown", method: http.MethodGet, path: "/admin/moderation/unknown.xyz", wantCode: http.StatusNotFound},
+
+		{name: "claim", method: http.MethodPost, path: "/admin/moderation/pending.xyz/claim", body: `{"moderator": "bob"}`, wantCode: http.StatusOK},
+		{name: "claim taken", method: http.MethodPost, path: "/admin/moderation/taken.xyz/claim", body: `{"moderator": "bob"}`, wantCode: http.StatusConflict},
+		{name: "claim resolved", method: http.MethodPost, path: "/admin/moderation/done.xyz/claim", body: `{"moderator": "bob"}`, wantCode: http.StatusConflict},
+		{name: "claim unknown", method: http.MethodPost, path: "/admin/moderation/unknown.xyz/claim", body: `{"moderator": "bob"}`, wantCode: http.StatusNotFound},
+		{name: "claim no moderator", method: http.MethodPost, path: "/admin/moderation/pending.xyz/claim", body: `{}`, wantCode: http.StatusBadRequest},
+
+		{name: "resolve", method: http.MethodPost, path: "/admin/moderation/taken.xyz/resolve", body: `{"moderator": "alice", "status": "scam", "notes": "phishing kit"}`, wantCode: http.StatusOK},
+		{name: "resolve unclaimed", method: http.MethodPost, path: "/admin/moderation/taken.xyz/resolve", body: `{"moderator": "bob", "status": "scam"}`, wantCode: http.StatusConflict},
+		{name: "resolve suspicious", method: http.MethodPost, path: "/admin/moderation/taken.xyz/resolve", body: `{"moderator": "alice", "status": "suspicious"}`, wantCode: http.StatusBadRequest},
+
+		{name: "priority", method: http.MethodPatch, path: "/admin/moderation/pending.xyz/priority", body: `{"priority": 1}`, wantCode: http.StatusOK},
+		{name: "priority out of range", method: http.MethodPatch, path: "/admin/moderation/pending.xyz/priority", body: `{"priority": 11}`, wantCode: http.StatusBadRequest},
+		{name: "priority missing", method: http.MethodPatch, path: "/admin/moderation/pending.xyz/priority", body: `{}`, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tc := range tests {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+		req.Header.Set("Content-Type", "application/json")
+		newModerationRouter(&stubModeration{}).ServeHTTP(w, req)
+
+		if w.Code != tc.wantCode {
+			t.Errorf("%s: code = %d; want %d, body %s", tc.name, w.Code, tc.wantCode, w.Body)
+		}
+	}
+}
+
+func TestResolveModerationTask(t *testing.T) {
+	moderation := &stubModeration{}
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/admin/moderation/Taken.XYZ/resolve",
+		strings.NewReader(`{"moderator": "alice", "status": "verified", "notes": "official partner"}`))
+	req.Header.Set("Content-Type", "application/json")
+	newModerationRouter(moderation).ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("code = %d; want %d, body %s", w.Code, http.StatusOK, w.Body)
+	}
+	got := moderation.resolved[0]
+	if got.Domain != "taken.xyz" || got.Resolution != entity.StatusVerified || got.Notes == nil || *got.Notes != "official partner" {
+		t.Errorf("Resolve() params = %+v", got)
+	}
+}
@@ -3,14 +3,18 @@ package handler
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
 	"net/http"
 	"net/url"
-	"regexp"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/ItsXomyak/scam-list/internal/adapter/http/handler/dto"
 	"github.com/ItsXomyak/scam-list/internal/domain/entity"
 	"github.com/ItsXomyak/scam-list/pkg/logger"
+	"github.com/ItsXomyak/scam-list/pkg/utils"
 )
 
 type Verifier interface {
@@ -33,9 +37,8 @@ func NewVerify(verifier Verifier, log logger.Logger) *Verify {
 func (h *Verify) VerifyDomain(c *gin.Context) {
 	ctx := logger.WithAction(c.Request.Context(), "handler_verify_domain")
 
-	domain := c.Param("domain")
-
-	if err := validateDomain(domain); err != nil {
+	domain, err := normalizeDomain(c.Param("domain"))
+	if err != nil {
 		badRequestResponse(c, err.Error())
 		return
 	}
@@ -54,33 +57,76 @@ func (h *Verify) VerifyDomain(c *gin.Context) {
 	})
 }
 
-// checks if the provided domain is valid
-func validateDomain(raw string) error {
+// VerifyURL checks a full link, e.g. a shortener URL, passed as ?url=.
+// The pipeline follows its redirects and checks the landing domain.
+func (h *Verify) VerifyURL(c *gin.Context) {
+	ctx := logger.WithAction(c.Request.Context(), "handler_verify_url")
+
+	target, err := normalizeURL(c.Query("url"))
+	if err != nil {
+		badRequestResponse(c, err.Error())
+		return
+	}
+
+	result, err := h.verifier.ProcessDomain(ctx, target)
+	if err != nil {
+		h.log.Error(logger.ErrorCtx(ctx, err), "error verifying url", err, "url", target)
+		internalErrorResponse(c, "internal server error")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"result": result,
+	})
+}
+
+// normalizeURL accepts an http(s) URL or a bare domain with a path and
+// returns it with the host in ASCII form
+func normalizeURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
 	if len(raw) == 0 {
-		return errors.New("URL must be provided")
+		return "", errors.New("url must be provided")
+	}
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
 	}
 
-	// Parse the URL
-	parsed, err := url.ParseRequestURI(raw)
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", errors.New("invalid url, only http and https links are supported")
+	}
+	if u.Port() != "" {
+		return "", errors.New("invalid url, custom ports are not supported")
+	}
+
+	host, err := normalizeDomain(u.Hostname())
 	if err != nil {
-		return errors.New("invalid URL format")
+		return "", err
 	}
+	u.Host = host
+	u.User = nil
+	u.Fragment = ""
+
+	return u.String(), nil
+}
 
-	// Must have scheme
-	if parsed.Scheme != "http" && parsed.Scheme != "https" {
-		return errors.New("URL must have http or https scheme")
+// normalizeDomain accepts a bare domain or URL, including Unicode and punycode
+// names, and returns its ASCII form
+func normalizeDomain(raw string) (string, error) {
+	if len(raw) == 0 {
+		return "", errors.New("domain must be provided")
 	}
 
-	// Must have host
-	if parsed.Host == "" {
-		return errors.New("URL must have a host")
+	host, err := utils.NormalizeDomain(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid domain: %w", err)
 	}
 
-	// Validate domain with regex (letters, digits, dash, dot)
-	domainRegex := regexp.MustCompile(`^([a-zA-Z0-9-]+\.)+[a-zA-Z]{2,}$`)
-	host := parsed.Hostname()
-	if !domainRegex.MatchString(host) {
-		return errors.New("invalid domain format")
+	if net.ParseIP(host) != nil {
+		return "", errors.New("IP addresses are not supported, provide a domain")
+	}
+	if len(host) > 253 || !dto.IsValidDomainName(host) {
+		return "", errors.New("invalid domain format")
 	}
-	return nil
+	return host, nil
 }
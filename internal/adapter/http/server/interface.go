@@ -9,3 +9,24 @@ type Verifier interface {
 type DomainService interface {
 	handler.DomainRepository
 }
+
+type CheckRepository interface {
+	handler.CheckRepository
+}
+
+type ModerationService interface {
+	handler.ModerationService
+}
+
+type StatusPolicy interface {
+	handler.StatusPolicy
+}
+
+type JobService interface {
+	handler.JobService
+	handler.CheckQueue
+}
+
+type CronScheduler interface {
+	handler.CronScheduler
+}
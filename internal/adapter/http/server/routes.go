@@ -13,6 +13,9 @@ func (a *API) setupRoutes() {
 	// API routes
 	api := a.router.Group("/api")
 	{
+		api.GET("/verify", a.routes.verify.VerifyURL)
+		api.POST("/verify", a.routes.jobs.SubmitVerify)
+		api.GET("/verify/jobs/:id", a.routes.jobs.GetVerifyJob)
 		api.GET("/verify/:domain", a.routes.verify.VerifyDomain)
 	}
 
@@ -24,6 +27,17 @@ func (a *API) setupRoutes() {
 		admin.GET("/domain/:domain", a.routes.admin.GetDomain)
 		admin.PATCH("/domain/:domain", a.routes.admin.PatchDomain)
 		admin.DELETE("/domain/:domain", a.routes.admin.DeleteDomain)
+		admin.GET("/domain/:domain/checks", a.routes.checks.ListChecks)
+		admin.POST("/domain/:domain/checks", a.routes.checks.RunCheck)
+
+		admin.GET("/moderation", a.routes.moderation.ListTasks)
+		admin.GET("/moderation/:domain", a.routes.moderation.GetTask)
+		admin.POST("/moderation/:domain/claim", a.routes.moderation.ClaimTask)
+		admin.POST("/moderation/:domain/resolve", a.routes.moderation.ResolveTask)
+		admin.PATCH("/moderation/:domain/priority", a.routes.moderation.SetPriority)
+
+		admin.GET("/cron", a.routes.cron.ListJobs)
+		admin.POST("/cron/:name/run", a.routes.cron.RunJob)
 	}
 }
 
@@ -24,11 +24,15 @@ type API struct {
 }
 
 type handlers struct {
-	verify *handler.Verify
-	admin  *handler.AdminPanel
+	verify     *handler.Verify
+	jobs       *handler.Jobs
+	admin      *handler.AdminPanel
+	checks     *handler.Checks
+	moderation *handler.Moderation
+	cron       *handler.Cron
 }
 
-func New(cfg config.Config, verifier Verifier, domainSvc DomainService, logger logger.Logger) *API {
+func New(cfg config.Config, verifier Verifier, jobs JobService, domainSvc DomainService, checks CheckRepository, moderation ModerationService, statuses StatusPolicy, cron CronScheduler, logger logger.Logger) *API {
 	addr := fmt.Sprintf(serverIPAddress, "0.0.0.0", cfg.HTTPServer.Port)
 
 	// Set Gin mode based on environment
@@ -42,8 +46,12 @@ func New(cfg config.Config, verifier Verifier, domainSvc DomainService, logger l
 
 	// Initialize handlers
 	handlers := &handlers{
-		verify: handler.NewVerify(verifier, logger),
-		admin:  handler.NewAdminPanel(domainSvc, logger),
+		verify:     handler.NewVerify(verifier, logger),
+		jobs:       handler.NewJobs(jobs, logger),
+		admin:      handler.NewAdminPanel(domainSvc, statuses, logger),
+		checks:     handler.NewChecks(checks, jobs, logger),
+		moderation: handler.NewModeration(moderation, logger),
+		cron:       handler.NewCron(cron, logger),
 	}
 
 	router := gin.New()
@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/postgres"
+)
+
+type CheckRepository struct {
+	pool postgres.PgxPool
+}
+
+func NewCheck(pool postgres.PgxPool) *CheckRepository {
+	return &CheckRepository{
+		pool: pool,
+	}
+}
+
+// CreateCheck stores a pipeline run and returns it with its id.
+func (r *CheckRepository) CreateCheck(ctx context.Context, check *entity.DomainCheck) (*entity.DomainCheck, error) {
+	results := check.ModuleResults
+	if results == nil {
+		results = []*entity.ModuleResult{}
+	}
+	raw, err := json.Marshal(results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode module results: %w", err)
+	}
+
+	query := `
+		INSERT INTO domain_checks (
+			domain, trigger, status, risk_score, reasons,
+			module_results, started_at, finished_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6::jsonb, $7, $8)
+		RETURNING id
+	`
+
+	saved := *check
+	err = r.pool.QueryRow(ctx, query,
+		check.Domain,
+		check.Trigger,
+		check.Status,
+		check.RiskScore,
+		check.Reasons,
+		raw,
+		check.StartedAt,
+		check.FinishedAt,
+	).Scan(&saved.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &saved, nil
+}
+
+// GetDomainChecks returns the checks of a domain, newest first.
+func (r *CheckRepository) GetDomainChecks(ctx context.Context, arg *entity.GetDomainChecksParams) ([]*entity.DomainCheck, error) {
+	before := arg.Before
+	if before.IsZero() {
+		before = time.Now().Add(time.Minute)
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT id, domain, trigger, status, risk_score, reasons,
+			module_results, started_at, finished_at
+		FROM domain_checks
+		WHERE domain = $1 AND started_at < $2
+		ORDER BY started_at DESC
+		LIMIT $3
+	`, arg.Domain, before, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.DomainCheck
+
+	for rows.Next() {
+		var (
+			check      entity.DomainCheck
+			resultsRaw []byte
+		)
+
+		if err := rows.Scan(
+			&check.ID,
+			&check.Domain,
+			&check.Trigger,
+			&check.Status,
+			&check.RiskScore,
+			&check.Reasons,
+			&resultsRaw,
+			&check.StartedAt,
+			&check.FinishedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(resultsRaw, &check.ModuleResults); err != nil {
+			return nil, fmt.Errorf("failed to decode module results of check %s: %w", check.ID, err)
+		}
+
+		out = append(out, &check)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
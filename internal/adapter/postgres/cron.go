@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/postgres"
+)
+
+const cronUnlockTimeout = 5 * time.Second
+
+// CronRepository elects cron leaders with advisory locks and keeps the
+// last run of every job in cron_runs.
+type CronRepository struct {
+	pool postgres.PgxPool
+}
+
+func NewCron(pool postgres.PgxPool) *CronRepository {
+	return &CronRepository{
+		pool: pool,
+	}
+}
+
+// TryLock takes a session advisory lock for the job. The lock belongs to
+// the connection, so the connection is kept out of the pool until unlock.
+func (r *CronRepository) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	key := cronLockKey(name)
+	var locked bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, key).Scan(&locked); err != nil {
+		conn.Release()
+		return nil, false, err
+	}
+	if !locked {
+		conn.Release()
+		return nil, false, nil
+	}
+
+	unlock := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cronUnlockTimeout)
+		defer cancel()
+
+		if _, err := conn.Exec(ctx, `SELECT pg_advisory_unlock($1)`, key); err != nil {
+			// closing the session releases its locks, the pool drops the closed connection
+			_ = conn.Conn().Close(ctx)
+		}
+		conn.Release()
+	}
+
+	return unlock, true, nil
+}
+
+// StartRun records a started run. A scheduled run whose slot is not after
+// the stored one is refused, manual runs keep the stored slot.
+func (r *CronRepository) StartRun(ctx context.Context, run *entity.CronRun) (bool, error) {
+	query := `
+		INSERT INTO cron_runs (name, trigger, status, scheduled_at, started_at, instance)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (name) DO UPDATE SET
+			trigger = EXCLUDED.trigger,
+			status = EXCLUDED.status,
+			scheduled_at = COALESCE(EXCLUDED.scheduled_at, cron_runs.scheduled_at),
+			started_at = EXCLUDED.started_at,
+			finished_at = NULL,
+			error = NULL,
+			instance = EXCLUDED.instance
+		WHERE EXCLUDED.scheduled_at IS NULL
+			OR cron_runs.scheduled_at IS NULL
+			OR cron_runs.scheduled_at < EXCLUDED.scheduled_at
+		RETURNING name
+	`
+
+	var name string
+	err := r.pool.QueryRow(ctx, query,
+		run.Name,
+		run.Trigger,
+		run.Status,
+		run.ScheduledAt,
+		run.StartedAt,
+		run.Instance,
+	).Scan(&name)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// conflict row was filtered out by the WHERE clause
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *CronRepository) FinishRun(ctx context.Context, run *entity.CronRun) error {
+	var errText *string
+	if run.Error != "" {
+		errText = &run.Error
+	}
+
+	_, err := r.pool.Exec(ctx, `
+		UPDATE cron_runs SET
+			status = $2,
+			finished_at = $3,
+			error = $4
+		WHERE name = $1
+	`, run.Name, run.Status, run.FinishedAt, errText)
+	return err
+}
+
+func (r *CronRepository) GetRuns(ctx context.Context) ([]*entity.CronRun, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT name, trigger, status, scheduled_at, started_at, finished_at, error, instance
+		FROM cron_runs
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.CronRun
+
+	for rows.Next() {
+		var (
+			run     entity.CronRun
+			errText *string
+		)
+
+		if err := rows.Scan(
+			&run.Name,
+			&run.Trigger,
+			&run.Status,
+			&run.ScheduledAt,
+			&run.StartedAt,
+			&run.FinishedAt,
+			&errText,
+			&run.Instance,
+		); err != nil {
+			return nil, err
+		}
+		if errText != nil {
+			run.Error = *errText
+		}
+
+		out = append(out, &run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// cronLockKey maps a job name to the bigint key of its advisory lock.
+func cronLockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte("cron:" + name))
+	return int64(h.Sum64())
+}
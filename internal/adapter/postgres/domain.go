@@ -3,6 +3,7 @@ package postgres
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -52,7 +53,9 @@ func (u *DomainRepository) CreateDomain(ctx context.Context, arg *entity.CreateD
 			reasons,
 			metadata,
 			created_at,
-			updated_at
+			updated_at,
+			last_check_at,
+			expires_at
 	`
 
 	var (
@@ -94,6 +97,8 @@ func (u *DomainRepository) CreateDomain(ctx context.Context, arg *entity.CreateD
 		&metadataRaw,
 		&createdAt,
 		&updatedAt,
+		&res.LastCheckAt,
+		&res.ExpiresAt,
 	)
 	if err != nil {
 		return nil, err
@@ -134,7 +139,9 @@ func (u *DomainRepository) GetDomain(ctx context.Context, domain string) (*entit
 			reasons,
 			metadata,
 			created_at,
-			updated_at
+			updated_at,
+			last_check_at,
+			expires_at
 		FROM domains
 		WHERE domain = $1
 	`
@@ -166,6 +173,8 @@ func (u *DomainRepository) GetDomain(ctx context.Context, domain string) (*entit
 		&metadataRaw,
 		&createdAt,
 		&updatedAt,
+		&res.LastCheckAt,
+		&res.ExpiresAt,
 	)
 	if err != nil {
 		return nil, err
@@ -206,7 +215,9 @@ func (u *DomainRepository) GetAllDomains(ctx context.Context) ([]*entity.Domain,
 			reasons,
 			metadata,
 			created_at,
-			updated_at
+			updated_at,
+			last_check_at,
+			expires_at
 		FROM domains
 		ORDER BY created_at DESC
 	`
@@ -248,6 +259,8 @@ func (u *DomainRepository) GetAllDomains(ctx context.Context) ([]*entity.Domain,
 			&metadataRaw,
 			&createdAt,
 			&updatedAt,
+			&d.LastCheckAt,
+			&d.ExpiresAt,
 		); err != nil {
 			return nil, err
 		}
@@ -312,7 +325,9 @@ func (u *DomainRepository) UpdateDomain(ctx context.Context, updated *entity.Dom
 			reasons,
 			metadata,
 			created_at,
-			updated_at
+			updated_at,
+			last_check_at,
+			expires_at
 	`
 
 	var (
@@ -354,6 +369,8 @@ func (u *DomainRepository) UpdateDomain(ctx context.Context, updated *entity.Dom
 		&metadataRaw,
 		&createdAt,
 		&updatedAt,
+		&res.LastCheckAt,
+		&res.ExpiresAt,
 	)
 	if err != nil {
 		return nil, err
@@ -379,6 +396,243 @@ func (u *DomainRepository) UpdateDomain(ctx context.Context, updated *entity.Dom
 	return &res, nil
 }
 
+// UpsertDomain inserts the domain or replaces its verdict.
+// Rows with verification_method = 'manual' are only replaced when
+// arg.OverwriteManual is set, otherwise entity.ErrManualVerdictLocked is returned.
+// The verdict counts as checked now and expires at arg.ExpiresAt.
+// Every field is replaced, so nothing of an older verdict is kept, and the
+// scam type is only stored for scam and suspicious domains.
+func (u *DomainRepository) UpsertDomain(ctx context.Context, arg *entity.UpsertDomainParams) (*entity.Domain, error) {
+	mdJSON, err := packMetadata(arg.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	newScamType := arg.ScamType
+	if arg.Status != entity.StatusScam && arg.Status != entity.StatusSuspicious {
+		newScamType = nil
+	}
+
+	query := `
+		INSERT INTO domains (
+			domain, status, company_name, country, scam_sources,
+			scam_type, verified_by, verification_method, risk_score,
+			reasons, metadata, last_check_at, expires_at
+		)
+		VALUES (
+			$1, $2, $3, $4, $5,
+			$6, $7, $8, $9,
+			$10, $11::jsonb, NOW(), $13
+		)
+		ON CONFLICT (domain) DO UPDATE SET
+			status = EXCLUDED.status,
+			company_name = EXCLUDED.company_name,
+			country = EXCLUDED.country,
+			scam_sources = EXCLUDED.scam_sources,
+			scam_type = EXCLUDED.scam_type,
+			verified_by = EXCLUDED.verified_by,
+			verification_method = EXCLUDED.verification_method,
+			risk_score = EXCLUDED.risk_score,
+			reasons = EXCLUDED.reasons,
+			metadata = EXCLUDED.metadata,
+			last_check_at = EXCLUDED.last_check_at,
+			expires_at = EXCLUDED.expires_at,
+			updated_at = NOW()
+		WHERE $12 OR domains.verification_method IS DISTINCT FROM 'manual'
+		RETURNING
+			domain,
+			status,
+			company_name,
+			country,
+			scam_sources,
+			scam_type,
+			verified_by,
+			verification_method,
+			risk_score,
+			reasons,
+			metadata,
+			created_at,
+			updated_at,
+			last_check_at,
+			expires_at
+	`
+
+	var (
+		res                  entity.Domain
+		metadataRaw          []byte
+		company              *string
+		country              *string
+		scamType             *string
+		verifiedBy           *string
+		verificationMethod   *string
+		riskScoreText        *float64
+		createdAt, updatedAt *time.Time
+		scamSources, reasons []string
+	)
+
+	err = u.pool.QueryRow(ctx, query,
+		arg.Domain,
+		arg.Status,
+		arg.CompanyName,
+		arg.Country,
+		arg.ScamSources,
+		newScamType,
+		arg.VerifiedBy,
+		arg.VerificationMethod,
+		arg.RiskScore,
+		arg.Reasons,
+		mdJSON,
+		arg.OverwriteManual,
+		arg.ExpiresAt,
+	).Scan(
+		&res.Domain,
+		&res.Status,
+		&company,
+		&country,
+		&scamSources,
+		&scamType,
+		&verifiedBy,
+		&verificationMethod,
+		&riskScoreText,
+		&reasons,
+		&metadataRaw,
+		&createdAt,
+		&updatedAt,
+		&res.LastCheckAt,
+		&res.ExpiresAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// conflict row was filtered out by the WHERE clause
+		return nil, entity.ErrManualVerdictLocked
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	md, err := unpackMetadata(metadataRaw)
+	if err != nil {
+		return nil, err
+	}
+
+	res.CompanyName = company
+	res.Country = country
+	res.ScamSources = scamSources
+	res.ScamType = scamType
+	res.VerifiedBy = verifiedBy
+	res.VerificationMethod = verificationMethod
+	res.RiskScore = riskScoreText
+	res.Reasons = reasons
+	res.Metadata = md
+	res.CreatedAt = createdAt
+	res.UpdatedAt = updatedAt
+
+	return &res, nil
+}
+
+// GetDomainsForRecheck leases up to arg.Limit automatic verdicts that
+// expired by arg.Now, oldest first. Their expires_at moves arg.Lease ahead,
+// a successful recheck replaces it through UpsertDomain. SKIP LOCKED keeps
+// instances running the query at the same time from taking the same rows.
+func (u *DomainRepository) GetDomainsForRecheck(ctx context.Context, arg *entity.GetDomainsForRecheckParams) ([]*entity.Domain, error) {
+	query := `
+		UPDATE domains SET
+			expires_at = $1::timestamptz + make_interval(secs => $2)
+		WHERE domain IN (
+			SELECT domain FROM domains
+			WHERE expires_at <= $1
+				AND verification_method IS DISTINCT FROM 'manual'
+			ORDER BY expires_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING
+			domain,
+			status,
+			company_name,
+			country,
+			scam_sources,
+			scam_type,
+			verified_by,
+			verification_method,
+			risk_score,
+			reasons,
+			metadata,
+			created_at,
+			updated_at,
+			last_check_at,
+			expires_at
+	`
+
+	rows, err := u.pool.Query(ctx, query, arg.Now, arg.Lease.Seconds(), arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.Domain
+
+	for rows.Next() {
+		var (
+			d                  entity.Domain
+			metadataRaw        []byte
+			company            *string
+			country            *string
+			scamType           *string
+			verifiedBy         *string
+			verificationMethod *string
+			riskScoreText      *float64
+
+			createdAt, updatedAt *time.Time
+			scamSources, reasons []string
+		)
+
+		if err := rows.Scan(
+			&d.Domain,
+			&d.Status,
+			&company,
+			&country,
+			&scamSources,
+			&scamType,
+			&verifiedBy,
+			&verificationMethod,
+			&riskScoreText,
+			&reasons,
+			&metadataRaw,
+			&createdAt,
+			&updatedAt,
+			&d.LastCheckAt,
+			&d.ExpiresAt,
+		); err != nil {
+			return nil, err
+		}
+
+		md, err := unpackMetadata(metadataRaw)
+		if err != nil {
+			return nil, err
+		}
+
+		d.CompanyName = company
+		d.Country = country
+		d.ScamSources = scamSources
+		d.ScamType = scamType
+		d.VerifiedBy = verifiedBy
+		d.VerificationMethod = verificationMethod
+		d.RiskScore = riskScoreText
+		d.Reasons = reasons
+		d.Metadata = md
+		d.CreatedAt = createdAt
+		d.UpdatedAt = updatedAt
+
+		out = append(out, &d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
 func (u *DomainRepository) DeleteDomain(ctx context.Context, domain string) error {
 	cmd, err := u.pool.Exec(ctx, `DELETE FROM domains WHERE domain = $1`, domain)
 	if err != nil {
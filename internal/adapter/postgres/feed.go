@@ -0,0 +1,210 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/postgres"
+)
+
+type FeedRepository struct {
+	pool postgres.PgxPool
+}
+
+func NewFeed(pool postgres.PgxPool) *FeedRepository {
+	return &FeedRepository{
+		pool: pool,
+	}
+}
+
+// ImportFeed stores a full snapshot of the feed in one transaction.
+// Entries are copied into a temp table and upserted from there: feed_entries
+// remembers when the feed last listed each domain, domains get the feed in
+// scam_sources and are not rechecked while listed, their open moderation
+// tasks are closed as scam. Manual verdicts are never touched. Entries the
+// feed stopped listing are removed according to feed.Removal.
+func (r *FeedRepository) ImportFeed(ctx context.Context, feed *entity.Feed, entries []entity.FeedEntry, seenAt time.Time) (*entity.FeedImportStats, error) {
+	stats := &entity.FeedImportStats{Feed: feed.Name, Parsed: len(entries)}
+	reason := "listed in " + feed.Name
+
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		CREATE TEMP TABLE feed_import (
+			domain VARCHAR(253) NOT NULL,
+			url TEXT,
+			scam_type VARCHAR(100)
+		) ON COMMIT DROP
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create import table: %w", err)
+	}
+
+	_, err = tx.CopyFrom(ctx, pgx.Identifier{"feed_import"}, []string{"domain", "url", "scam_type"},
+		pgx.CopyFromSlice(len(entries), func(i int) ([]any, error) {
+			e := entries[i]
+			return []any{e.Domain, nullIfEmpty(e.URL), nullIfEmpty(e.ScamType)}, nil
+		}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy feed entries: %w", err)
+	}
+
+	// feed membership, xmax = 0 tells a new row from an updated one
+	err = tx.QueryRow(ctx, `
+		WITH upserted AS (
+			INSERT INTO feed_entries (feed, domain, url, scam_type, first_seen_at, last_seen_at)
+			SELECT DISTINCT ON (domain) $1::varchar, domain, url, scam_type, $2, $2
+			FROM feed_import
+			ON CONFLICT (feed, domain) DO UPDATE SET
+				url = EXCLUDED.url,
+				scam_type = EXCLUDED.scam_type,
+				last_seen_at = EXCLUDED.last_seen_at
+			RETURNING (xmax = 0) AS inserted
+		)
+		SELECT count(*) FILTER (WHERE inserted) FROM upserted
+	`, feed.Name, seenAt).Scan(&stats.Added)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upsert feed entries: %w", err)
+	}
+
+	// domains already flagged by this feed are left alone
+	err = tx.QueryRow(ctx, `
+		WITH listed AS (
+			INSERT INTO domains (
+				domain, status, scam_sources, scam_type,
+				verified_by, verification_method, risk_score, reasons
+			)
+			SELECT DISTINCT ON (domain)
+				domain, 'scam', ARRAY[$1::varchar], COALESCE(scam_type, $2::varchar),
+				$1::varchar, 'feed', $3::numeric, ARRAY[$4::text]
+			FROM feed_import
+			ON CONFLICT (domain) DO UPDATE SET
+				status = 'scam',
+				scam_sources = array_append(COALESCE(domains.scam_sources, '{}'), $1::varchar),
+				scam_type = CASE
+					WHEN domains.scam_type IS NULL OR domains.scam_type = 'other' THEN COALESCE(EXCLUDED.scam_type, domains.scam_type)
+					ELSE domains.scam_type
+				END,
+				risk_score = GREATEST(COALESCE(domains.risk_score, 0), EXCLUDED.risk_score),
+				reasons = array_append(COALESCE(domains.reasons, '{}'), $4::text),
+				expires_at = NULL,
+				updated_at = NOW()
+			WHERE domains.verification_method IS DISTINCT FROM 'manual'
+				AND NOT ($1::varchar = ANY(COALESCE(domains.scam_sources, '{}')))
+			RETURNING 1
+		)
+		SELECT count(*) FROM listed
+	`, feed.Name, nullIfEmpty(feed.ScamType), feed.RiskScore, reason).Scan(&stats.Listed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list feed domains: %w", err)
+	}
+
+	// the feed verdict settles what a moderator was asked to review
+	_, err = tx.Exec(ctx, `
+		UPDATE pending_moderation SET
+			status = 'resolved',
+			resolution = $1,
+			resolved_by = $2,
+			resolved_at = NOW(),
+			updated_at = NOW()
+		FROM domains d
+		WHERE d.domain = pending_moderation.domain
+			AND pending_moderation.status IN ('pending', 'in_progress')
+			AND d.domain IN (SELECT domain FROM feed_import)
+			AND d.verification_method IS DISTINCT FROM 'manual'
+	`, entity.StatusScam, entity.ModerationResolvedBySystem)
+	if err != nil {
+		return nil, fmt.Errorf("failed to close moderation tasks of feed domains: %w", err)
+	}
+
+	if feed.Removal == entity.FeedRemovalRemove {
+		stats.Removed, err = removeMissing(ctx, tx, feed, reason, seenAt.Add(-feed.RemoveAfter))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// removeMissing drops entries the feed has not listed since before. Domains
+// that only this feed put in the list are deleted, other automatic ones
+// lose the feed from scam_sources. An automatic verdict no feed lists any
+// more may have been overridden by the feed, so it is due for a recheck.
+func removeMissing(ctx context.Context, tx pgx.Tx, feed *entity.Feed, reason string, before time.Time) (int, error) {
+	rows, err := tx.Query(ctx, `
+		DELETE FROM feed_entries
+		WHERE feed = $1 AND last_seen_at < $2
+		RETURNING domain
+	`, feed.Name, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove missing feed entries: %w", err)
+	}
+	gone, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return 0, fmt.Errorf("failed to remove missing feed entries: %w", err)
+	}
+	if len(gone) == 0 {
+		return 0, nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		DELETE FROM domains
+		WHERE domain = ANY($2)
+			AND verification_method = 'feed'
+			AND scam_sources = ARRAY[$1::varchar]
+	`, feed.Name, gone)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete unlisted domains: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE domains SET
+			scam_sources = array_remove(scam_sources, $1::varchar),
+			reasons = array_remove(reasons, $3::text),
+			expires_at = CASE
+				WHEN verification_method = 'automatic'
+					AND NOT EXISTS (SELECT 1 FROM feed_entries fe WHERE fe.domain = domains.domain) THEN NOW()
+				ELSE expires_at
+			END,
+			updated_at = NOW()
+		WHERE domain = ANY($2)
+			AND verification_method IS DISTINCT FROM 'manual'
+			AND $1::varchar = ANY(scam_sources)
+	`, feed.Name, gone, reason)
+	if err != nil {
+		return 0, fmt.Errorf("failed to unlist domains: %w", err)
+	}
+
+	return len(gone), nil
+}
+
+// FeedDomains returns every domain the feed currently lists.
+func (r *FeedRepository) FeedDomains(ctx context.Context, feed string) ([]string, error) {
+	rows, err := r.pool.Query(ctx, `SELECT domain FROM feed_entries WHERE feed = $1`, feed)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func nullIfEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
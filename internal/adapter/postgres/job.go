@@ -0,0 +1,200 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/postgres"
+)
+
+const jobColumns = `id, target, status, trigger, priority, skip_cache, result, error, attempts, max_attempts,
+	created_at, started_at, finished_at, visible_at`
+
+type JobRepository struct {
+	pool postgres.PgxPool
+}
+
+func NewJob(pool postgres.PgxPool) *JobRepository {
+	return &JobRepository{
+		pool: pool,
+	}
+}
+
+// CreateJob queues a verification. A target that already has a queued or
+// running job gets that job back instead of a new one, raised to the
+// higher of both priorities and forced to skip the cache if either is.
+// The trigger of the raising request replaces the stored one.
+func (r *JobRepository) CreateJob(ctx context.Context, arg *entity.CreateJobParams) (*entity.VerifyJob, error) {
+	query := `
+		INSERT INTO verify_jobs (target, trigger, priority, max_attempts, skip_cache)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (target) WHERE status IN ('queued', 'running')
+		DO UPDATE SET
+			trigger = CASE WHEN EXCLUDED.priority < verify_jobs.priority
+				THEN EXCLUDED.trigger ELSE verify_jobs.trigger END,
+			priority = LEAST(verify_jobs.priority, EXCLUDED.priority),
+			skip_cache = verify_jobs.skip_cache OR EXCLUDED.skip_cache,
+			updated_at = NOW()
+		RETURNING ` + jobColumns
+
+	return scanJob(r.pool.QueryRow(ctx, query,
+		arg.Target,
+		arg.Trigger,
+		arg.Priority,
+		arg.MaxAttempts,
+		arg.SkipCache,
+	))
+}
+
+func (r *JobRepository) GetJob(ctx context.Context, id string) (*entity.VerifyJob, error) {
+	query := `SELECT ` + jobColumns + ` FROM verify_jobs WHERE id = $1`
+
+	return scanJob(r.pool.QueryRow(ctx, query, id))
+}
+
+// ClaimJob marks the most urgent visible job as running and hides it for
+// the visibility timeout. A running job whose timeout passed belongs to a
+// dead worker and is claimed again. SKIP LOCKED lets several workers claim
+// concurrently without waiting on each other. pgx.ErrNoRows means nothing
+// is ready to run.
+func (r *JobRepository) ClaimJob(ctx context.Context, visibility time.Duration) (*entity.VerifyJob, error) {
+	query := `
+		UPDATE verify_jobs SET
+			status = 'running',
+			attempts = attempts + 1,
+			started_at = NOW(),
+			visible_at = NOW() + make_interval(secs => $1),
+			updated_at = NOW()
+		WHERE id = (
+			SELECT id FROM verify_jobs
+			WHERE status IN ('queued', 'running') AND visible_at <= NOW()
+			ORDER BY priority, visible_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns
+
+	return scanJob(r.pool.QueryRow(ctx, query, visibility.Seconds()))
+}
+
+// CompleteJob stores the result of a job. Like RetryJob, DeadJob and
+// ReleaseJob it takes the attempt the job was claimed with as a fencing
+// token: a worker that passed the visibility timeout no longer owns the
+// job, and its write must not undo the outcome of the worker that claimed
+// it again. Such a write returns entity.ErrJobLeaseLost.
+func (r *JobRepository) CompleteJob(ctx context.Context, id string, attempt int, result *entity.VerifyDomainResult) error {
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to encode job result: %w", err)
+	}
+
+	return r.finish(ctx, `
+		UPDATE verify_jobs SET
+			status = 'done',
+			result = $3::jsonb,
+			error = NULL,
+			finished_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1 AND status = 'running' AND attempts = $2
+	`, id, attempt, raw)
+}
+
+// RetryJob queues a failed job again after the backoff delay.
+func (r *JobRepository) RetryJob(ctx context.Context, id string, attempt int, reason string, delay time.Duration) error {
+	return r.finish(ctx, `
+		UPDATE verify_jobs SET
+			status = 'queued',
+			error = $3,
+			started_at = NULL,
+			visible_at = NOW() + make_interval(secs => $4),
+			updated_at = NOW()
+		WHERE id = $1 AND status = 'running' AND attempts = $2
+	`, id, attempt, reason, delay.Seconds())
+}
+
+// DeadJob moves a job that ran out of attempts to the dead-letter state.
+func (r *JobRepository) DeadJob(ctx context.Context, id string, attempt int, reason string) error {
+	return r.finish(ctx, `
+		UPDATE verify_jobs SET
+			status = 'dead',
+			error = $3,
+			finished_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1 AND status = 'running' AND attempts = $2
+	`, id, attempt, reason)
+}
+
+// ReleaseJob puts a running job back in the queue, e.g. on shutdown.
+// The interrupted attempt is not counted.
+func (r *JobRepository) ReleaseJob(ctx context.Context, id string, attempt int) error {
+	return r.finish(ctx, `
+		UPDATE verify_jobs SET
+			status = 'queued',
+			attempts = GREATEST(attempts - 1, 0),
+			started_at = NULL,
+			visible_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1 AND status = 'running' AND attempts = $2
+	`, id, attempt)
+}
+
+// finish runs a fenced update of a claimed job.
+func (r *JobRepository) finish(ctx context.Context, query string, args ...any) error {
+	cmd, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	if cmd.RowsAffected() == 0 {
+		return entity.ErrJobLeaseLost
+	}
+	return nil
+}
+
+func scanJob(row pgx.Row) (*entity.VerifyJob, error) {
+	var (
+		job        entity.VerifyJob
+		resultRaw  []byte
+		errText    *string
+		startedAt  *time.Time
+		finishedAt *time.Time
+	)
+
+	err := row.Scan(
+		&job.ID,
+		&job.Target,
+		&job.Status,
+		&job.Trigger,
+		&job.Priority,
+		&job.SkipCache,
+		&resultRaw,
+		&errText,
+		&job.Attempts,
+		&job.MaxAttempts,
+		&job.CreatedAt,
+		&startedAt,
+		&finishedAt,
+		&job.VisibleAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resultRaw) > 0 {
+		job.Result = &entity.VerifyDomainResult{}
+		if err := json.Unmarshal(resultRaw, job.Result); err != nil {
+			return nil, fmt.Errorf("failed to decode job result: %w", err)
+		}
+	}
+	if errText != nil {
+		job.Error = *errText
+	}
+	job.StartedAt = startedAt
+	job.FinishedAt = finishedAt
+
+	return &job, nil
+}
@@ -0,0 +1,243 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/postgres"
+)
+
+// moderationColumns are selected from pending_moderation pm joined with domains d.
+const moderationColumns = `pm.domain, pm.check_id, pm.reasons, pm.source_modules, pm.priority, pm.status,
+	pm.assigned_to, pm.resolution, pm.resolved_by, pm.moderator_notes,
+	pm.submitted_at, pm.claimed_at, pm.resolved_at, pm.updated_at,
+	d.risk_score, d.company_name, d.country`
+
+// moderationSelect wraps a statement returning pending_moderation rows
+// so they come back with the domain data.
+func moderationSelect(statement string) string {
+	return `WITH pm AS (` + statement + ` RETURNING *)
+		SELECT ` + moderationColumns + ` FROM pm JOIN domains d ON d.domain = pm.domain`
+}
+
+type ModerationRepository struct {
+	pool postgres.PgxPool
+}
+
+func NewModeration(pool postgres.PgxPool) *ModerationRepository {
+	return &ModerationRepository{
+		pool: pool,
+	}
+}
+
+// CreateModerationTask queues a domain. An open task of the domain keeps
+// its status, moderator and priority and gets the new check and reasons,
+// a resolved one is reopened as a new task.
+func (r *ModerationRepository) CreateModerationTask(ctx context.Context, arg *entity.CreateModerationTaskParams) (*entity.ModerationTask, error) {
+	reasons, modules := arg.Reasons, arg.SourceModules
+	if reasons == nil {
+		reasons = []string{}
+	}
+	if modules == nil {
+		modules = []string{}
+	}
+
+	query := moderationSelect(`
+		INSERT INTO pending_moderation AS t (domain, check_id, reasons, source_modules, priority)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (domain) DO UPDATE SET
+			check_id = COALESCE(EXCLUDED.check_id, t.check_id),
+			reasons = EXCLUDED.reasons,
+			source_modules = EXCLUDED.source_modules,
+			priority = CASE WHEN t.status = 'resolved' THEN EXCLUDED.priority ELSE t.priority END,
+			status = CASE WHEN t.status = 'resolved' THEN 'pending' ELSE t.status END,
+			assigned_to = CASE WHEN t.status = 'resolved' THEN NULL ELSE t.assigned_to END,
+			claimed_at = CASE WHEN t.status = 'resolved' THEN NULL ELSE t.claimed_at END,
+			submitted_at = CASE WHEN t.status = 'resolved' THEN NOW() ELSE t.submitted_at END,
+			resolution = NULL,
+			resolved_by = NULL,
+			moderator_notes = CASE WHEN t.status = 'resolved' THEN NULL ELSE t.moderator_notes END,
+			resolved_at = NULL,
+			updated_at = NOW()`)
+
+	return scanModerationTask(r.pool.QueryRow(ctx, query,
+		arg.Domain,
+		arg.CheckID,
+		reasons,
+		modules,
+		arg.Priority,
+	))
+}
+
+func (r *ModerationRepository) GetModerationTask(ctx context.Context, domain string) (*entity.ModerationTask, error) {
+	query := `SELECT ` + moderationColumns + `
+		FROM pending_moderation pm
+		JOIN domains d ON d.domain = pm.domain
+		WHERE pm.domain = $1`
+
+	return scanModerationTask(r.pool.QueryRow(ctx, query, domain))
+}
+
+// GetModerationTasks lists open tasks most urgent first and resolved
+// ones latest first.
+func (r *ModerationRepository) GetModerationTasks(ctx context.Context, arg *entity.GetModerationTasksParams) ([]*entity.ModerationTask, error) {
+	order := `pm.priority, pm.submitted_at`
+	if arg.Status == entity.ModerationStatusResolved {
+		order = `pm.resolved_at DESC`
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT `+moderationColumns+`
+		FROM pending_moderation pm
+		JOIN domains d ON d.domain = pm.domain
+		WHERE CASE WHEN $1::text = '' THEN pm.status IN ('pending', 'in_progress') ELSE pm.status = $1 END
+			AND ($2::text = '' OR pm.assigned_to = $2)
+		ORDER BY `+order+`
+		LIMIT $3 OFFSET $4
+	`, arg.Status, arg.AssignedTo, arg.Limit, arg.Offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*entity.ModerationTask
+
+	for rows.Next() {
+		task, err := scanModerationTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// ClaimModerationTask assigns a pending task to the moderator. The status
+// is checked by the UPDATE itself: of two moderators claiming the same
+// task the second one waits for the row lock, sees it in progress and
+// gets pgx.ErrNoRows. Claiming a task again by its moderator succeeds.
+func (r *ModerationRepository) ClaimModerationTask(ctx context.Context, domain, moderator string) (*entity.ModerationTask, error) {
+	query := moderationSelect(`
+		UPDATE pending_moderation SET
+			status = 'in_progress',
+			assigned_to = $2,
+			claimed_at = COALESCE(claimed_at, NOW()),
+			updated_at = NOW()
+		WHERE domain = $1 AND (
+			status = 'pending'
+			OR (status = 'in_progress' AND assigned_to = $2)
+		)`)
+
+	return scanModerationTask(r.pool.QueryRow(ctx, query, domain, moderator))
+}
+
+// ResolveModerationTask closes a task claimed by the moderator and stores
+// the verdict as a manual one in the same transaction, so the pipeline
+// does not replace it. pgx.ErrNoRows means the task is not claimed by
+// the moderator.
+func (r *ModerationRepository) ResolveModerationTask(ctx context.Context, arg *entity.ResolveModerationTaskParams) (*entity.ModerationTask, error) {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	task, err := scanModerationTask(tx.QueryRow(ctx, moderationSelect(`
+		UPDATE pending_moderation SET
+			status = 'resolved',
+			resolution = $3,
+			resolved_by = $2,
+			moderator_notes = $4,
+			resolved_at = NOW(),
+			updated_at = NOW()
+		WHERE domain = $1 AND status = 'in_progress' AND assigned_to = $2`),
+		arg.Domain, arg.Moderator, arg.Resolution, arg.Notes,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE domains SET
+			status = $2,
+			verified_by = $3,
+			verification_method = 'manual',
+			expires_at = NULL,
+			updated_at = NOW()
+		WHERE domain = $1
+	`, arg.Domain, arg.Resolution, arg.Moderator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save moderator verdict: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+// UpdateModerationTaskPriority changes the priority of an open task.
+// pgx.ErrNoRows means there is no open task for the domain.
+func (r *ModerationRepository) UpdateModerationTaskPriority(ctx context.Context, domain string, priority int) (*entity.ModerationTask, error) {
+	query := moderationSelect(`
+		UPDATE pending_moderation SET
+			priority = $2,
+			updated_at = NOW()
+		WHERE domain = $1 AND status IN ('pending', 'in_progress')`)
+
+	return scanModerationTask(r.pool.QueryRow(ctx, query, domain, priority))
+}
+
+// CloseModerationTask resolves the open task of a domain that got a
+// verified or scam status without a moderator. It is a no-op if the
+// domain has no open task.
+func (r *ModerationRepository) CloseModerationTask(ctx context.Context, domain, resolution string) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE pending_moderation SET
+			status = 'resolved',
+			resolution = $2,
+			resolved_by = $3,
+			resolved_at = NOW(),
+			updated_at = NOW()
+		WHERE domain = $1 AND status IN ('pending', 'in_progress')
+	`, domain, resolution, entity.ModerationResolvedBySystem)
+	return err
+}
+
+func scanModerationTask(row pgx.Row) (*entity.ModerationTask, error) {
+	var task entity.ModerationTask
+
+	err := row.Scan(
+		&task.Domain,
+		&task.CheckID,
+		&task.Reasons,
+		&task.SourceModules,
+		&task.Priority,
+		&task.Status,
+		&task.AssignedTo,
+		&task.Resolution,
+		&task.ResolvedBy,
+		&task.Notes,
+		&task.SubmittedAt,
+		&task.ClaimedAt,
+		&task.ResolvedAt,
+		&task.UpdatedAt,
+		&task.RiskScore,
+		&task.CompanyName,
+		&task.Country,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
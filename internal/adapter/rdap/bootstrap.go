@@ -0,0 +1,175 @@
+package rdap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// IANABootstrapURL is the bootstrap file of all TLDs published by IANA.
+const IANABootstrapURL = "https://data.iana.org/rdap/dns.json"
+
+const (
+	// bootstrapTTL is how long a fetched file is used, IANA updates it
+	// a few times a month.
+	bootstrapTTL = 24 * time.Hour
+	// bootstrapRetry is the pause after a failed fetch, lookups meanwhile
+	// fail and go to WHOIS.
+	bootstrapRetry = time.Minute
+)
+
+// BootstrapSource gives the bootstrap to use for a lookup.
+type BootstrapSource interface {
+	Bootstrap(ctx context.Context) (*Bootstrap, error)
+}
+
+// Bootstrap maps TLDs to RDAP base URLs (RFC 9224).
+type Bootstrap struct {
+	Publication string
+	servers     map[string]string
+}
+
+type bootstrapFile struct {
+	Publication string       `json:"publication"`
+	Services    [][][]string `json:"services"`
+}
+
+// ParseBootstrap reads a bootstrap file in the IANA dns.json format.
+func ParseBootstrap(r io.Reader) (*Bootstrap, error) {
+	var f bootstrapFile
+	if err := json.NewDecoder(r).Decode(&f); err != nil {
+		return nil, fmt.Errorf("invalid rdap bootstrap file: %w", err)
+	}
+
+	b := &Bootstrap{Publication: f.Publication, servers: make(map[string]string)}
+	for _, service := range f.Services {
+		if len(service) != 2 || len(service[1]) == 0 {
+			continue
+		}
+		base := pickURL(service[1])
+		for _, tld := range service[0] {
+			b.servers[strings.ToLower(tld)] = base
+		}
+	}
+
+	if len(b.servers) == 0 {
+		return nil, fmt.Errorf("rdap bootstrap file has no services")
+	}
+	return b, nil
+}
+
+// LoadBootstrapFile reads a bootstrap file from disk.
+func LoadBootstrapFile(path string) (*Bootstrap, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open rdap bootstrap file: %w", err)
+	}
+	defer f.Close()
+
+	return ParseBootstrap(f)
+}
+
+// BootstrapLoader fetches the bootstrap file over HTTP on first use and
+// refetches it once a day. A stale file is kept when a refetch fails.
+type BootstrapLoader struct {
+	url    string
+	client *http.Client
+
+	mu        sync.Mutex
+	cached    *Bootstrap
+	fetchedAt time.Time
+	failedAt  time.Time
+}
+
+func NewBootstrapLoader(url string, client *http.Client) *BootstrapLoader {
+	return &BootstrapLoader{url: url, client: client}
+}
+
+func (l *BootstrapLoader) Bootstrap(ctx context.Context) (*Bootstrap, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	if l.cached != nil && now.Sub(l.fetchedAt) < bootstrapTTL {
+		return l.cached, nil
+	}
+	if now.Sub(l.failedAt) < bootstrapRetry {
+		if l.cached != nil {
+			return l.cached, nil
+		}
+		return nil, fmt.Errorf("rdap bootstrap file %s is not loaded yet", l.url)
+	}
+
+	b, err := l.fetch(ctx)
+	if err != nil {
+		l.failedAt = now
+		if l.cached != nil {
+			return l.cached, nil
+		}
+		return nil, err
+	}
+
+	l.cached, l.fetchedAt = b, now
+	return b, nil
+}
+
+func (l *BootstrapLoader) fetch(ctx context.Context) (*Bootstrap, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rdap bootstrap request: %w", err)
+	}
+
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rdap bootstrap file: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("rdap bootstrap file %s returned status %d", l.url, resp.StatusCode)
+	}
+
+	return ParseBootstrap(io.LimitReader(resp.Body, maxResponse))
+}
+
+// Bootstrap lets a fixed bootstrap be used as a BootstrapSource.
+func (b *Bootstrap) Bootstrap(context.Context) (*Bootstrap, error) {
+	return b, nil
+}
+
+// NewBootstrap builds a bootstrap from a TLD to base URL map.
+func NewBootstrap(servers map[string]string) *Bootstrap {
+	b := &Bootstrap{servers: make(map[string]string, len(servers))}
+	for tld, base := range servers {
+		b.servers[strings.ToLower(tld)] = base
+	}
+	return b
+}
+
+// ServerFor returns the RDAP base URL of the TLD, always ending with "/".
+func (b *Bootstrap) ServerFor(tld string) (string, bool) {
+	base, ok := b.servers[strings.ToLower(strings.Trim(tld, "."))]
+	if !ok {
+		return "", false
+	}
+	if !strings.HasSuffix(base, "/") {
+		base += "/"
+	}
+	return base, true
+}
+
+// pickURL prefers https as RFC 9224 recommends.
+func pickURL(urls []string) string {
+	for _, u := range urls {
+		if strings.HasPrefix(u, "https://") {
+			return u
+		}
+	}
+	return urls[0]
+}
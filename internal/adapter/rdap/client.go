@@ -0,0 +1,96 @@
+package rdap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/safehttp"
+)
+
+const (
+	contentType = "application/rdap+json"
+	maxResponse = 1 << 20
+)
+
+var (
+	ErrNoServer = errors.New("no rdap server for the tld")
+	// ErrNotFound is a 404 from the RDAP server. It is not taken as "not
+	// registered" since a stale or wrong bootstrap URL answers the same.
+	ErrNotFound = errors.New("rdap server has no such domain")
+)
+
+// Client queries RDAP servers (RFC 9082, RFC 9083) found in the bootstrap file.
+type Client struct {
+	bootstrap BootstrapSource
+	client    *http.Client
+}
+
+// NewClient creates a client. A nil http client uses a safehttp client
+// with the given timeout, a nil bootstrap fetches the IANA file with it.
+func NewClient(bootstrap BootstrapSource, client *http.Client, timeout time.Duration) *Client {
+	if client == nil {
+		client = safehttp.New(safehttp.Config{Timeout: timeout})
+	}
+	if bootstrap == nil {
+		bootstrap = NewBootstrapLoader(IANABootstrapURL, client)
+	}
+
+	return &Client{bootstrap: bootstrap, client: client}
+}
+
+// Lookup fetches the domain object from the RDAP server of its TLD.
+func (c *Client) Lookup(ctx context.Context, domain string) (*entity.Registration, error) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	tld := domain[strings.LastIndex(domain, ".")+1:]
+
+	bootstrap, err := c.bootstrap.Bootstrap(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	base, ok := bootstrap.ServerFor(tld)
+	if !ok {
+		return nil, fmt.Errorf("%w: .%s", ErrNoServer, tld)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+"domain/"+url.PathEscape(domain), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rdap request: %w", err)
+	}
+	req.Header.Set("Accept", contentType)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("rdap request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s on %s", ErrNotFound, domain, base)
+	case resp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("rdap server %s returned status %d", base, resp.StatusCode)
+	}
+
+	var obj domainObject
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponse)).Decode(&obj); err != nil {
+		return nil, fmt.Errorf("failed to decode rdap response: %w", err)
+	}
+	if obj.ObjectClassName != "" && obj.ObjectClassName != "domain" {
+		return nil, fmt.Errorf("rdap server %s returned a %q object", base, obj.ObjectClassName)
+	}
+
+	reg := obj.registration()
+	reg.Domain = domain
+	reg.Server = base
+
+	return reg, nil
+}
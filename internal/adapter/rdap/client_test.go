@@ -0,0 +1,196 @@
+package rdap
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+)
+
+// newServer is an RDAP stand-in serving testdata/<domain>.json under /rdap/domain/.
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, ok := strings.CutPrefix(r.URL.Path, "/rdap/domain/")
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Accept") != contentType {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		if name == "broken.xyz" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		raw, err := os.ReadFile("testdata/" + name + ".json")
+		if err != nil {
+			w.Header().Set("Content-Type", contentType)
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errorCode":404,"title":"Not Found"}`))
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Write(raw)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func date(s string) time.Time {
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
+
+func TestLookup(t *testing.T) {
+	srv := newServer(t)
+	client := NewClient(NewBootstrap(map[string]string{"xyz": srv.URL + "/rdap"}), srv.Client(), 5*time.Second)
+
+	reg, err := client.Lookup(context.Background(), "Example-Shop.xyz.")
+	if err != nil {
+		t.Fatalf("Lookup() unexpected error: %v", err)
+	}
+
+	if reg.Domain != "example-shop.xyz" || reg.Source != entity.RegistrationSourceRDAP || reg.Server != srv.URL+"/rdap/" {
+		t.Errorf("Domain, Source, Server = %q, %q, %q", reg.Domain, reg.Source, reg.Server)
+	}
+	if reg.Registrar != "NameCheap, Inc." || reg.RegistrarIANAID != "1068" {
+		t.Errorf("Registrar = %q (%q); want NameCheap, Inc. (1068)", reg.Registrar, reg.RegistrarIANAID)
+	}
+
+	dates := []struct {
+		field string
+		got   *time.Time
+		want  string
+	}{
+		{"Created", reg.Created, "2024-03-01T10:15:00Z"},
+		{"Updated", reg.Updated, "2024-03-05T08:00:00Z"},
+		{"Expires", reg.Expires, "2025-03-01T23:59:59Z"},
+	}
+	for _, d := range dates {
+		if d.got == nil || !d.got.Equal(date(d.want)) {
+			t.Errorf("%s = %v; want %s", d.field, d.got, d.want)
+		}
+	}
+
+	if want := []string{"ns1.example-dns.com", "ns2.example-dns.com"}; !reflect.DeepEqual(reg.NameServers, want) {
+		t.Errorf("NameServers = %v; want %v", reg.NameServers, want)
+	}
+	if want := []string{"client transfer prohibited", "server hold"}; !reflect.DeepEqual(reg.Statuses, want) {
+		t.Errorf("Statuses = %v; want %v", reg.Statuses, want)
+	}
+	if reg.AbuseEmail != "abuse@namecheap.com" || reg.AbusePhone != "+1.6613102107" {
+		t.Errorf("abuse contact = %q, %q", reg.AbuseEmail, reg.AbusePhone)
+	}
+	if reg.RegistrantOrg != "Example Shop LLP" || reg.RegistrantCountry != "KZ" {
+		t.Errorf("registrant = %q, %q; want Example Shop LLP, KZ", reg.RegistrantOrg, reg.RegistrantCountry)
+	}
+}
+
+func TestLookupErrors(t *testing.T) {
+	srv := newServer(t)
+	client := NewClient(NewBootstrap(map[string]string{"xyz": srv.URL + "/rdap/"}), srv.Client(), 5*time.Second)
+
+	tests := []struct {
+		domain string
+		want   error
+	}{
+		{domain: "free-name.xyz", want: ErrNotFound},
+		{domain: "kaspi.kz", want: ErrNoServer},
+		{domain: "broken.xyz"},
+	}
+
+	for _, tc := range tests {
+		_, err := client.Lookup(context.Background(), tc.domain)
+		if err == nil {
+			t.Errorf("Lookup(%q) expected error, got none", tc.domain)
+			continue
+		}
+		if tc.want != nil && !errors.Is(err, tc.want) {
+			t.Errorf("Lookup(%q) error = %v; want %v", tc.domain, err, tc.want)
+		}
+		// a 404 must leave the verdict to the next lookup
+		if errors.Is(err, entity.ErrNotRegistered) {
+			t.Errorf("Lookup(%q) error = %v; want it not to mean not registered", tc.domain, err)
+		}
+	}
+}
+
+func TestBootstrap(t *testing.T) {
+	raw := `{"services":[[["com","NET"],["http://rdap.example/","https://rdap.example/"]],[["bad"]]]}`
+	b, err := ParseBootstrap(strings.NewReader(raw))
+	if err != nil {
+		t.Fatalf("ParseBootstrap() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		tld    string
+		want   string
+		wantOk bool
+	}{
+		{tld: "com", want: "https://rdap.example/", wantOk: true},
+		{tld: ".net", want: "https://rdap.example/", wantOk: true},
+		{tld: "kz", wantOk: false},
+		{tld: "bad", wantOk: false},
+	}
+	for _, tc := range tests {
+		got, ok := b.ServerFor(tc.tld)
+		if got != tc.want || ok != tc.wantOk {
+			t.Errorf("ServerFor(%q) = %q, %v; want %q, %v", tc.tld, got, ok, tc.want, tc.wantOk)
+		}
+	}
+
+	if _, err := ParseBootstrap(strings.NewReader(`{"services":[]}`)); err == nil {
+		t.Error("ParseBootstrap() expected error for an empty file, got none")
+	}
+}
+
+func TestBootstrapLoader(t *testing.T) {
+	rdapSrv := newServer(t)
+
+	var fetches int
+	fail := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		if fail {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"services":[[["xyz"],["` + rdapSrv.URL + `/rdap/"]]]}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	client := NewClient(NewBootstrapLoader(srv.URL, srv.Client()), srv.Client(), 5*time.Second)
+	for i := 0; i < 2; i++ {
+		if _, err := client.Lookup(context.Background(), "example-shop.xyz"); err != nil {
+			t.Fatalf("Lookup() unexpected error: %v", err)
+		}
+	}
+	if fetches != 1 {
+		t.Errorf("bootstrap fetched %d times; want 1", fetches)
+	}
+
+	fail = true
+	broken := NewBootstrapLoader(srv.URL, srv.Client())
+	if _, err := broken.Bootstrap(context.Background()); err == nil {
+		t.Error("Bootstrap() expected error for a failing server, got none")
+	}
+	// a failed fetch is not retried right away
+	if _, err := broken.Bootstrap(context.Background()); err == nil {
+		t.Error("Bootstrap() expected error while waiting to retry, got none")
+	}
+	if fetches != 2 {
+		t.Errorf("bootstrap fetched %d times; want 2", fetches)
+	}
+}
@@ -0,0 +1,204 @@
+package rdap
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+)
+
+// domainObject is the part of the RDAP domain object (RFC 9083) we use.
+type domainObject struct {
+	ObjectClassName string       `json:"objectClassName"`
+	LDHName         string       `json:"ldhName"`
+	Status          []string     `json:"status"`
+	Events          []event      `json:"events"`
+	Entities        []rdapEntity `json:"entities"`
+	Nameservers     []struct {
+		LDHName string `json:"ldhName"`
+	} `json:"nameservers"`
+}
+
+type event struct {
+	Action string `json:"eventAction"`
+	Date   string `json:"eventDate"`
+}
+
+type rdapEntity struct {
+	Roles     []string        `json:"roles"`
+	VCard     json.RawMessage `json:"vcardArray"`
+	PublicIDs []publicID      `json:"publicIds"`
+	Entities  []rdapEntity    `json:"entities"`
+}
+
+type publicID struct {
+	Type       string `json:"type"`
+	Identifier string `json:"identifier"`
+}
+
+func (o *domainObject) registration() *entity.Registration {
+	reg := &entity.Registration{
+		Source:   entity.RegistrationSourceRDAP,
+		Statuses: o.Status,
+	}
+
+	for _, e := range o.Events {
+		t, ok := parseDate(e.Date)
+		if !ok {
+			continue
+		}
+		switch e.Action {
+		case "registration":
+			reg.Created = t
+		case "expiration":
+			reg.Expires = t
+		case "last changed":
+			reg.Updated = t
+		}
+	}
+
+	for _, ns := range o.Nameservers {
+		if ns.LDHName != "" {
+			reg.NameServers = append(reg.NameServers, strings.ToLower(ns.LDHName))
+		}
+	}
+
+	for _, e := range o.Entities {
+		switch {
+		case e.hasRole("registrar"):
+			card := parseVCard(e.VCard)
+			reg.Registrar = card.fn
+			for _, id := range e.PublicIDs {
+				if id.Type == "IANA Registrar ID" {
+					reg.RegistrarIANAID = id.Identifier
+				}
+			}
+			// abuse contacts are nested in the registrar entity
+			for _, nested := range e.Entities {
+				if nested.hasRole("abuse") {
+					abuse := parseVCard(nested.VCard)
+					reg.AbuseEmail, reg.AbusePhone = abuse.email, abuse.tel
+				}
+			}
+		case e.hasRole("registrant"):
+			card := parseVCard(e.VCard)
+			reg.RegistrantOrg = card.org
+			if reg.RegistrantOrg == "" {
+				reg.RegistrantOrg = card.fn
+			}
+			reg.RegistrantCountry = card.country
+		}
+	}
+
+	// some registries put the abuse contact at the top level
+	if reg.AbuseEmail == "" {
+		for _, e := range o.Entities {
+			if e.hasRole("abuse") {
+				abuse := parseVCard(e.VCard)
+				reg.AbuseEmail, reg.AbusePhone = abuse.email, abuse.tel
+			}
+		}
+	}
+
+	return reg
+}
+
+func (e *rdapEntity) hasRole(role string) bool {
+	for _, r := range e.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// vcard holds the jCard (RFC 7095) properties we use.
+type vcard struct {
+	fn, org, email, tel, country string
+}
+
+// parseVCard reads a jCard: ["vcard", [[name, params, type, value...], ...]].
+func parseVCard(raw json.RawMessage) vcard {
+	var card vcard
+
+	var arr []json.RawMessage
+	if len(raw) == 0 || json.Unmarshal(raw, &arr) != nil || len(arr) != 2 {
+		return card
+	}
+	var props [][]json.RawMessage
+	if json.Unmarshal(arr[1], &props) != nil {
+		return card
+	}
+
+	for _, prop := range props {
+		if len(prop) < 4 {
+			continue
+		}
+		var name string
+		if json.Unmarshal(prop[0], &name) != nil {
+			continue
+		}
+
+		switch strings.ToLower(name) {
+		case "fn":
+			card.fn = text(prop[3])
+		case "org":
+			card.org = text(prop[3])
+		case "email":
+			if card.email == "" {
+				card.email = text(prop[3])
+			}
+		case "tel":
+			if card.tel == "" {
+				card.tel = strings.TrimPrefix(text(prop[3]), "tel:")
+			}
+		case "adr":
+			card.country = adrCountry(prop)
+		}
+	}
+
+	return card
+}
+
+// text returns a string value or the first string of a structured one.
+func text(raw json.RawMessage) string {
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return strings.TrimSpace(s)
+	}
+	var parts []string
+	if json.Unmarshal(raw, &parts) == nil && len(parts) > 0 {
+		return strings.TrimSpace(parts[0])
+	}
+	return ""
+}
+
+// adrCountry takes the country from the "cc" parameter or the last
+// address component when it is a two letter code.
+func adrCountry(prop []json.RawMessage) string {
+	var params map[string]any
+	if json.Unmarshal(prop[1], &params) == nil {
+		if cc, ok := params["cc"].(string); ok && len(cc) == 2 {
+			return strings.ToUpper(cc)
+		}
+	}
+
+	var parts []any
+	if json.Unmarshal(prop[3], &parts) != nil || len(parts) == 0 {
+		return ""
+	}
+	if country, ok := parts[len(parts)-1].(string); ok && len(country) == 2 {
+		return strings.ToUpper(country)
+	}
+	return ""
+}
+
+func parseDate(s string) (*time.Time, bool) {
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(s))
+	if err != nil {
+		return nil, false
+	}
+	t = t.UTC()
+	return &t, true
+}
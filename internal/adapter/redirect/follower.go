@@ -0,0 +1,317 @@
+package redirect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/safehttp"
+	"github.com/ItsXomyak/scam-list/pkg/utils"
+)
+
+const (
+	DefaultMaxHops = 10
+
+	// meta refresh and redirect scripts sit at the top of the page
+	maxBody = 64 << 10
+)
+
+var (
+	ErrInvalidURL = errors.New("invalid url")
+
+	// location = "...", location.href = '...', location.replace("...") at
+	// the start of a statement
+	jsRedirectRe = regexp.MustCompile(`(?:^|[;\s{}])(?:window\.|document\.|top\.|self\.)?location(?:\.href)?\s*(?:=|\.replace\(|\.assign\()\s*["']([^"']+)["']`)
+
+	// setTimeout(function() { and setTimeout(() => { run on their own,
+	// unlike click handlers and other functions
+	timerRe = regexp.MustCompile(`setTimeout\(\s*(?:function\s*\(\s*\)|\(\s*\)\s*=>)\s*$`)
+)
+
+// Follower follows HTTP, meta refresh and simple JavaScript redirects.
+type Follower struct {
+	client  *http.Client
+	maxHops int
+}
+
+// NewFollower creates a follower. A nil client uses a safehttp client with
+// the given timeout per request, maxHops <= 0 means DefaultMaxHops.
+func NewFollower(client *http.Client, timeout time.Duration, maxHops int) *Follower {
+	if client == nil {
+		client = safehttp.New(safehttp.Config{Timeout: timeout})
+	}
+	if maxHops <= 0 {
+		maxHops = DefaultMaxHops
+	}
+
+	// hops are followed one by one to record each of them
+	c := *client
+	c.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+	return &Follower{client: &c, maxHops: maxHops}
+}
+
+// Follow visits rawURL and every URL it redirects to. A bare domain is
+// tried over https first and then over http. Only a failure on the first
+// hop is an error, the chain ends at the first hop that cannot be fetched.
+func (f *Follower) Follow(ctx context.Context, rawURL string) (*entity.RedirectChain, error) {
+	start, err := startURLs(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		hop  entity.RedirectHop
+		next *url.URL
+	)
+	for _, u := range start {
+		hop, next, err = f.visit(ctx, u)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", rawURL, err)
+	}
+
+	chain := &entity.RedirectChain{Hops: []entity.RedirectHop{hop}}
+	seen := map[string]struct{}{hop.URL: {}}
+
+	for next != nil {
+		if _, ok := seen[next.String()]; ok {
+			// a loop never lands anywhere
+			chain.LimitReached = true
+			break
+		}
+		if len(chain.Hops) > f.maxHops {
+			chain.LimitReached = true
+			break
+		}
+		seen[next.String()] = struct{}{}
+
+		hop, next, err = f.visit(ctx, next)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			// the dead landing page is still where the chain leads
+			next = nil
+		}
+		chain.Hops = append(chain.Hops, hop)
+	}
+
+	return chain, nil
+}
+
+// visit fetches u and returns its hop and the URL it redirects to.
+// On a fetch error the hop has only the URL and host.
+func (f *Follower) visit(ctx context.Context, u *url.URL) (entity.RedirectHop, *url.URL, error) {
+	hop := entity.RedirectHop{URL: u.String(), Host: hostOf(u)}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return hop, nil, err
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,*/*;q=0.8")
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return hop, nil, err
+	}
+	defer resp.Body.Close()
+	hop.StatusCode = resp.StatusCode
+
+	if resp.StatusCode >= 300 && resp.StatusCode < 400 {
+		if next, ok := resolve(u, resp.Header.Get("Location")); ok {
+			hop.Kind = entity.RedirectHTTP
+			return hop, next, nil
+		}
+		return hop, nil, nil
+	}
+
+	if !isHTML(resp.Header.Get("Content-Type")) {
+		return hop, nil, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBody))
+	if err != nil {
+		return hop, nil, nil
+	}
+
+	target, kind := pageRedirect(string(body))
+	if next, ok := resolve(u, target); ok && kind != "" {
+		hop.Kind = kind
+		return hop, next, nil
+	}
+	return hop, nil, nil
+}
+
+// pageRedirect finds a meta refresh or a location assignment in the page.
+func pageRedirect(body string) (string, string) {
+	doc, err := html.Parse(strings.NewReader(body))
+	if err != nil {
+		return "", ""
+	}
+
+	var target, kind string
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if kind != "" {
+			return
+		}
+		if n.Type == html.ElementNode {
+			switch n.DataAtom {
+			case atom.Meta:
+				if strings.EqualFold(attr(n, "http-equiv"), "refresh") {
+					if t := refreshURL(attr(n, "content")); t != "" {
+						target, kind = t, entity.RedirectMeta
+						return
+					}
+				}
+			case atom.Script:
+				if n.FirstChild != nil && attr(n, "src") == "" {
+					if t := scriptRedirect(n.FirstChild.Data); t != "" {
+						target, kind = t, entity.RedirectJS
+						return
+					}
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	return target, kind
+}
+
+// scriptRedirect returns the target of the first location assignment that
+// runs when the script loads: a top-level statement or one in a timer.
+func scriptRedirect(script string) string {
+	for _, m := range jsRedirectRe.FindAllStringSubmatchIndex(script, -1) {
+		if runsOnLoad(script, m[0]) {
+			return script[m[2]:m[3]]
+		}
+	}
+	return ""
+}
+
+// runsOnLoad reports whether every block around pos is a setTimeout
+// callback. String literals are skipped, comments and regexps are not.
+func runsOnLoad(script string, pos int) bool {
+	var blocks []int
+	var quote byte
+	for i := 0; i < pos; i++ {
+		switch c := script[i]; {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+		case c == '{':
+			blocks = append(blocks, i)
+		case c == '}' && len(blocks) > 0:
+			blocks = blocks[:len(blocks)-1]
+		}
+	}
+
+	for _, b := range blocks {
+		if !timerRe.MatchString(script[:b]) {
+			return false
+		}
+	}
+	return true
+}
+
+// refreshURL parses the content of a meta refresh: "0; url=https://...".
+func refreshURL(content string) string {
+	_, rest, ok := strings.Cut(content, ";")
+	if !ok {
+		rest = content
+	}
+	rest = strings.TrimSpace(rest)
+	if len(rest) >= 4 && strings.EqualFold(rest[:4], "url=") {
+		rest = rest[4:]
+	} else if !ok {
+		return ""
+	}
+	return strings.Trim(strings.TrimSpace(rest), `"'`)
+}
+
+func startURLs(raw string) ([]*url.URL, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return nil, ErrInvalidURL
+	}
+
+	if strings.Contains(raw, "://") {
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidURL, raw)
+		}
+		return []*url.URL{u}, nil
+	}
+
+	var out []*url.URL
+	for _, scheme := range []string{"https", "http"} {
+		u, err := url.Parse(scheme + "://" + raw)
+		if err != nil || u.Host == "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidURL, raw)
+		}
+		out = append(out, u)
+	}
+	return out, nil
+}
+
+func resolve(base *url.URL, ref string) (*url.URL, bool) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, false
+	}
+	u, err := base.Parse(ref)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, false
+	}
+	u.Fragment = ""
+	return u, true
+}
+
+// hostOf returns the ASCII host of the URL.
+func hostOf(u *url.URL) string {
+	host, err := utils.NormalizeDomain(u.Hostname())
+	if err != nil {
+		return strings.ToLower(u.Hostname())
+	}
+	return host
+}
+
+func isHTML(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == "text/html" || mediaType == "application/xhtml+xml"
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,202 @@
+package redirect
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+)
+
+// newWeb is a stand-in for several sites, routed by the Host header.
+// Keys are "host/path".
+func newWeb(t *testing.T, pages map[string]http.HandlerFunc) *http.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h, ok := pages[r.Host+r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		h(w, r)
+	}))
+	t.Cleanup(srv.Close)
+
+	addr := srv.Listener.Addr().String()
+	return &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+				if strings.HasSuffix(address, ":443") {
+					// the stand-in has no TLS
+					return nil, fmt.Errorf("connection refused: %s", address)
+				}
+				var d net.Dialer
+				return d.DialContext(ctx, network, addr)
+			},
+		},
+	}
+}
+
+func redirectTo(url string, code int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, url, code)
+	}
+}
+
+func page(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, body)
+	}
+}
+
+func TestFollow(t *testing.T) {
+	client := newWeb(t, map[string]http.HandlerFunc{
+		"bit.ly/abc":       redirectTo("http://x.top/go?id=1", http.StatusMovedPermanently),
+		"x.top/go":         page(`<html><head><meta http-equiv="Refresh" content="0; URL='/land'"></head></html>`),
+		"x.top/land":       page(`<script>setTimeout(function(){ window.location.href = "http://kaspi-bonus.xyz/"; }, 10)</script>`),
+		"kaspi-bonus.xyz/": page(`<html><title>Kaspi bonus</title></html>`),
+		"loop.top/a":       redirectTo("/b", http.StatusFound),
+		"loop.top/b":       redirectTo("/a", http.StatusFound),
+		"dead-end.top/":    redirectTo("http://gone.invalid/", http.StatusFound),
+		"plain.kz/":        page(`<html><body>hello, location = "nowhere" in text</body></html>`),
+		"replace.kz/":      page(`<script>location.replace('http://plain.kz/')</script>`),
+		"bare.kz/":         redirectTo("/home", http.StatusMovedPermanently),
+		"bare.kz/home":     page(`ok`),
+		"button.kz/":       page(`<script>var allocation = "x"; document.getElementById("pay").onclick = function() { location.href = "http://kaspi-bonus.xyz/"; };</script>`),
+		"arrow.kz/":        page(`<script>setTimeout(() => { location = "/next"; }, 0)</script>`),
+		"arrow.kz/next":    page(`ok`),
+	})
+
+	tests := []struct {
+		url       string
+		wantHosts []string
+		wantKinds []string
+		wantLimit bool
+	}{
+		{
+			url:       "http://bit.ly/abc",
+			wantHosts: []string{"bit.ly", "x.top", "x.top", "kaspi-bonus.xyz"},
+			wantKinds: []string{entity.RedirectHTTP, entity.RedirectMeta, entity.RedirectJS, ""},
+		},
+		{
+			url:       "http://loop.top/a",
+			wantHosts: []string{"loop.top", "loop.top"},
+			wantKinds: []string{entity.RedirectHTTP, entity.RedirectHTTP},
+			wantLimit: true,
+		},
+		{
+			url:       "http://dead-end.top/",
+			wantHosts: []string{"dead-end.top", "gone.invalid"},
+			wantKinds: []string{entity.RedirectHTTP, ""},
+		},
+		{
+			url:       "plain.kz",
+			wantHosts: []string{"plain.kz"},
+			wantKinds: []string{""},
+		},
+		{
+			url:       "http://replace.kz/",
+			wantHosts: []string{"replace.kz", "plain.kz"},
+			wantKinds: []string{entity.RedirectJS, ""},
+		},
+		{
+			url:       "http://button.kz/",
+			wantHosts: []string{"button.kz"},
+			wantKinds: []string{""},
+		},
+		{
+			url:       "http://arrow.kz/",
+			wantHosts: []string{"arrow.kz", "arrow.kz"},
+			wantKinds: []string{entity.RedirectJS, ""},
+		},
+		{
+			url:       "bare.kz",
+			wantHosts: []string{"bare.kz", "bare.kz"},
+			wantKinds: []string{entity.RedirectHTTP, ""},
+		},
+	}
+
+	f := NewFollower(client, 0, 0)
+	for _, tc := range tests {
+		t.Run(tc.url, func(t *testing.T) {
+			chain, err := f.Follow(context.Background(), tc.url)
+			if err != nil {
+				t.Fatalf("Follow() unexpected error: %v", err)
+			}
+
+			var hosts, kinds []string
+			for _, hop := range chain.Hops {
+				hosts = append(hosts, hop.Host)
+				kinds = append(kinds, hop.Kind)
+			}
+			if !reflect.DeepEqual(hosts, tc.wantHosts) {
+				t.Errorf("hosts = %v; want %v", hosts, tc.wantHosts)
+			}
+			if !reflect.DeepEqual(kinds, tc.wantKinds) {
+				t.Errorf("kinds = %v; want %v", kinds, tc.wantKinds)
+			}
+			if chain.LimitReached != tc.wantLimit {
+				t.Errorf("LimitReached = %v; want %v", chain.LimitReached, tc.wantLimit)
+			}
+		})
+	}
+}
+
+func TestFollowLimit(t *testing.T) {
+	pages := make(map[string]http.HandlerFunc)
+	for i := 0; i < 10; i++ {
+		pages[fmt.Sprintf("hop.top/%d", i)] = redirectTo(fmt.Sprintf("/%d", i+1), http.StatusFound)
+	}
+
+	chain, err := NewFollower(newWeb(t, pages), 0, 3).Follow(context.Background(), "http://hop.top/0")
+	if err != nil {
+		t.Fatalf("Follow() unexpected error: %v", err)
+	}
+	if !chain.LimitReached || len(chain.Hops) != 4 {
+		t.Errorf("hops = %d, LimitReached = %v; want 4, true", len(chain.Hops), chain.LimitReached)
+	}
+}
+
+func TestFollowErrors(t *testing.T) {
+	f := NewFollower(newWeb(t, nil), 0, 0)
+
+	for _, raw := range []string{"", "ftp://files.kz/", "http://"} {
+		if _, err := f.Follow(context.Background(), raw); err == nil {
+			t.Errorf("Follow(%q) expected error, got none", raw)
+		}
+	}
+
+	down := NewFollower(&http.Client{Transport: &http.Transport{
+		DialContext: func(context.Context, string, string) (net.Conn, error) {
+			return nil, fmt.Errorf("connection refused")
+		},
+	}}, 0, 0)
+	if _, err := down.Follow(context.Background(), "bit.ly/abc"); err == nil {
+		t.Error("Follow() expected error when the first hop is down, got none")
+	}
+}
+
+func TestRefreshURL(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{in: "0; url=https://a.kz/", want: "https://a.kz/"},
+		{in: "5;URL='/next'", want: "/next"},
+		{in: `0; url="b.kz"`, want: "b.kz"},
+		{in: "30", want: ""},
+	}
+	for _, tc := range tests {
+		if got := refreshURL(tc.in); got != tc.want {
+			t.Errorf("refreshURL(%q) = %q; want %q", tc.in, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package whois
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/safehttp"
+)
+
+const (
+	// IANAServer knows the WHOIS server of every TLD.
+	IANAServer = "whois.iana.org"
+
+	port         = "43"
+	maxReferrals = 2
+	maxResponse  = 256 << 10
+)
+
+var (
+	ErrNoServer = errors.New("no whois server for the tld")
+	ErrNoData   = errors.New("whois answer has no registration data")
+)
+
+// Dialer opens TCP connections to WHOIS servers.
+type Dialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// Client queries WHOIS servers over port 43.
+type Client struct {
+	dialer  Dialer
+	servers map[string]string // tld -> server, skips the IANA lookup
+	timeout time.Duration
+}
+
+// NewClient creates a client. servers overrides WHOIS servers per TLD,
+// e.g. {"kz": "whois.nic.kz"}, a nil dialer is a safehttp.Dialer since
+// referrals come from remote servers.
+func NewClient(dialer Dialer, servers map[string]string, timeout time.Duration) *Client {
+	if dialer == nil {
+		dialer = safehttp.NewDialer(nil, nil, timeout)
+	}
+
+	normalized := make(map[string]string, len(servers))
+	for tld, server := range servers {
+		normalized[strings.ToLower(strings.Trim(tld, "."))] = server
+	}
+
+	return &Client{dialer: dialer, servers: normalized, timeout: timeout}
+}
+
+// Lookup finds the TLD server, queries it and follows the referral to the
+// registrar server. Fields from the registrar answer win.
+func (c *Client) Lookup(ctx context.Context, domain string) (*entity.Registration, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	server, err := c.serverFor(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var reg *entity.Registration
+	for i := 0; i <= maxReferrals && server != ""; i++ {
+		raw, err := c.query(ctx, server, domain)
+		if err != nil {
+			if reg != nil {
+				// the registry answer is enough when the registrar server is down
+				return reg, nil
+			}
+			return nil, err
+		}
+
+		parsed, referral := Parse(raw)
+		if parsed == nil {
+			if reg != nil {
+				return reg, nil
+			}
+			if NotRegistered(raw) {
+				return nil, fmt.Errorf("%s: %w", domain, entity.ErrNotRegistered)
+			}
+			// rate limits and empty answers say nothing about the domain
+			return nil, fmt.Errorf("%s from %s: %w", domain, server, ErrNoData)
+		}
+		parsed.Domain = domain
+		parsed.Server = server
+		reg = merge(reg, parsed)
+
+		if referral == "" || strings.EqualFold(referral, server) {
+			break
+		}
+		server = referral
+	}
+
+	return reg, nil
+}
+
+// serverFor returns the configured server of the TLD or asks IANA for it.
+func (c *Client) serverFor(ctx context.Context, domain string) (string, error) {
+	tld := domain[strings.LastIndex(domain, ".")+1:]
+	if server, ok := c.servers[tld]; ok {
+		return server, nil
+	}
+
+	raw, err := c.query(ctx, IANAServer, tld)
+	if err != nil {
+		return "", fmt.Errorf("failed to ask iana for .%s: %w", tld, err)
+	}
+
+	for _, line := range strings.Split(raw, "\n") {
+		key, value, ok := splitLine(line)
+		if ok && (key == "refer" || key == "whois") && value != "" {
+			return value, nil
+		}
+	}
+	return "", fmt.Errorf("%w: .%s", ErrNoServer, tld)
+}
+
+func (c *Client) query(ctx context.Context, server, q string) (string, error) {
+	addr := server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		addr = net.JoinHostPort(server, port)
+	}
+
+	conn, err := c.dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return "", fmt.Errorf("failed to connect to %s: %w", server, err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if _, err := io.WriteString(conn, q+"\r\n"); err != nil {
+		return "", fmt.Errorf("failed to query %s: %w", server, err)
+	}
+
+	raw, err := io.ReadAll(io.LimitReader(conn, maxResponse))
+	if err != nil {
+		return "", fmt.Errorf("failed to read answer of %s: %w", server, err)
+	}
+
+	return string(raw), nil
+}
+
+// merge fills empty fields of base from next, non-empty fields of next win.
+func merge(base, next *entity.Registration) *entity.Registration {
+	if base == nil {
+		return next
+	}
+
+	out := *base
+	setString := func(dst *string, v string) {
+		if v != "" {
+			*dst = v
+		}
+	}
+	setTime := func(dst **time.Time, v *time.Time) {
+		if v != nil {
+			*dst = v
+		}
+	}
+
+	setString(&out.Registrar, next.Registrar)
+	setString(&out.RegistrarIANAID, next.RegistrarIANAID)
+	setString(&out.Server, next.Server)
+	setString(&out.RegistrantOrg, next.RegistrantOrg)
+	setString(&out.RegistrantCountry, next.RegistrantCountry)
+	setTime(&out.Created, next.Created)
+	setTime(&out.Updated, next.Updated)
+	setTime(&out.Expires, next.Expires)
+	if len(next.NameServers) > 0 {
+		out.NameServers = next.NameServers
+	}
+	if len(next.Statuses) > 0 {
+		out.Statuses = next.Statuses
+	}
+
+	return &out
+}
@@ -0,0 +1,232 @@
+package whois
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+)
+
+// startServer is a WHOIS stand-in answering queries from the map.
+func startServer(t *testing.T, answers map[string]string) string {
+	t.Helper()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				q, err := bufio.NewReader(conn).ReadString('\n')
+				if err != nil {
+					return
+				}
+				answer, ok := answers[strings.TrimSpace(q)]
+				if !ok {
+					answer = "No match for \"" + strings.TrimSpace(q) + "\".\r\n"
+				}
+				conn.Write([]byte(answer))
+			}(conn)
+		}
+	}()
+
+	return l.Addr().String()
+}
+
+// routeDialer sends connections for a server name to its stand-in.
+type routeDialer map[string]string
+
+func (d routeDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, _, _ := net.SplitHostPort(address)
+	addr, ok := d[host]
+	if !ok {
+		return nil, errors.New("unknown server " + host)
+	}
+	var nd net.Dialer
+	return nd.DialContext(ctx, network, addr)
+}
+
+func fixture(t *testing.T, name string) string {
+	t.Helper()
+	raw, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(raw)
+}
+
+func date(s string) time.Time {
+	t, _ := time.Parse(time.RFC3339, s)
+	return t
+}
+
+func TestLookupKZ(t *testing.T) {
+	dialer := routeDialer{
+		IANAServer:     startServer(t, map[string]string{"kz": "refer:        whois.nic.kz\n"}),
+		"whois.nic.kz": startServer(t, map[string]string{"kaspi.kz": fixture(t, "kaspi.kz.txt")}),
+	}
+
+	reg, err := NewClient(dialer, nil, time.Second).Lookup(context.Background(), "kaspi.kz")
+	if err != nil {
+		t.Fatalf("Lookup() unexpected error: %v", err)
+	}
+
+	if reg.Created == nil || !reg.Created.Equal(date("2004-07-14T13:51:21Z")) {
+		t.Errorf("Created = %v", reg.Created)
+	}
+	if reg.Updated == nil || !reg.Updated.Equal(date("2023-06-19T06:22:16Z")) {
+		t.Errorf("Updated = %v", reg.Updated)
+	}
+	if reg.Expires != nil {
+		t.Errorf("Expires = %v; want nil, whois.nic.kz does not publish it", reg.Expires)
+	}
+	if reg.Registrar != "HOSTER.KZ" {
+		t.Errorf("Registrar = %q", reg.Registrar)
+	}
+	if reg.RegistrantOrg != "Kaspi Bank JSC" || reg.RegistrantCountry != "KZ" {
+		t.Errorf("Registrant = %q, %q", reg.RegistrantOrg, reg.RegistrantCountry)
+	}
+	if strings.Join(reg.NameServers, ",") != "ns1.kaspi.kz,ns2.kaspi.kz" {
+		t.Errorf("NameServers = %v", reg.NameServers)
+	}
+	if strings.Join(reg.Statuses, ",") != "clientTransferProhibited,clientDeleteProhibited" {
+		t.Errorf("Statuses = %v", reg.Statuses)
+	}
+	if reg.Server != "whois.nic.kz" || reg.Source != entity.RegistrationSourceWHOIS {
+		t.Errorf("Server = %q, Source = %q", reg.Server, reg.Source)
+	}
+}
+
+func TestLookupFollowsReferral(t *testing.T) {
+	dialer := routeDialer{
+		"whois.verisign.test":  startServer(t, map[string]string{"example-shop.com": fixture(t, "example.com.registry.txt")}),
+		"whois.registrar.test": startServer(t, map[string]string{"example-shop.com": fixture(t, "example.com.registrar.txt")}),
+	}
+	client := NewClient(dialer, map[string]string{"com": "whois.verisign.test"}, time.Second)
+
+	reg, err := client.Lookup(context.Background(), "Example-Shop.com")
+	if err != nil {
+		t.Fatalf("Lookup() unexpected error: %v", err)
+	}
+
+	if reg.Server != "whois.registrar.test" {
+		t.Errorf("Server = %q; want the registrar server", reg.Server)
+	}
+	if reg.Registrar != "Test Registrar LLC" || reg.RegistrarIANAID != "1068" {
+		t.Errorf("Registrar = %q, %q", reg.Registrar, reg.RegistrarIANAID)
+	}
+	if reg.Created == nil || !reg.Created.Equal(date("2025-01-10T04:00:00Z")) {
+		t.Errorf("Created = %v", reg.Created)
+	}
+	if reg.Expires == nil || !reg.Expires.Equal(date("2026-01-10T04:00:00Z")) {
+		t.Errorf("Expires = %v", reg.Expires)
+	}
+	// the registrar answer is newer
+	if reg.Updated == nil || !reg.Updated.Equal(date("2024-08-15T07:01:34Z")) {
+		t.Errorf("Updated = %v", reg.Updated)
+	}
+	if reg.RegistrantCountry != "IS" {
+		t.Errorf("RegistrantCountry = %q", reg.RegistrantCountry)
+	}
+}
+
+func TestLookupRegistrarDown(t *testing.T) {
+	dialer := routeDialer{
+		"whois.verisign.test": startServer(t, map[string]string{"example-shop.com": fixture(t, "example.com.registry.txt")}),
+	}
+	client := NewClient(dialer, map[string]string{"com": "whois.verisign.test"}, time.Second)
+
+	reg, err := client.Lookup(context.Background(), "example-shop.com")
+	if err != nil {
+		t.Fatalf("Lookup() unexpected error: %v", err)
+	}
+	if reg.Server != "whois.verisign.test" || reg.Created == nil {
+		t.Errorf("Lookup() = %+v; want the registry answer", reg)
+	}
+}
+
+func TestLookupNotRegistered(t *testing.T) {
+	dialer := routeDialer{
+		"whois.nic.kz": startServer(t, map[string]string{"free-name.kz": "*** Nothing found for this query.\n"}),
+	}
+	client := NewClient(dialer, map[string]string{"kz": "whois.nic.kz"}, time.Second)
+
+	_, err := client.Lookup(context.Background(), "free-name.kz")
+	if !errors.Is(err, entity.ErrNotRegistered) {
+		t.Errorf("Lookup() error = %v; want %v", err, entity.ErrNotRegistered)
+	}
+}
+
+func TestLookupNoData(t *testing.T) {
+	answers := map[string]string{
+		"rate limit": "% Query rate limit exceeded. Try again later.\r\n",
+		"error line": "Error: too many requests from your address\r\n",
+		"empty":      "",
+	}
+
+	for name, answer := range answers {
+		t.Run(name, func(t *testing.T) {
+			dialer := routeDialer{
+				"whois.nic.kz": startServer(t, map[string]string{"busy-name.kz": answer}),
+			}
+			client := NewClient(dialer, map[string]string{"kz": "whois.nic.kz"}, time.Second)
+
+			_, err := client.Lookup(context.Background(), "busy-name.kz")
+			if !errors.Is(err, ErrNoData) {
+				t.Errorf("Lookup() error = %v; want %v", err, ErrNoData)
+			}
+			if errors.Is(err, entity.ErrNotRegistered) {
+				t.Errorf("Lookup() error = %v; want the domain not reported as free", err)
+			}
+		})
+	}
+}
+
+func TestLookupUnknownTLD(t *testing.T) {
+	dialer := routeDialer{
+		IANAServer: startServer(t, map[string]string{"zz": "% IANA WHOIS server\n% This query returned 0 objects.\n"}),
+	}
+
+	_, err := NewClient(dialer, nil, time.Second).Lookup(context.Background(), "example.zz")
+	if !errors.Is(err, ErrNoServer) {
+		t.Errorf("Lookup() error = %v; want %v", err, ErrNoServer)
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "2004-07-14 13:51:21 (GMT+0:00)", want: "2004-07-14T13:51:21Z"},
+		{in: "1995-08-14T04:00:00Z", want: "1995-08-14T04:00:00Z"},
+		{in: "2020-01-02T03:04:05.0Z", want: "2020-01-02T03:04:05Z"},
+		{in: "14-Jul-2004", want: "2004-07-14T00:00:00Z"},
+		{in: "2004.07.14", want: "2004-07-14T00:00:00Z"},
+		{in: "garbage", want: ""},
+	}
+
+	for _, tc := range tests {
+		got := parseDate(tc.in)
+		switch {
+		case tc.want == "" && got != nil:
+			t.Errorf("parseDate(%q) = %v; want nil", tc.in, got)
+		case tc.want != "" && (got == nil || got.Format(time.RFC3339) != tc.want):
+			t.Errorf("parseDate(%q) = %v; want %s", tc.in, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package whois
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+)
+
+var (
+	// answers of registries that have no such domain
+	notFoundMarkers = []string{
+		"no match for",
+		"not found",
+		"no entries found",
+		"nothing found",
+		"no data found",
+		"status: free",
+		"status: available",
+		"domain not found",
+		"is available for registration",
+	}
+
+	// "(GMT+0:00)" in whois.nic.kz dates and similar comments
+	parenRe = regexp.MustCompile(`\s*\(.*\)\s*$`)
+
+	dateLayouts = []string{
+		time.RFC3339,
+		"2006-01-02T15:04:05Z",
+		"2006-01-02T15:04:05.0Z",
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04:05",
+		"2006-01-02 15:04:05 MST",
+		"2006-01-02",
+		"2006.01.02 15:04:05",
+		"2006.01.02",
+		"02.01.2006",
+		"02-Jan-2006",
+		"02-Jan-2006 15:04:05",
+		"2-Jan-2006",
+		"January 02 2006",
+		"Mon Jan 2 15:04:05 MST 2006",
+	}
+)
+
+// field keys, lowercase and without dots
+var (
+	createdKeys   = []string{"creation date", "created", "created on", "domain created", "registered on", "registration time", "registered", "domain registration date"}
+	updatedKeys   = []string{"updated date", "last modified", "last updated", "changed", "modified", "last update"}
+	expiresKeys   = []string{"registry expiry date", "registrar registration expiration date", "expiry date", "expiration date", "expires on", "expires", "paid-till", "expire date"}
+	registrarKeys = []string{"registrar", "current registar", "current registrar", "sponsoring registrar", "registrar name"}
+	ianaKeys      = []string{"registrar iana id"}
+	referralKeys  = []string{"registrar whois server", "whois server", "refer"}
+	nsKeys        = []string{"name server", "nserver", "nameserver", "primary server", "secondary server"}
+	statusKeys    = []string{"domain status", "status", "state"}
+	orgKeys       = []string{"registrant organization", "organization name", "org", "registrant"}
+	countryKeys   = []string{"registrant country", "country"}
+)
+
+// Parse reads a WHOIS answer. It returns nil when the answer has no
+// registration data, see NotRegistered for telling a free domain from an
+// error message, and the referral server if the answer has one.
+func Parse(raw string) (reg *entity.Registration, referral string) {
+	fields := make(map[string][]string)
+
+	var lastKey string
+	for _, line := range strings.Split(raw, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "%") || strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+
+		key, value, ok := splitLine(line)
+		if !ok {
+			// whois.nic.kz continues "Domain status" on indented lines
+			if lastKey != "" && strings.HasPrefix(line, " ") && strings.TrimSpace(line) != "" {
+				fields[lastKey] = append(fields[lastKey], strings.TrimSpace(line))
+			} else {
+				lastKey = ""
+			}
+			continue
+		}
+		lastKey = key
+		if value != "" {
+			fields[key] = append(fields[key], value)
+		}
+	}
+
+	if len(fields) == 0 || (first(fields, createdKeys) == "" && NotRegistered(raw)) {
+		return nil, ""
+	}
+
+	reg = &entity.Registration{
+		Source:            entity.RegistrationSourceWHOIS,
+		Registrar:         first(fields, registrarKeys),
+		RegistrarIANAID:   first(fields, ianaKeys),
+		Created:           parseDate(first(fields, createdKeys)),
+		Updated:           parseDate(first(fields, updatedKeys)),
+		Expires:           parseDate(first(fields, expiresKeys)),
+		RegistrantOrg:     first(fields, orgKeys),
+		RegistrantCountry: strings.ToUpper(first(fields, countryKeys)),
+	}
+	if len(reg.RegistrantCountry) != 2 {
+		reg.RegistrantCountry = ""
+	}
+
+	for _, key := range nsKeys {
+		for _, ns := range fields[key] {
+			reg.NameServers = appendUnique(reg.NameServers, strings.ToLower(strings.Fields(ns)[0]))
+		}
+	}
+	for _, key := range statusKeys {
+		for _, s := range fields[key] {
+			reg.Statuses = appendUnique(reg.Statuses, strings.Fields(s)[0])
+		}
+	}
+
+	if reg.Created == nil && reg.Expires == nil && reg.Registrar == "" && len(reg.NameServers) == 0 {
+		// "Error: rate limit exceeded" and other notices
+		return nil, ""
+	}
+
+	referral = first(fields, referralKeys)
+	referral = strings.TrimPrefix(strings.TrimPrefix(referral, "whois://"), "rwhois://")
+	if strings.Contains(referral, "://") || strings.Contains(referral, "/") {
+		// registrar web pages are not whois servers
+		referral = ""
+	}
+
+	return reg, referral
+}
+
+// NotRegistered reports whether the answer says the domain is free.
+func NotRegistered(raw string) bool {
+	return containsAny(strings.ToLower(raw), notFoundMarkers)
+}
+
+// splitLine splits "Key: value" and "Key.........: value" lines. The key
+// is lowercased without the dot padding.
+func splitLine(line string) (key, value string, ok bool) {
+	i := strings.Index(line, ":")
+	if i <= 0 {
+		return "", "", false
+	}
+
+	key = strings.ToLower(strings.TrimSpace(strings.TrimRight(strings.TrimSpace(line[:i]), ".")))
+	if key == "" || strings.Contains(key, "  ") || len(key) > 50 {
+		return "", "", false
+	}
+	return key, strings.TrimSpace(line[i+1:]), true
+}
+
+func first(fields map[string][]string, keys []string) string {
+	for _, k := range keys {
+		if v := fields[k]; len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
+
+func parseDate(s string) *time.Time {
+	s = strings.TrimSpace(parenRe.ReplaceAllString(s, ""))
+	if s == "" {
+		return nil
+	}
+
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			t = t.UTC()
+			return &t
+		}
+	}
+	return nil
+}
+
+func containsAny(s string, subs []string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+func appendUnique(list []string, v string) []string {
+	for _, x := range list {
+		if x == v {
+			return list
+		}
+	}
+	return append(list, v)
+}
@@ -3,16 +3,22 @@ package app
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/ItsXomyak/scam-list/config"
 	httpserver "github.com/ItsXomyak/scam-list/internal/adapter/http/server"
 	"github.com/ItsXomyak/scam-list/internal/adapter/postgres"
+	"github.com/ItsXomyak/scam-list/internal/app/cron"
 	"github.com/ItsXomyak/scam-list/internal/services/domain"
-	"github.com/ItsXomyak/scam-list/internal/services/pipeline"
+	"github.com/ItsXomyak/scam-list/internal/services/feed"
+	"github.com/ItsXomyak/scam-list/internal/services/job"
+	"github.com/ItsXomyak/scam-list/internal/services/moderation"
+	"github.com/ItsXomyak/scam-list/internal/services/recheck"
 	"github.com/ItsXomyak/scam-list/pkg/logger"
 	postgresclient "github.com/ItsXomyak/scam-list/pkg/postgres"
 )
@@ -21,6 +27,8 @@ import (
 type App struct {
 	postgresDB *postgresclient.Postgres
 	httpServer *httpserver.API
+	jobs       *job.Service
+	cron       *cron.Scheduler
 
 	cfg config.Config
 	log logger.Logger
@@ -29,30 +37,65 @@ type App struct {
 // NewApp creates a new instance of the application
 func NewApp(ctx context.Context, cfg config.Config, log logger.Logger) (*App, error) {
 	// Initialize Postgres client
-	postgresDB, err := postgresclient.New(ctx, cfg.Postgres.GetDsn(), &postgresclient.Config{
-		MaxPoolSize:  cfg.Postgres.MaxPoolSize,
-		ConnAttempts: cfg.Postgres.ConnAttempts,
-		ConnTimeout:  cfg.Postgres.ConnTimeout,
-	})
+	postgresDB, err := newPostgres(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
 
 	// repositories
 	domainRepo := postgres.NewDomain(postgresDB.Pool)
+	feedRepo := postgres.NewFeed(postgresDB.Pool)
+	checkRepo := postgres.NewCheck(postgresDB.Pool)
+	moderationRepo := postgres.NewModeration(postgresDB.Pool)
 
 	// services
-	domainSvc := domain.NewDomainService(domainRepo)
+	moderationSvc := moderation.NewService(moderationRepo, log)
+	domainSvc := domain.NewDomainService(domainRepo, moderationSvc)
 
-	// core pipeline
-	domainPipeline := pipeline.NewDomainPipeline(nil, domainSvc)
+	domainPipeline, statuses, err := newPipeline(ctx, cfg, domainSvc, checkRepo, log)
+	if err != nil {
+		return nil, err
+	}
+
+	// blocklist feeds are imported by the API, the blocklist checker answers
+	// from what is already stored and refreshes swap in new entries later
+	feedList, err := loadFeeds(ctx, cfg, feedRepo, log)
+	if err != nil {
+		return nil, err
+	}
+	var feeds *feed.Service
+	if len(feedList) > 0 {
+		client := &http.Client{Timeout: cfg.Feeds.Timeout}
+		feeds = feed.NewService(feedRepo, feedList, feed.DefaultIndex(), client, cfg.Feeds.MaxSize, log)
+	}
+
+	// asynchronous verifications
+	jobs := newJobs(cfg, postgresDB.Pool, domainPipeline, log)
+
+	// expired automatic verdicts are rechecked through the same queue
+	var rechecks *recheck.Service
+	if !cfg.Recheck.Disabled {
+		rechecks = recheck.NewService(domainRepo, jobs, recheck.Config{
+			Batch: cfg.Recheck.Batch,
+			Lease: cfg.Recheck.Lease,
+		}, log)
+	}
+
+	// periodic tasks run once across all replicas
+	cronRepo := postgres.NewCron(postgresDB.Pool)
+	scheduler := cron.New(cronRepo, cronRepo, log)
+	if err := registerCronJobs(scheduler, cfg, feeds, rechecks); err != nil {
+		return nil, err
+	}
 
 	// Initialize HTTP server
-	server := httpserver.New(cfg, domainPipeline, domainRepo, log)
+	server := httpserver.New(cfg, domainPipeline, jobs, domainSvc, checkRepo, moderationSvc, statuses, scheduler, log)
 
 	return &App{
 		postgresDB: postgresDB,
 		httpServer: server,
+		jobs:       jobs,
+		cron:       scheduler,
 		cfg:        cfg,
 		log:        log,
 	}, nil
@@ -73,9 +116,26 @@ func (app *App) Run(ctx context.Context) error {
 
 	ctx = logger.WithAction(ctx, "app_run")
 
+	// background jobs stop when Run returns
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	defer stopJobs()
+
 	errCh := make(chan error, 1)
 	app.httpServer.Start(ctx, errCh)
 
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		app.jobs.Run(jobsCtx)
+	}()
+	go func() {
+		defer wg.Done()
+		app.cron.Run(jobsCtx)
+	}()
+	// runs before the shutdown above closes Postgres under the jobs
+	defer app.stopJobs(ctx, stopJobs, wg)
+
 	// Waiting signal
 	shutdownCh := make(chan os.Signal, 1)
 	signal.Notify(shutdownCh, syscall.SIGINT, syscall.SIGTERM)
@@ -94,6 +154,23 @@ func (app *App) Run(ctx context.Context) error {
 	return nil
 }
 
+// stopJobs cancels the background jobs and waits for them for at most
+// the shutdown timeout.
+func (app *App) stopJobs(ctx context.Context, stop context.CancelFunc, wg *sync.WaitGroup) {
+	stop()
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Duration(app.cfg.HTTPServer.ShutdownTimeoutSeconds) * time.Second):
+		app.log.Warn(ctx, "background jobs did not stop in time, unfinished jobs are picked up after the visibility timeout")
+	}
+}
+
 // Shutdown gracefully shuts down the application
 func (app *App) Shutdown(ctx context.Context) error {
 	t, err := time.ParseDuration(fmt.Sprintf("%ds", app.cfg.HTTPServer.ShutdownTimeoutSeconds))
@@ -118,3 +195,31 @@ func (app *App) Shutdown(ctx context.Context) error {
 
 	return nil
 }
+
+// registerCronJobs schedules every feed refresh on its interval and the
+// domain rechecks. Nil services have nothing to schedule.
+func registerCronJobs(scheduler *cron.Scheduler, cfg config.Config, feeds *feed.Service, rechecks *recheck.Service) error {
+	if feeds != nil {
+		for _, f := range feeds.Feeds() {
+			f := f
+			err := scheduler.Register("feed_refresh:"+f.Name, "@every "+f.Interval.String(), func(ctx context.Context) error {
+				return feeds.RefreshAndLog(ctx, f)
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if rechecks != nil {
+		err := scheduler.Register("domain_recheck", cfg.Recheck.Schedule, func(ctx context.Context) error {
+			_, err := rechecks.RunOnce(ctx)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("invalid RECHECK_SCHEDULE: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ItsXomyak/scam-list/config"
+	"github.com/ItsXomyak/scam-list/internal/adapter/postgres"
+	"github.com/ItsXomyak/scam-list/internal/adapter/redirect"
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/internal/modules/registry"
+	"github.com/ItsXomyak/scam-list/internal/services/feed"
+	"github.com/ItsXomyak/scam-list/internal/services/job"
+	"github.com/ItsXomyak/scam-list/internal/services/pipeline"
+	"github.com/ItsXomyak/scam-list/internal/services/status"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+	postgresclient "github.com/ItsXomyak/scam-list/pkg/postgres"
+	"github.com/ItsXomyak/scam-list/pkg/safehttp"
+)
+
+// Constructors shared by the API and the worker.
+
+func newPostgres(ctx context.Context, cfg config.Config) (*postgresclient.Postgres, error) {
+	return postgresclient.New(ctx, cfg.Postgres.GetDsn(), &postgresclient.Config{
+		MaxPoolSize:  cfg.Postgres.MaxPoolSize,
+		ConnAttempts: cfg.Postgres.ConnAttempts,
+		ConnTimeout:  cfg.Postgres.ConnTimeout,
+	})
+}
+
+// newPipeline builds the enabled checker modules and the pipeline running them.
+func newPipeline(ctx context.Context, cfg config.Config, domainSvc pipeline.DomainService, checks pipeline.CheckRecorder, log logger.Logger) (*pipeline.DomainPipeline, *status.Policy, error) {
+	// checkers
+	modules, err := registry.Default().Build(cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build checker modules: %w", err)
+	}
+	logModules(ctx, log, modules)
+
+	statuses, err := status.NewPolicy(cfg.Status.VerifiedMax, cfg.Status.SuspiciousMax)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid status config: %w", err)
+	}
+
+	var redirects pipeline.RedirectFollower
+	if !cfg.Redirects.Disabled {
+		client := safehttp.New(safehttp.Config{
+			Timeout:   cfg.Redirects.Timeout,
+			UserAgent: cfg.Outbound.UserAgent,
+			Allow:     cfg.Outbound.AllowCIDRs,
+		})
+		redirects = redirect.NewFollower(client, cfg.Redirects.Timeout, cfg.Redirects.MaxHops)
+	}
+
+	// core pipeline
+	scorer := pipeline.NewRiskScorer(registry.Weights(modules), cfg.Scoring.DefaultWeight)
+	domainPipeline := pipeline.NewDomainPipeline(registry.Checkers(modules), domainSvc, scorer, statuses, redirects, checks, pipeline.Config{
+		CheckerTimeout: cfg.Pipeline.CheckerTimeout,
+		MinSuccessful:  cfg.Pipeline.MinSuccessfulCheckers,
+		RecheckAfter:   cfg.Recheck.After,
+	}, log)
+
+	return domainPipeline, statuses, nil
+}
+
+// logModules lists enabled checker modules at startup
+func logModules(ctx context.Context, log logger.Logger, modules []*registry.ActiveModule) {
+	if len(modules) == 0 {
+		log.Warn(ctx, "no checker modules enabled, every fresh verdict will be insufficient_data")
+		return
+	}
+
+	for _, m := range modules {
+		log.Info(ctx, "checker module enabled",
+			"module", m.Name,
+			"version", m.Version,
+			"weight", m.Weight,
+			"timeout", m.Timeout.String(),
+		)
+	}
+}
+
+// loadFeeds reads FEEDS_FILE, if set, and loads the stored entries of its
+// feeds into the index the blocklist checker answers from.
+func loadFeeds(ctx context.Context, cfg config.Config, repo *postgres.FeedRepository, log logger.Logger) ([]*entity.Feed, error) {
+	var list []*entity.Feed
+	if cfg.Feeds.File != "" {
+		var err error
+		if list, err = feed.LoadFile(cfg.Feeds.File); err != nil {
+			return nil, err
+		}
+		log.Info(ctx, "blocklist feeds loaded", "count", len(list), "file", cfg.Feeds.File)
+	}
+
+	if err := feed.DefaultIndex().Load(ctx, repo, list); err != nil {
+		log.Warn(ctx, "failed to load feed index, blocklist checks fail until it is reloaded", "error", err.Error())
+	} else {
+		log.Info(ctx, "feed index loaded", "entries", feed.DefaultIndex().Size())
+	}
+
+	return list, nil
+}
+
+func newJobs(cfg config.Config, pool postgresclient.PgxPool, verifier job.Verifier, log logger.Logger) *job.Service {
+	return job.NewService(postgres.NewJob(pool), verifier, job.Config{
+		Workers:           cfg.Jobs.Workers,
+		PollInterval:      cfg.Jobs.PollInterval,
+		Timeout:           cfg.Jobs.Timeout,
+		MaxAttempts:       cfg.Jobs.MaxAttempts,
+		RetryBackoff:      cfg.Jobs.RetryBackoff,
+		MaxBackoff:        cfg.Jobs.MaxBackoff,
+		VisibilityTimeout: cfg.Jobs.VisibilityTimeout,
+	}, log)
+}
@@ -0,0 +1,67 @@
+package cron
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScheduleNext(t *testing.T) {
+	// a Saturday
+	from := time.Date(2025, 3, 1, 10, 17, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{expr: "* * * * *", want: time.Date(2025, 3, 1, 10, 18, 0, 0, time.UTC)},
+		{expr: "*/15 * * * *", want: time.Date(2025, 3, 1, 10, 30, 0, 0, time.UTC)},
+		{expr: "0 3 * * *", want: time.Date(2025, 3, 2, 3, 0, 0, 0, time.UTC)},
+		{expr: "30 9-17/4 * * *", want: time.Date(2025, 3, 1, 13, 30, 0, 0, time.UTC)},
+		{expr: "0 0 * * mon-fri", want: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 * * 7", want: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 0 1,15 * *", want: time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)},
+		// both day fields restricted: either one matches
+		{expr: "0 0 15 * sun", want: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC)},
+		{expr: "0 12 29 feb *", want: time.Date(2028, 2, 29, 12, 0, 0, 0, time.UTC)},
+		{expr: "@hourly", want: time.Date(2025, 3, 1, 11, 0, 0, 0, time.UTC)},
+		{expr: "@monthly", want: time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC)},
+		{expr: "@every 6h", want: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)},
+		{expr: "@every 10m", want: time.Date(2025, 3, 1, 10, 20, 0, 0, time.UTC)},
+		{expr: "0 0 30 2 *", want: time.Time{}},
+	}
+
+	for _, tc := range tests {
+		s, err := Parse(tc.expr)
+		if err != nil {
+			t.Errorf("Parse(%q) unexpected error: %v", tc.expr, err)
+			continue
+		}
+		if got := s.Next(from); !got.Equal(tc.want) {
+			t.Errorf("Parse(%q).Next() = %v; want %v", tc.expr, got, tc.want)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@every 10",
+		"@every 500ms",
+		"@sometimes",
+	} {
+		if _, err := Parse(expr); !errors.Is(err, ErrInvalidSchedule) {
+			t.Errorf("Parse(%q) error = %v; want ErrInvalidSchedule", expr, err)
+		}
+	}
+}
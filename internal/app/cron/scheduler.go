@@ -0,0 +1,305 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+)
+
+// Locker elects the replica that runs a job.
+type Locker interface {
+	// TryLock takes the lock of the named job without waiting. ok is false
+	// when another replica holds it. unlock must be called once the job is done.
+	TryLock(ctx context.Context, name string) (unlock func(), ok bool, err error)
+}
+
+// Store keeps the last run of every job.
+type Store interface {
+	// StartRun records a started run. It reports false, and records nothing,
+	// when run.ScheduledAt is not after the slot of the last scheduled run:
+	// another replica already ran that slot.
+	StartRun(ctx context.Context, run *entity.CronRun) (bool, error)
+	FinishRun(ctx context.Context, run *entity.CronRun) error
+	GetRuns(ctx context.Context) ([]*entity.CronRun, error)
+}
+
+// Func is the body of a job. Its error is recorded as the run result.
+type Func func(ctx context.Context) error
+
+type job struct {
+	name     string
+	schedule *Schedule
+	fn       Func
+	running  atomic.Bool
+}
+
+// Scheduler runs named jobs on cron schedules. Every replica runs the same
+// scheduler, a job runs on the replica that takes its lock and each slot
+// runs once. Schedules are evaluated in UTC.
+type Scheduler struct {
+	locker   Locker
+	store    Store
+	instance string
+	log      logger.Logger
+	now      func() time.Time
+
+	mu   sync.Mutex
+	jobs []*job
+	ctx  context.Context // set by Run, manual runs stop with it
+}
+
+func New(locker Locker, store Store, log logger.Logger) *Scheduler {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+
+	return &Scheduler{
+		locker:   locker,
+		store:    store,
+		instance: fmt.Sprintf("%s/%d", host, os.Getpid()),
+		log:      log,
+		now:      func() time.Time { return time.Now().UTC() },
+		ctx:      context.Background(),
+	}
+}
+
+// Register adds a job. Names must be unique, jobs registered after Run
+// starts are only run manually.
+func (s *Scheduler) Register(name, expr string, fn Func) error {
+	schedule, err := Parse(expr)
+	if err != nil {
+		return fmt.Errorf("cron job %s: %w", name, err)
+	}
+	if schedule.Next(s.now()).IsZero() {
+		return fmt.Errorf("cron job %s: %w: %q never runs", name, ErrInvalidSchedule, expr)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, j := range s.jobs {
+		if j.name == name {
+			return fmt.Errorf("cron job %s is already registered", name)
+		}
+	}
+	s.jobs = append(s.jobs, &job{name: name, schedule: schedule, fn: fn})
+
+	return nil
+}
+
+// Run schedules every registered job until ctx is done. A slot missed
+// while no replica was running is run once right away.
+func (s *Scheduler) Run(ctx context.Context) {
+	ctx = logger.WithAction(ctx, "cron")
+
+	s.mu.Lock()
+	s.ctx = ctx
+	jobs := append([]*job(nil), s.jobs...)
+	s.mu.Unlock()
+
+	last := make(map[string]*entity.CronRun)
+	runs, err := s.store.GetRuns(ctx)
+	if err != nil {
+		// without the history every job catches up, the store still
+		// keeps a slot from running twice
+		s.log.Error(logger.ErrorCtx(ctx, err), "failed to load cron runs", err)
+	}
+	for _, r := range runs {
+		last[r.Name] = r
+	}
+
+	wg := &sync.WaitGroup{}
+	for _, j := range jobs {
+		wg.Add(1)
+		go func(j *job) {
+			defer wg.Done()
+			s.loop(ctx, j, last[j.name])
+		}(j)
+	}
+	wg.Wait()
+}
+
+func (s *Scheduler) loop(ctx context.Context, j *job, last *entity.CronRun) {
+	if slot, ok := s.missed(j, last); ok {
+		s.runScheduled(ctx, j, slot)
+	}
+
+	for {
+		next := j.schedule.Next(s.now())
+		timer := time.NewTimer(next.Sub(s.now()))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.runScheduled(ctx, j, next)
+	}
+}
+
+// missed returns the slot to catch up when the slot following the last
+// scheduled run has already passed. A job that never ran on schedule is
+// run at startup, in the slot of the current minute so that replicas
+// starting together agree on it.
+func (s *Scheduler) missed(j *job, last *entity.CronRun) (time.Time, bool) {
+	now := s.now()
+	if last == nil || last.ScheduledAt == nil {
+		return now.Truncate(time.Minute), true
+	}
+
+	next := j.schedule.Next(*last.ScheduledAt)
+	if next.IsZero() || next.After(now) {
+		return time.Time{}, false
+	}
+	return next, true
+}
+
+func (s *Scheduler) runScheduled(ctx context.Context, j *job, slot time.Time) {
+	unlock, err := s.acquire(ctx, j)
+	switch {
+	case errors.Is(err, entity.ErrCronJobRunning):
+		s.log.Debug(ctx, "cron job is running elsewhere, slot skipped", "job", j.name, "slot", slot.Format(time.RFC3339))
+		return
+	case err != nil:
+		if ctx.Err() == nil {
+			s.log.Error(logger.ErrorCtx(ctx, err), "failed to lock cron job", err, "job", j.name)
+		}
+		return
+	}
+	defer unlock()
+
+	s.execute(ctx, j, entity.CronTriggerSchedule, &slot)
+}
+
+// Trigger starts a job out of schedule and returns once it has started.
+// It returns entity.ErrCronJobNotFound for an unknown name and
+// entity.ErrCronJobRunning when the job runs on any replica.
+func (s *Scheduler) Trigger(ctx context.Context, name string) error {
+	s.mu.Lock()
+	var j *job
+	for _, cur := range s.jobs {
+		if cur.name == name {
+			j = cur
+		}
+	}
+	runCtx := s.ctx
+	s.mu.Unlock()
+
+	if j == nil {
+		return entity.ErrCronJobNotFound
+	}
+
+	unlock, err := s.acquire(ctx, j)
+	if err != nil {
+		return err
+	}
+
+	s.log.Info(ctx, "cron job triggered manually", "job", j.name)
+	go func() {
+		defer unlock()
+		s.execute(logger.WithAction(runCtx, "cron"), j, entity.CronTriggerManual, nil)
+	}()
+
+	return nil
+}
+
+// acquire marks the job as running here and takes its lock.
+func (s *Scheduler) acquire(ctx context.Context, j *job) (func(), error) {
+	if !j.running.CompareAndSwap(false, true) {
+		return nil, entity.ErrCronJobRunning
+	}
+
+	unlock, ok, err := s.locker.TryLock(ctx, j.name)
+	if err != nil || !ok {
+		j.running.Store(false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to lock cron job %s: %w", j.name, err)
+		}
+		return nil, entity.ErrCronJobRunning
+	}
+
+	return func() {
+		unlock()
+		j.running.Store(false)
+	}, nil
+}
+
+// execute runs a locked job and records the run.
+func (s *Scheduler) execute(ctx context.Context, j *job, trigger string, slot *time.Time) {
+	// the run is recorded even if the scheduler is stopping
+	saveCtx := context.WithoutCancel(ctx)
+
+	run := &entity.CronRun{
+		Name:        j.name,
+		Trigger:     trigger,
+		Status:      entity.CronStatusRunning,
+		ScheduledAt: slot,
+		StartedAt:   s.now(),
+		Instance:    s.instance,
+	}
+	started, err := s.store.StartRun(saveCtx, run)
+	if err != nil {
+		s.log.Error(logger.ErrorCtx(ctx, err), "failed to record cron run", err, "job", j.name)
+		return
+	}
+	if !started {
+		s.log.Debug(ctx, "cron slot already ran", "job", j.name, "slot", slot.Format(time.RFC3339))
+		return
+	}
+
+	runErr := j.fn(ctx)
+
+	finished := s.now()
+	run.FinishedAt = &finished
+	run.Status = entity.CronStatusSucceeded
+	if runErr != nil {
+		run.Status = entity.CronStatusFailed
+		run.Error = runErr.Error()
+		s.log.Error(logger.ErrorCtx(ctx, runErr), "cron job failed", runErr, "job", j.name, "trigger", trigger)
+	} else {
+		s.log.Info(ctx, "cron job finished", "job", j.name, "trigger", trigger, "duration", finished.Sub(run.StartedAt).String())
+	}
+
+	if err := s.store.FinishRun(saveCtx, run); err != nil {
+		s.log.Error(logger.ErrorCtx(ctx, err), "failed to record cron run result", err, "job", j.name)
+	}
+}
+
+// Jobs lists the registered jobs with their last runs.
+func (s *Scheduler) Jobs(ctx context.Context) ([]*entity.CronJob, error) {
+	runs, err := s.store.GetRuns(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cron runs: %w", err)
+	}
+	last := make(map[string]*entity.CronRun, len(runs))
+	for _, r := range runs {
+		last[r.Name] = r
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	out := make([]*entity.CronJob, 0, len(s.jobs))
+	for _, j := range s.jobs {
+		out = append(out, &entity.CronJob{
+			Name:     j.name,
+			Schedule: j.schedule.String(),
+			NextRun:  j.schedule.Next(now),
+			Running:  j.running.Load(),
+			LastRun:  last[j.name],
+		})
+	}
+
+	return out, nil
+}
@@ -0,0 +1,272 @@
+package cron
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+)
+
+var testLogger = logger.InitLogger("cron-test", logger.LevelError)
+
+// memLocker is a lock table shared by the schedulers of several replicas.
+type memLocker struct {
+	mu   sync.Mutex
+	held map[string]bool
+	err  error
+}
+
+func (l *memLocker) TryLock(_ context.Context, name string) (func(), bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.err != nil {
+		return nil, false, l.err
+	}
+	if l.held == nil {
+		l.held = make(map[string]bool)
+	}
+	if l.held[name] {
+		return nil, false, nil
+	}
+	l.held[name] = true
+
+	return func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		delete(l.held, name)
+	}, true, nil
+}
+
+// memStore has the semantics of CronRepository.
+type memStore struct {
+	mu   sync.Mutex
+	runs map[string]*entity.CronRun
+}
+
+func newMemStore() *memStore {
+	return &memStore{runs: make(map[string]*entity.CronRun)}
+}
+
+func (s *memStore) StartRun(_ context.Context, run *entity.CronRun) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cur := s.runs[run.Name]
+	if cur != nil && run.ScheduledAt != nil && cur.ScheduledAt != nil && !cur.ScheduledAt.Before(*run.ScheduledAt) {
+		return false, nil
+	}
+
+	saved := *run
+	if saved.ScheduledAt == nil && cur != nil {
+		saved.ScheduledAt = cur.ScheduledAt
+	}
+	s.runs[run.Name] = &saved
+	return true, nil
+}
+
+func (s *memStore) FinishRun(_ context.Context, run *entity.CronRun) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cur := s.runs[run.Name]
+	cur.Status = run.Status
+	cur.FinishedAt = run.FinishedAt
+	cur.Error = run.Error
+	return nil
+}
+
+func (s *memStore) GetRuns(context.Context) ([]*entity.CronRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]*entity.CronRun, 0, len(s.runs))
+	for _, r := range s.runs {
+		cp := *r
+		out = append(out, &cp)
+	}
+	return out, nil
+}
+
+func (s *memStore) get(name string) *entity.CronRun {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.runs[name]
+}
+
+func newTestScheduler(locker Locker, store Store, now time.Time) *Scheduler {
+	s := New(locker, store, testLogger)
+	s.now = func() time.Time { return now }
+	return s
+}
+
+func TestRunScheduledOncePerSlot(t *testing.T) {
+	locker := &memLocker{}
+	store := newMemStore()
+	now := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+
+	// two replicas fire the same slot one after another
+	calls := 0
+	for i := 0; i < 2; i++ {
+		s := newTestScheduler(locker, store, now)
+		if err := s.Register("report", "0 * * * *", func(context.Context) error {
+			calls++
+			return nil
+		}); err != nil {
+			t.Fatalf("Register() unexpected error: %v", err)
+		}
+		s.runScheduled(ctx, s.jobs[0], now)
+	}
+
+	if calls != 1 {
+		t.Errorf("job ran %d times in one slot; want 1", calls)
+	}
+	run := store.get("report")
+	if run.Status != entity.CronStatusSucceeded || run.Trigger != entity.CronTriggerSchedule || run.FinishedAt == nil {
+		t.Errorf("recorded run = %+v", run)
+	}
+}
+
+func TestRunScheduledRecordsFailure(t *testing.T) {
+	store := newMemStore()
+	s := newTestScheduler(&memLocker{}, store, time.Now().UTC())
+	s.Register("feed_refresh:openphish", "@every 6h", func(context.Context) error {
+		return errors.New("feed has no valid entries")
+	})
+
+	s.runScheduled(context.Background(), s.jobs[0], s.now())
+
+	run := store.get("feed_refresh:openphish")
+	if run.Status != entity.CronStatusFailed || run.Error != "feed has no valid entries" {
+		t.Errorf("recorded run = %+v; want the failure", run)
+	}
+}
+
+func TestRunScheduledLockedElsewhere(t *testing.T) {
+	locker := &memLocker{held: map[string]bool{"report": true}}
+	store := newMemStore()
+	s := newTestScheduler(locker, store, time.Now().UTC())
+	s.Register("report", "@hourly", func(context.Context) error {
+		t.Error("job ran without the lock")
+		return nil
+	})
+
+	s.runScheduled(context.Background(), s.jobs[0], s.now())
+
+	if store.get("report") != nil {
+		t.Error("run recorded without the lock")
+	}
+	if s.jobs[0].running.Load() {
+		t.Error("job still marked as running")
+	}
+}
+
+func TestMissed(t *testing.T) {
+	now := time.Date(2025, 3, 1, 10, 17, 30, 0, time.UTC)
+	at := func(h, m int) *time.Time {
+		t := time.Date(2025, 3, 1, h, m, 0, 0, time.UTC)
+		return &t
+	}
+
+	tests := []struct {
+		name     string
+		expr     string
+		last     *entity.CronRun
+		wantSlot time.Time
+		wantOK   bool
+	}{
+		{name: "never ran", expr: "0 3 * * *", wantSlot: *at(10, 17), wantOK: true},
+		{name: "only manual runs", expr: "0 3 * * *", last: &entity.CronRun{}, wantSlot: *at(10, 17), wantOK: true},
+		{name: "slot missed", expr: "@every 6h", last: &entity.CronRun{ScheduledAt: at(0, 0)}, wantSlot: *at(6, 0), wantOK: true},
+		{name: "up to date", expr: "@every 6h", last: &entity.CronRun{ScheduledAt: at(6, 0)}},
+		{name: "daily done", expr: "0 3 * * *", last: &entity.CronRun{ScheduledAt: at(3, 0)}},
+	}
+
+	for _, tc := range tests {
+		s := newTestScheduler(&memLocker{}, newMemStore(), now)
+		s.Register("job", tc.expr, func(context.Context) error { return nil })
+
+		slot, ok := s.missed(s.jobs[0], tc.last)
+		if ok != tc.wantOK || !slot.Equal(tc.wantSlot) {
+			t.Errorf("%s: missed() = %v, %v; want %v, %v", tc.name, slot, ok, tc.wantSlot, tc.wantOK)
+		}
+	}
+}
+
+func TestTrigger(t *testing.T) {
+	store := newMemStore()
+	s := newTestScheduler(&memLocker{}, store, time.Now().UTC())
+
+	release := make(chan struct{})
+	done := make(chan struct{})
+	s.Register("report", "@daily", func(context.Context) error {
+		<-release
+		close(done)
+		return nil
+	})
+	ctx := context.Background()
+
+	if err := s.Trigger(ctx, "unknown"); !errors.Is(err, entity.ErrCronJobNotFound) {
+		t.Errorf("Trigger(unknown) error = %v; want ErrCronJobNotFound", err)
+	}
+	if err := s.Trigger(ctx, "report"); err != nil {
+		t.Fatalf("Trigger() unexpected error: %v", err)
+	}
+	if err := s.Trigger(ctx, "report"); !errors.Is(err, entity.ErrCronJobRunning) {
+		t.Errorf("second Trigger() error = %v; want ErrCronJobRunning", err)
+	}
+
+	jobs, err := s.Jobs(ctx)
+	if err != nil {
+		t.Fatalf("Jobs() unexpected error: %v", err)
+	}
+	if len(jobs) != 1 || !jobs[0].Running || jobs[0].NextRun.IsZero() {
+		t.Errorf("Jobs() = %+v; want the running job", jobs[0])
+	}
+
+	close(release)
+	<-done
+	for deadline := time.Now().Add(time.Second); s.jobs[0].running.Load(); {
+		if time.Now().After(deadline) {
+			t.Fatal("manual run did not finish")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	run := store.get("report")
+	if run.Trigger != entity.CronTriggerManual || run.Status != entity.CronStatusSucceeded || run.ScheduledAt != nil {
+		t.Errorf("recorded run = %+v", run)
+	}
+}
+
+func TestTriggerLockError(t *testing.T) {
+	s := newTestScheduler(&memLocker{err: errors.New("connection refused")}, newMemStore(), time.Now().UTC())
+	s.Register("report", "@daily", func(context.Context) error { return nil })
+
+	err := s.Trigger(context.Background(), "report")
+	if err == nil || errors.Is(err, entity.ErrCronJobRunning) {
+		t.Errorf("Trigger() error = %v; want the lock error", err)
+	}
+	if s.jobs[0].running.Load() {
+		t.Error("job still marked as running")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	s := New(&memLocker{}, newMemStore(), testLogger)
+
+	if err := s.Register("report", "@daily", func(context.Context) error { return nil }); err != nil {
+		t.Fatalf("Register() unexpected error: %v", err)
+	}
+	if err := s.Register("report", "@hourly", func(context.Context) error { return nil }); err == nil {
+		t.Error("Register() of a duplicate name expected error, got none")
+	}
+	if err := s.Register("never", "0 0 31 2 *", func(context.Context) error { return nil }); !errors.Is(err, ErrInvalidSchedule) {
+		t.Errorf("Register() of an impossible schedule error = %v; want ErrInvalidSchedule", err)
+	}
+}
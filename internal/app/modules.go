@@ -0,0 +1,13 @@
+package app
+
+// Checker modules register themselves in registry.Default() on import.
+import (
+	_ "github.com/ItsXomyak/scam-list/internal/modules/blocklist"
+	_ "github.com/ItsXomyak/scam-list/internal/modules/content"
+	_ "github.com/ItsXomyak/scam-list/internal/modules/dns"
+	_ "github.com/ItsXomyak/scam-list/internal/modules/domainage"
+	_ "github.com/ItsXomyak/scam-list/internal/modules/homoglyph"
+	_ "github.com/ItsXomyak/scam-list/internal/modules/lexical"
+	_ "github.com/ItsXomyak/scam-list/internal/modules/scamdetector"
+	_ "github.com/ItsXomyak/scam-list/internal/modules/tlscert"
+)
@@ -0,0 +1,115 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/config"
+	"github.com/ItsXomyak/scam-list/internal/adapter/postgres"
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/internal/services/domain"
+	"github.com/ItsXomyak/scam-list/internal/services/feed"
+	"github.com/ItsXomyak/scam-list/internal/services/job"
+	"github.com/ItsXomyak/scam-list/internal/services/moderation"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+	postgresclient "github.com/ItsXomyak/scam-list/pkg/postgres"
+)
+
+// Worker runs verification jobs from the queue without serving HTTP,
+// so checking scales separately from the API.
+type Worker struct {
+	postgresDB *postgresclient.Postgres
+	jobs       *job.Service
+	feedRepo   *postgres.FeedRepository
+	feedList   []*entity.Feed
+
+	cfg config.Config
+	log logger.Logger
+}
+
+// NewWorker creates a worker with the same pipeline as the API.
+func NewWorker(ctx context.Context, cfg config.Config, log logger.Logger) (*Worker, error) {
+	if cfg.Jobs.Workers <= 0 {
+		return nil, fmt.Errorf("JOBS_WORKERS must be positive for the worker, got %d", cfg.Jobs.Workers)
+	}
+
+	postgresDB, err := newPostgres(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	// verdicts saved by the worker queue suspicious domains like the API does
+	moderationSvc := moderation.NewService(postgres.NewModeration(postgresDB.Pool), log)
+	domainSvc := domain.NewDomainService(postgres.NewDomain(postgresDB.Pool), moderationSvc)
+	domainPipeline, _, err := newPipeline(ctx, cfg, domainSvc, postgres.NewCheck(postgresDB.Pool), log)
+	if err != nil {
+		postgresDB.Close()
+		return nil, err
+	}
+
+	// feeds are imported by the API, the worker only reads them
+	feedRepo := postgres.NewFeed(postgresDB.Pool)
+	feedList, err := loadFeeds(ctx, cfg, feedRepo, log)
+	if err != nil {
+		postgresDB.Close()
+		return nil, err
+	}
+
+	return &Worker{
+		postgresDB: postgresDB,
+		jobs:       newJobs(cfg, postgresDB.Pool, domainPipeline, log),
+		feedRepo:   feedRepo,
+		feedList:   feedList,
+		cfg:        cfg,
+		log:        log,
+	}, nil
+}
+
+// Run processes jobs until SIGINT or SIGTERM. Jobs interrupted by the
+// signal go back to the queue.
+func (w *Worker) Run(ctx context.Context) error {
+	ctx = logger.WithAction(ctx, "worker_run")
+	defer w.postgresDB.Close()
+
+	runCtx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	wg := &sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		w.jobs.Run(runCtx)
+	}()
+	go func() {
+		defer wg.Done()
+		feed.DefaultIndex().Reload(runCtx, w.feedRepo, w.feedList, w.cfg.Feeds.IndexReload, w.log)
+	}()
+
+	shutdownCh := make(chan os.Signal, 1)
+	signal.Notify(shutdownCh, syscall.SIGINT, syscall.SIGTERM)
+
+	w.log.Info(ctx, "worker started", "workers", w.cfg.Jobs.Workers)
+	sig := <-shutdownCh
+	w.log.Info(ctx, "shutting down worker", "signal", sig.String())
+
+	stop()
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		w.log.Info(ctx, "graceful shutdown completed")
+	case <-time.After(time.Duration(w.cfg.HTTPServer.ShutdownTimeoutSeconds) * time.Second):
+		w.log.Warn(ctx, "workers did not stop in time, unfinished jobs are picked up after the visibility timeout")
+	}
+
+	return nil
+}
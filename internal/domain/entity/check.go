@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// Check triggers tell who asked for a pipeline run.
+const (
+	CheckTriggerUser    = "user"    // GET /api/verify or POST /api/verify
+	CheckTriggerRecheck = "recheck" // background recheck of an expired verdict
+	CheckTriggerAdmin   = "admin"   // POST /admin/domain/:domain/checks
+)
+
+// DomainCheck is the record of a single pipeline run, kept to see how the
+// risk of a domain changed over time and to debug a verdict.
+type DomainCheck struct {
+	ID            string
+	Domain        string
+	Trigger       string
+	Status        string   // may be insufficient_data, unlike domains.status
+	RiskScore     *float64 // nil for insufficient_data
+	Reasons       []string
+	ModuleResults []*ModuleResult // every checker, failed ones with their error
+	StartedAt     time.Time
+	FinishedAt    time.Time
+}
@@ -2,6 +2,19 @@ package entity
 
 import "time"
 
+// Verdict sources tell whether VerifyDomainResult was read from the list or freshly computed.
+const (
+	VerdictSourceCache    = "cache"
+	VerdictSourceAnalysis = "analysis"
+)
+
+// VerifyOptions controls a single verification run.
+type VerifyOptions struct {
+	SkipCache       bool   // run the checkers even if the domain is already in the list
+	OverwriteManual bool   // allow replacing a manual moderator verdict
+	Trigger         string // recorded with the check, empty means CheckTriggerUser
+}
+
 // VerifyDomainResult represents the result of verifying a domain that we returns the user.
 type VerifyDomainResult struct {
 	Domain        string          `json:"domain"`
@@ -12,12 +25,36 @@ type VerifyDomainResult struct {
 	Country       string          `json:"country"`
 	VerifiedBy    string          `json:"verified_by"`
 	VerifiedAt    time.Time       `json:"verified_at"`
+	Reasons       []string        `json:"reasons"`
+	ScamSources   []string        `json:"scam_sources"`
+	VerdictSource string          `json:"verdict_source"`
 	ModuleResults []*ModuleResult `json:"module_results"`
+
+	// set when the submitted URL redirects, Domain is then the landing domain
+	SubmittedDomain string         `json:"submitted_domain,omitempty"`
+	RedirectPath    string         `json:"redirect_path,omitempty"`
+	RedirectChain   *RedirectChain `json:"redirect_chain,omitempty"`
+
+	// status stored before this run when the run changed it
+	PreviousStatus string `json:"previous_status,omitempty"`
+	// domain_checks record of this run, empty for a stored verdict
+	CheckID string `json:"check_id,omitempty"`
 }
 
+// Module statuses of a single checker run.
+const (
+	ModuleStatusOK      = "ok"
+	ModuleStatusTimeout = "timeout"
+	ModuleStatusError   = "error"
+)
+
 type ModuleResult struct {
 	ModuleName  string         `json:"module_name"`
 	Description string         `json:"description"`
+	Status      string         `json:"status,omitempty"`
+	Error       string         `json:"error,omitempty"`
+	DurationMs  int64          `json:"duration_ms,omitempty"`
 	RiskScore   float64        `json:"risk_score"`
+	Reasons     []string       `json:"reasons,omitempty"`
 	Metadata    map[string]any `json:"metadata"`
 }
@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+// Cron run triggers.
+const (
+	CronTriggerSchedule = "schedule"
+	CronTriggerManual   = "manual" // POST /admin/cron/:name/run
+)
+
+// Cron run statuses.
+const (
+	CronStatusRunning   = "running"
+	CronStatusSucceeded = "succeeded"
+	CronStatusFailed    = "failed"
+)
+
+// CronRun is the last run of a scheduled job, one per job name.
+type CronRun struct {
+	Name        string
+	Trigger     string
+	Status      string
+	ScheduledAt *time.Time // slot of the last scheduled run, manual runs keep it
+	StartedAt   time.Time
+	FinishedAt  *time.Time
+	Error       string
+	Instance    string // host and pid of the replica that ran it
+}
+
+// CronJob describes a registered job and its last run.
+type CronJob struct {
+	Name     string
+	Schedule string
+	NextRun  time.Time
+	Running  bool     // running on this replica
+	LastRun  *CronRun // nil if the job never ran
+}
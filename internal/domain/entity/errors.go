@@ -0,0 +1,19 @@
+package entity
+
+import "errors"
+
+var (
+	ErrDomainNotFound       = errors.New("domain not found")
+	ErrManualVerdictLocked  = errors.New("domain has a manual verdict")
+	ErrStatusMismatch       = errors.New("status does not match risk_score")
+	ErrStatusRequired       = errors.New("status or risk_score must be provided")
+	ErrNotRegistered        = errors.New("domain is not registered")
+	ErrJobNotFound          = errors.New("job not found")
+	ErrJobLeaseLost         = errors.New("job was claimed again by another worker")
+	ErrCronJobNotFound      = errors.New("cron job not found")
+	ErrCronJobRunning       = errors.New("cron job is already running")
+	ErrModerationNotFound   = errors.New("moderation task not found")
+	ErrModerationClaimed    = errors.New("moderation task is claimed by another moderator")
+	ErrModerationNotClaimed = errors.New("moderation task is not claimed by this moderator")
+	ErrModerationResolved   = errors.New("moderation task is already resolved")
+)
@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+// Feed formats.
+const (
+	FeedFormatHosts     = "hosts"     // "0.0.0.0 example.com"
+	FeedFormatAdblock   = "adblock"   // "||example.com^"
+	FeedFormatPlain     = "plain"     // one domain or URL per line
+	FeedFormatCSV       = "csv"       // header row with a domain, host or url column
+	FeedFormatPhishTank = "phishtank" // online-valid.csv
+	FeedFormatOpenPhish = "openphish" // feed.txt, one URL per line
+	FeedFormatURLhaus   = "urlhaus"   // csv.txt dump with # comments
+)
+
+// Removal policies for entries that disappear from a feed.
+const (
+	FeedRemovalRemove = "remove" // drop the feed from scam_sources after RemoveAfter
+	FeedRemovalKeep   = "keep"   // keep the domain listed forever
+)
+
+// Feed is a third-party blocklist imported into domains.
+type Feed struct {
+	Name        string // stored in scam_sources
+	Format      string
+	Source      string // http(s) URL or local file path
+	Interval    time.Duration
+	ScamType    string  // used when the format has no type of its own
+	RiskScore   float64 // risk_score of the imported domains
+	Removal     string
+	RemoveAfter time.Duration // grace period before a missing entry is removed
+}
+
+// FeedEntry is a single domain listed in a feed.
+type FeedEntry struct {
+	Domain   string
+	URL      string // listed URL when the feed has one
+	ScamType string
+}
+
+// FeedImportStats reports a single feed refresh.
+type FeedImportStats struct {
+	Feed     string
+	Parsed   int // unique domains in the feed
+	Skipped  int // lines that are not a valid domain
+	Added    int // domains new to the feed
+	Listed   int // domains rows created or flagged by this import
+	Removed  int // entries dropped by the removal policy
+	Duration time.Duration
+}
@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+// Verification job statuses.
+const (
+	JobStatusQueued  = "queued"
+	JobStatusRunning = "running"
+	JobStatusDone    = "done"
+	JobStatusDead    = "dead" // every attempt failed
+)
+
+// Job priorities, a lower number is claimed first.
+const (
+	JobPriorityUser    = 1 // submitted through the API, someone waits for it
+	JobPriorityRecheck = 5 // background rechecks of known domains
+)
+
+// VerifyJob is an asynchronous verification of a domain or URL.
+type VerifyJob struct {
+	ID          string
+	Target      string // domain or URL as submitted
+	Status      string
+	Trigger     string // entity.CheckTrigger*
+	Priority    int
+	SkipCache   bool                // run the checkers even for a known domain
+	Result      *VerifyDomainResult // set when the job is done
+	Error       string              // error of the last failed attempt
+	Attempts    int
+	MaxAttempts int
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+	FinishedAt  *time.Time
+	VisibleAt   time.Time // queued: not claimed before, running: abandoned after
+}
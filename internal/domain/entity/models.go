@@ -5,6 +5,22 @@ import (
 	"time"
 )
 
+// Domain statuses. StatusInsufficientData is only returned to the user
+// and never stored, the domains table accepts the other three.
+const (
+	StatusVerified         = "verified"
+	StatusSuspicious       = "suspicious"
+	StatusScam             = "scam"
+	StatusInsufficientData = "insufficient_data"
+)
+
+// Verification methods stored in domains.verification_method.
+const (
+	VerificationManual    = "manual"
+	VerificationAutomatic = "automatic"
+	VerificationFeed      = "feed" // imported from a third-party blocklist
+)
+
 type Domain struct {
 	Domain             string
 	Status             string
@@ -19,8 +35,22 @@ type Domain struct {
 	Metadata           []json.RawMessage
 	CreatedAt          *time.Time
 	UpdatedAt          *time.Time
+	LastCheckAt        *time.Time // last automatic verification
+	ExpiresAt          *time.Time // the verdict is rechecked after it, nil never expires
 }
 
+// CheckerResult is the outcome of a single ScamChecker run.
 type CheckerResult struct {
-	TotalScore float64
+	Module     string  // name of the checker that produced the result
+	TotalScore float64 // risk from 0 (trusted) to 100 (scam)
+	Confidence float64 // how much the checker trusts its own score, from 0 to 1
+
+	Reasons     []string       // human-readable reasons behind the score
+	Evidence    map[string]any // structured data the score is based on, stored as module metadata
+	ScamType    string         // suggested scam type (phishing, fraud, ...), empty if unknown
+	ScamSources []string       // external lists or engines that flagged the domain
+
+	// attributes discovered about the domain owner, empty if unknown
+	CompanyName string
+	Country     string // ISO 3166-1 alpha-2
 }
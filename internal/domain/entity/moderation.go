@@ -0,0 +1,46 @@
+package entity
+
+import "time"
+
+// Moderation task statuses stored in pending_moderation.status.
+const (
+	ModerationStatusPending    = "pending"
+	ModerationStatusInProgress = "in_progress" // claimed by a moderator
+	ModerationStatusResolved   = "resolved"
+)
+
+// ModerationResolvedBySystem resolves a task whose domain stopped being
+// suspicious without a moderator, e.g. after a recheck.
+const ModerationResolvedBySystem = "system"
+
+// Moderation priorities, a smaller number is reviewed first.
+const (
+	ModerationPriorityHighest = 1
+	ModerationPriorityDefault = 5
+	ModerationPriorityLowest  = 10
+)
+
+// ModerationTask is a suspicious domain waiting for a manual verdict.
+// A domain has at most one task, a resolved task is reopened when the
+// domain becomes suspicious again.
+type ModerationTask struct {
+	Domain        string
+	CheckID       *string // domain_checks run that made the domain suspicious, nil when set by an admin
+	Reasons       []string
+	SourceModules []string // modules that scored the domain as risky
+	Priority      int
+	Status        string
+	AssignedTo    *string
+	Resolution    *string // verified or scam, the status a resolved task gave the domain
+	ResolvedBy    *string
+	Notes         *string
+	SubmittedAt   time.Time
+	ClaimedAt     *time.Time
+	ResolvedAt    *time.Time
+	UpdatedAt     time.Time
+
+	// current data of the domain
+	RiskScore   *float64
+	CompanyName *string
+	Country     *string
+}
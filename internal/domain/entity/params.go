@@ -1,6 +1,9 @@
 package entity
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"time"
+)
 
 type CreateDomainParams struct {
 	Domain             string
@@ -16,6 +19,59 @@ type CreateDomainParams struct {
 	Metadata           []json.RawMessage
 }
 
+// UpsertDomainParams creates a domain or replaces the stored verdict.
+// Manual moderator verdicts are kept unless OverwriteManual is set.
+type UpsertDomainParams struct {
+	CreateDomainParams
+	OverwriteManual bool
+	ExpiresAt       *time.Time // nil keeps the verdict until it is replaced
+	CheckID         string     // domain_checks run of the verdict, not stored in domains
+}
+
+// GetDomainChecksParams pages through the checks of a domain, newest
+// first. A zero Before starts from the latest check.
+type GetDomainChecksParams struct {
+	Domain string
+	Before time.Time
+	Limit  int32
+}
+
+// CreateJobParams queues a verification job.
+type CreateJobParams struct {
+	Target      string
+	Trigger     string
+	Priority    int
+	MaxAttempts int
+	SkipCache   bool
+}
+
+// CreateModerationTaskParams queues a suspicious domain for moderation.
+type CreateModerationTaskParams struct {
+	Domain        string
+	CheckID       *string
+	Reasons       []string
+	SourceModules []string
+	Priority      int
+}
+
+// GetModerationTasksParams lists moderation tasks. An empty Status lists
+// the open ones, pending and in progress, most urgent first.
+type GetModerationTasksParams struct {
+	Status     string
+	AssignedTo string
+	Limit      int32
+	Offset     int32
+}
+
+// ResolveModerationTaskParams closes a claimed task and gives the domain
+// the manual verdict of the moderator.
+type ResolveModerationTaskParams struct {
+	Domain     string
+	Moderator  string
+	Resolution string // verified or scam
+	Notes      *string
+}
+
 // type GetDomainsByRiskScoreParams struct {
 // 	RiskScore *string
 // 	RiscScore2 *string
@@ -23,10 +79,14 @@ type CreateDomainParams struct {
 // 	Offset     int32
 // }
 
-// type GetDomainsForRecheckParams struct {
-// 	LastCheckAt *time.Time
-// 	Limit       int32
-// }
+// GetDomainsForRecheckParams selects automatic verdicts that expired by Now.
+// The selected rows are leased: their expiry moves Lease ahead, so other
+// instances skip them and a failed recheck is retried after the lease.
+type GetDomainsForRecheckParams struct {
+	Now   time.Time
+	Lease time.Duration
+	Limit int32
+}
 
 // type GetDomainsByStatusParams struct {
 // 	Status string
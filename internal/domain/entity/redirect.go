@@ -0,0 +1,53 @@
+package entity
+
+import "strings"
+
+// Redirect kinds.
+const (
+	RedirectHTTP = "http" // 3xx with a Location header
+	RedirectMeta = "meta" // <meta http-equiv="refresh">
+	RedirectJS   = "js"   // location assignment in an inline script
+)
+
+// RedirectHop is a URL visited while following redirects. Kind tells how
+// the hop sent us on, it is empty for the landing page.
+type RedirectHop struct {
+	URL        string `json:"url"`
+	Host       string `json:"host"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Kind       string `json:"kind,omitempty"`
+}
+
+// RedirectChain is every hop from the submitted URL to the landing page.
+type RedirectChain struct {
+	Hops         []RedirectHop `json:"hops"`
+	LimitReached bool          `json:"limit_reached,omitempty"` // stopped before the landing page
+}
+
+// Final returns the landing hop.
+func (c *RedirectChain) Final() RedirectHop {
+	if c == nil || len(c.Hops) == 0 {
+		return RedirectHop{}
+	}
+	return c.Hops[len(c.Hops)-1]
+}
+
+// Redirected reports whether the chain left the submitted host.
+func (c *RedirectChain) Redirected() bool {
+	return c != nil && len(c.Hops) > 1 && c.Hops[0].Host != c.Final().Host
+}
+
+// Path formats the hosts of the chain, e.g. "bit.ly → x.top → kaspi-bonus.xyz".
+// Hops on the same host are shown once.
+func (c *RedirectChain) Path() string {
+	if c == nil {
+		return ""
+	}
+	var hosts []string
+	for _, hop := range c.Hops {
+		if len(hosts) == 0 || hosts[len(hosts)-1] != hop.Host {
+			hosts = append(hosts, hop.Host)
+		}
+	}
+	return strings.Join(hosts, " → ")
+}
@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// Registration sources.
+const (
+	RegistrationSourceWHOIS = "whois"
+	RegistrationSourceRDAP  = "rdap"
+)
+
+// Registration is the registry data of a domain, from WHOIS or RDAP.
+// Dates and fields the registry does not publish are left empty.
+type Registration struct {
+	Domain          string
+	Registrar       string
+	RegistrarIANAID string
+	Server          string // WHOIS server or RDAP base URL that answered
+	Source          string
+
+	Created *time.Time
+	Updated *time.Time
+	Expires *time.Time
+
+	NameServers []string
+	Statuses    []string
+
+	RegistrantOrg     string
+	RegistrantCountry string // ISO 3166-1 alpha-2
+
+	AbuseEmail string
+	AbusePhone string
+}
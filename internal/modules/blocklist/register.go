@@ -0,0 +1,25 @@
+package blocklist
+
+import (
+	"time"
+
+	"github.com/ItsXomyak/scam-list/config"
+	"github.com/ItsXomyak/scam-list/internal/modules/registry"
+	"github.com/ItsXomyak/scam-list/internal/services/feed"
+	"github.com/ItsXomyak/scam-list/internal/services/pipeline"
+)
+
+const Version = "1.0.0"
+
+func init() {
+	registry.Register(registry.Module{
+		Name:    ModuleName,
+		Version: Version,
+		Weight:  3,
+		Timeout: time.Second,
+		Factory: func(cfg config.Config) (pipeline.ScamChecker, error) {
+			// the feed service keeps the shared index up to date
+			return New(feed.DefaultIndex()), nil
+		},
+	})
+}
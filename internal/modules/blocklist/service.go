@@ -0,0 +1,82 @@
+package blocklist
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/utils"
+)
+
+// ModuleName is the checker name used for weights and module results.
+const ModuleName = "blocklist"
+
+// feeds are curated lists, a listing is strong evidence. Most scam domains
+// never make it into a public feed, so a domain no feed lists gets no
+// confidence and does not pull the verdict towards clean.
+const listedConfidence = 0.95
+
+var ErrIndexNotReady = errors.New("feed index is not loaded yet")
+
+// Index answers which feeds list a domain or any of its parents.
+type Index interface {
+	Ready() bool
+	Lookup(domain string) []*entity.Feed
+}
+
+// Checker looks domains up in the in-memory index of ingested blocklist feeds.
+type Checker struct {
+	index Index
+}
+
+func New(index Index) *Checker {
+	return &Checker{index: index}
+}
+
+func (c *Checker) Name() string {
+	return ModuleName
+}
+
+func (c *Checker) Info() string {
+	return "looks the domain and its parents up in the imported blocklist feeds"
+}
+
+func (c *Checker) Check(ctx context.Context, domain string) (*entity.CheckerResult, error) {
+	host, err := utils.NormalizeDomain(domain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain %q: %w", domain, err)
+	}
+	if !c.index.Ready() {
+		return nil, ErrIndexNotReady
+	}
+
+	return score(c.index.Lookup(host)), nil
+}
+
+// score takes the highest risk score of the listing feeds.
+func score(feeds []*entity.Feed) *entity.CheckerResult {
+	res := &entity.CheckerResult{
+		Module:   ModuleName,
+		Evidence: map[string]any{"listed": len(feeds) > 0},
+	}
+	if len(feeds) == 0 {
+		return res
+	}
+
+	names := make([]string, 0, len(feeds))
+	for _, f := range feeds {
+		names = append(names, f.Name)
+		res.Reasons = append(res.Reasons, "listed in "+f.Name)
+		res.TotalScore = math.Max(res.TotalScore, f.RiskScore)
+		if res.ScamType == "" {
+			res.ScamType = f.ScamType
+		}
+	}
+	res.Confidence = listedConfidence
+	res.ScamSources = names
+	res.Evidence["feeds"] = names
+
+	return res
+}
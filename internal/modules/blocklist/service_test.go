@@ -0,0 +1,65 @@
+package blocklist
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/internal/services/feed"
+)
+
+func TestChecker(t *testing.T) {
+	index := feed.NewIndex()
+	index.Update(&entity.Feed{Name: "openphish", RiskScore: 90, ScamType: "phishing"}, []string{"kaspi-bonus.xyz"})
+	index.Update(&entity.Feed{Name: "local", RiskScore: 100}, []string{"kaspi-bonus.xyz", "olx-dostavka.site"})
+	checker := New(index)
+
+	tests := []struct {
+		domain      string
+		wantScore   float64
+		wantSources []string
+		wantType    string
+	}{
+		{domain: "https://pay.kaspi-bonus.xyz/card", wantScore: 100, wantSources: []string{"local", "openphish"}, wantType: "phishing"},
+		{domain: "olx-dostavka.site", wantScore: 100, wantSources: []string{"local"}},
+		{domain: "kaspi.kz", wantScore: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.domain, func(t *testing.T) {
+			res, err := checker.Check(context.Background(), tc.domain)
+			if err != nil {
+				t.Fatalf("Check() unexpected error: %v", err)
+			}
+			if res.TotalScore != tc.wantScore {
+				t.Errorf("TotalScore = %v; want %v", res.TotalScore, tc.wantScore)
+			}
+			if len(res.ScamSources) != len(tc.wantSources) {
+				t.Fatalf("ScamSources = %v; want %v", res.ScamSources, tc.wantSources)
+			}
+			for i := range tc.wantSources {
+				if res.ScamSources[i] != tc.wantSources[i] {
+					t.Errorf("ScamSources = %v; want %v", res.ScamSources, tc.wantSources)
+				}
+			}
+			if res.ScamType != tc.wantType {
+				t.Errorf("ScamType = %q; want %q", res.ScamType, tc.wantType)
+			}
+			wantConfidence := 0.0
+			if len(tc.wantSources) > 0 {
+				wantConfidence = listedConfidence
+			}
+			if res.Confidence != wantConfidence {
+				t.Errorf("Confidence = %v; want %v", res.Confidence, wantConfidence)
+			}
+		})
+	}
+}
+
+func TestCheckerNotReady(t *testing.T) {
+	_, err := New(feed.NewIndex()).Check(context.Background(), "kaspi-bonus.xyz")
+	if !errors.Is(err, ErrIndexNotReady) {
+		t.Errorf("Check() error = %v; want ErrIndexNotReady", err)
+	}
+}
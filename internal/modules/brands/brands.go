@@ -0,0 +1,107 @@
+// Package brands is the list of brands scammers impersonate most often,
+// shared by the checkers that look for them in domain names.
+package brands
+
+import "strings"
+
+// Brand is a protected brand and the domains it really owns.
+type Brand struct {
+	Name     string
+	Keywords []string // lowercase fragments that point to the brand in a hostname
+	Domains  []string // domains owned by the brand with their service domains
+}
+
+var protected = []Brand{
+	{Name: "Kaspi", Keywords: []string{"kaspi"}, Domains: []string{"kaspi.kz"}},
+	{Name: "Halyk Bank", Keywords: []string{"halyk", "homebank"}, Domains: []string{"halykbank.kz", "homebank.kz", "halyk.kz"}},
+	{Name: "eGov", Keywords: []string{"egov"}, Domains: []string{"egov.kz", "gov.kz"}},
+	{Name: "Jusan", Keywords: []string{"jusan"}, Domains: []string{"jusan.kz", "jusanbank.kz"}},
+	{Name: "Freedom", Keywords: []string{"ffin", "freedombank", "freedom24"}, Domains: []string{"ffin.kz", "bankffin.kz", "freedom24.com"}},
+	{Name: "ForteBank", Keywords: []string{"forte"}, Domains: []string{"forte.kz", "fortebank.com"}},
+	{Name: "Bank CenterCredit", Keywords: []string{"bcc"}, Domains: []string{"bcc.kz"}},
+	{Name: "Home Credit", Keywords: []string{"homecredit"}, Domains: []string{"homecredit.kz"}},
+	{Name: "Kazpost", Keywords: []string{"kazpost"}, Domains: []string{"post.kz", "kazpost.kz"}},
+	{Name: "Kcell", Keywords: []string{"kcell"}, Domains: []string{"kcell.kz"}},
+	{Name: "Beeline", Keywords: []string{"beeline"}, Domains: []string{"beeline.kz", "beeline.ru"}},
+	{Name: "OLX", Keywords: []string{"olx"}, Domains: []string{"olx.kz", "olx.ua", "olx.pl"}},
+	{Name: "Wildberries", Keywords: []string{"wildberries"}, Domains: []string{"wildberries.ru", "wildberries.kz", "wb.ru"}},
+	{Name: "Ozon", Keywords: []string{"ozon"}, Domains: []string{"ozon.ru", "ozon.kz"}},
+	{Name: "Telegram", Keywords: []string{"telegram"}, Domains: []string{"telegram.org", "t.me", "telegram.me", "telesco.pe", "tdesktop.com"}},
+	{Name: "WhatsApp", Keywords: []string{"whatsapp"}, Domains: []string{"whatsapp.com", "whatsapp.net", "wa.me"}},
+	{Name: "Instagram", Keywords: []string{"instagram"}, Domains: []string{"instagram.com", "cdninstagram.com", "instagr.am"}},
+	{Name: "Facebook", Keywords: []string{"facebook"}, Domains: []string{"facebook.com", "fb.com", "facebook.net", "fbcdn.net", "fb.me", "messenger.com"}},
+	{Name: "Google", Keywords: []string{"google"}, Domains: []string{"google.com", "google.kz", "googleapis.com", "googleapis.cn", "googleusercontent.com", "gstatic.com", "googlevideo.com", "google-analytics.com", "googlemail.com", "gmail.com", "youtube.com"}},
+	{Name: "Apple", Keywords: []string{"apple", "icloud"}, Domains: []string{"apple.com", "icloud.com", "icloud-content.com", "apple-cloudkit.com", "mzstatic.com", "me.com"}},
+	{Name: "Microsoft", Keywords: []string{"microsoft", "office365"}, Domains: []string{"microsoft.com", "office.com", "live.com", "microsoftonline.com", "office365.com", "outlook.com", "hotmail.com", "windows.net", "azure.com", "msn.com", "bing.com"}},
+	{Name: "PayPal", Keywords: []string{"paypal"}, Domains: []string{"paypal.com", "paypal.me", "paypalobjects.com"}},
+	{Name: "Binance", Keywords: []string{"binance"}, Domains: []string{"binance.com", "binance.org", "bnbstatic.com"}},
+}
+
+// All returns the protected brands.
+func All() []Brand {
+	return protected
+}
+
+// Owns reports whether the registrable domain belongs to the brand. Some
+// brand domains like googleapis.com and gov.kz are public suffixes, names
+// under them are registrable on their own and belong to the brand too.
+func (b Brand) Owns(registrable string) bool {
+	registrable = strings.ToLower(registrable)
+	for _, d := range b.Domains {
+		if registrable == d || strings.HasSuffix(registrable, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// Match returns the first brand keyword found in s. A keyword must start
+// a label or follow a hyphen or digit, so "apple" does not match
+// snapple.com, and keywords shorter than five letters must be a whole
+// hyphen or dot separated token, so "bcc" does not match inside random
+// words.
+func (b Brand) Match(s string) (string, bool) {
+	s = strings.ToLower(s)
+	tokens := strings.FieldsFunc(s, func(r rune) bool { return r == '-' || r == '.' })
+
+	for _, kw := range b.Keywords {
+		if len(kw) >= 5 {
+			if startsWord(s, kw) {
+				return kw, true
+			}
+			continue
+		}
+		for _, t := range tokens {
+			if t == kw {
+				return kw, true
+			}
+		}
+	}
+	return "", false
+}
+
+// startsWord reports whether kw is in s right after the start, a dot, a
+// hyphen or a digit.
+func startsWord(s, kw string) bool {
+	for i := 0; ; {
+		j := strings.Index(s[i:], kw)
+		if j < 0 {
+			return false
+		}
+		i += j
+		if i == 0 || !(s[i-1] >= 'a' && s[i-1] <= 'z') {
+			return true
+		}
+		i++
+	}
+}
+
+// OwnedBy returns the brand owning the registrable domain.
+func OwnedBy(registrable string) (Brand, bool) {
+	for _, b := range protected {
+		if b.Owns(registrable) {
+			return b, true
+		}
+	}
+	return Brand{}, false
+}
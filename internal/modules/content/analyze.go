@@ -0,0 +1,362 @@
+package content
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/internal/modules/brands"
+	"github.com/ItsXomyak/scam-list/pkg/utils"
+)
+
+const (
+	cardFormScore     = 40.0
+	passwordFormScore = 25.0
+	foreignFormScore  = 30.0
+	kitScore          = 40.0
+	obfuscatedScore   = 25.0
+	brandTitleScore   = 30.0
+	walletScore       = 25.0
+
+	maxScriptEvidence = 3
+)
+
+var (
+	// input names, ids, placeholders and autocomplete values of card fields
+	cardFieldMarkers = []string{
+		"cc-number", "cc-csc", "cc-exp", "cardnumber", "card_number", "card-number", "ccnum",
+		"cvv", "cvc", "expiry", "exp_date", "номер карты", "срок действия",
+	}
+
+	// paths and strings left by popular phishing kits
+	kitSignatures = []string{
+		"api.telegram.org/bot", "/antibot", "16shop", "ex-robotos", "kr3pto", "/panel/bots",
+		"killbot", "visitor_log",
+	}
+
+	// kit script names, matched against the whole file name of a reference
+	// because shorter generic names like send.php are on plenty of real sites
+	kitFiles = []string{"blocker.php", "verif.php"}
+
+	obfuscationRes = []*regexp.Regexp{
+		regexp.MustCompile(`eval\s*\(\s*(atob|unescape|decodeURIComponent|String\.fromCharCode)\s*\(`),
+		regexp.MustCompile(`document\.write\s*\(\s*(unescape|atob)\s*\(`),
+		regexp.MustCompile(`(\\x[0-9a-fA-F]{2}){40,}`),
+		regexp.MustCompile(`(%[0-9a-fA-F]{2}){60,}`),
+		regexp.MustCompile(`[A-Za-z0-9+/]{800,}={0,2}`),
+		regexp.MustCompile(`_0x[0-9a-f]{4,6}\[`),
+	}
+
+	walletRes = map[string]*regexp.Regexp{
+		"btc":  regexp.MustCompile(`\b(bc1[02-9ac-hj-np-z]{25,59}|[13][1-9A-HJ-NP-Za-km-z]{25,34})\b`),
+		"eth":  regexp.MustCompile(`\b0x[0-9a-fA-F]{40}\b`),
+		"tron": regexp.MustCompile(`\bT[1-9A-HJ-NP-Za-km-z]{33}\b`),
+	}
+)
+
+// findings is what the walk over the document collected.
+type findings struct {
+	title   string
+	text    strings.Builder
+	scripts []string // inline script bodies
+	refs    []string // src and href values
+	forms   []form
+	curForm int  // index in forms of the open form, -1 outside forms
+	noForm  form // inputs outside any form
+}
+
+type form struct {
+	action   string
+	method   string
+	password bool
+	card     bool
+}
+
+func analyze(p *page) *entity.CheckerResult {
+	f := &findings{curForm: -1}
+	f.walk(p.doc)
+
+	res := &entity.CheckerResult{
+		Module:     ModuleName,
+		Confidence: confidence,
+		Evidence: map[string]any{
+			"url":         p.url,
+			"status_code": p.statusCode,
+			"forms":       len(f.forms),
+		},
+	}
+	if f.title != "" {
+		res.Evidence["title"] = f.title
+	}
+	if p.truncated {
+		res.Evidence["truncated"] = true
+	}
+
+	var signals []string
+	add := func(signal string, score float64, reason string) {
+		signals = append(signals, signal)
+		res.TotalScore += score
+		res.Reasons = append(res.Reasons, reason)
+	}
+
+	registrable, _ := utils.RegistrableDomain(p.host)
+	owner, owned := brands.OwnedBy(registrable)
+
+	forms := append([]form{f.noForm}, f.forms...)
+	if !owned {
+		switch {
+		case anyForm(forms, func(fm form) bool { return fm.card }):
+			add("card_form", cardFormScore, "page asks for bank card details")
+			res.ScamType = "phishing"
+		case anyForm(forms, func(fm form) bool { return fm.password }):
+			add("password_form", passwordFormScore, "page has a password form")
+		}
+	}
+
+	if targets := foreignTargets(f.forms, p.url, registrable); len(targets) > 0 {
+		add("foreign_form", foreignFormScore, fmt.Sprintf("form posts data to another domain: %s", strings.Join(targets, ", ")))
+		res.Evidence["form_targets"] = targets
+	}
+
+	if kits := f.kitSignatures(); len(kits) > 0 {
+		add("phishing_kit", kitScore, fmt.Sprintf("page contains phishing kit signatures: %s", strings.Join(kits, ", ")))
+		res.Evidence["kit_signatures"] = kits
+		res.ScamType = "phishing"
+	}
+
+	if samples := f.obfuscatedScripts(); len(samples) > 0 {
+		add("obfuscated_js", obfuscatedScore, "page runs obfuscated JavaScript")
+		res.Evidence["obfuscated_scripts"] = samples
+	}
+
+	if brand, ok := titleBrand(f.title); ok && !(owned && owner.Name == brand.Name) {
+		add("brand_title", brandTitleScore, fmt.Sprintf("page title mentions %s but the site is not theirs", brand.Name))
+		res.ScamType = "phishing"
+	}
+
+	if wallets := findWallets(f.text.String()); len(wallets) > 0 {
+		add("crypto_wallet", walletScore, fmt.Sprintf("page shows %d crypto wallet address(es)", len(wallets)))
+		res.Evidence["wallets"] = wallets
+		if res.ScamType == "" {
+			res.ScamType = "crypto"
+		}
+	}
+
+	res.TotalScore = min(res.TotalScore, 100)
+	res.Evidence["signals"] = signals
+
+	return res
+}
+
+func (f *findings) walk(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		if n.Parent == nil || (n.Parent.DataAtom != atom.Script && n.Parent.DataAtom != atom.Style) {
+			f.text.WriteString(n.Data)
+			f.text.WriteByte(' ')
+		}
+	case html.ElementNode:
+		f.element(n)
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		f.walk(c)
+	}
+
+	if n.Type == html.ElementNode && n.DataAtom == atom.Form {
+		f.curForm = -1
+	}
+}
+
+func (f *findings) element(n *html.Node) {
+	switch n.DataAtom {
+	case atom.Title:
+		if f.title == "" && n.FirstChild != nil {
+			f.title = strings.TrimSpace(n.FirstChild.Data)
+		}
+	case atom.Script:
+		if src := attr(n, "src"); src != "" {
+			f.refs = append(f.refs, src)
+		} else if n.FirstChild != nil {
+			f.scripts = append(f.scripts, n.FirstChild.Data)
+		}
+	case atom.Link, atom.A, atom.Img, atom.Iframe:
+		for _, key := range []string{"href", "src"} {
+			if v := attr(n, key); v != "" {
+				f.refs = append(f.refs, v)
+			}
+		}
+	case atom.Form:
+		f.forms = append(f.forms, form{action: attr(n, "action"), method: strings.ToLower(attr(n, "method"))})
+		f.curForm = len(f.forms) - 1
+		f.refs = append(f.refs, attr(n, "action"))
+	case atom.Input:
+		fm := &f.noForm
+		if f.curForm >= 0 {
+			fm = &f.forms[f.curForm]
+		}
+		if strings.EqualFold(attr(n, "type"), "password") {
+			fm.password = true
+		}
+		if isCardField(n) {
+			fm.card = true
+		}
+	}
+}
+
+// kitSignatures looks for kit strings in references and inline scripts
+// and for kit file names in references.
+func (f *findings) kitSignatures() []string {
+	haystack := strings.ToLower(strings.Join(f.refs, "\n") + "\n" + strings.Join(f.scripts, "\n"))
+
+	var found []string
+	for _, sig := range kitSignatures {
+		if strings.Contains(haystack, sig) {
+			found = append(found, sig)
+		}
+	}
+
+	files := make(map[string]struct{})
+	for _, ref := range f.refs {
+		if u, err := url.Parse(strings.TrimSpace(ref)); err == nil {
+			files[strings.ToLower(path.Base(u.Path))] = struct{}{}
+		}
+	}
+	for _, name := range kitFiles {
+		if _, ok := files[name]; ok {
+			found = append(found, name)
+		}
+	}
+	return found
+}
+
+// obfuscatedScripts returns the start of inline scripts that look packed.
+func (f *findings) obfuscatedScripts() []string {
+	var samples []string
+	for _, script := range f.scripts {
+		for _, re := range obfuscationRes {
+			if re.MatchString(script) {
+				sample := strings.TrimSpace(script)
+				if len(sample) > 80 {
+					sample = sample[:80] + "..."
+				}
+				samples = append(samples, sample)
+				break
+			}
+		}
+		if len(samples) == maxScriptEvidence {
+			break
+		}
+	}
+	return samples
+}
+
+// foreignTargets returns hosts outside the page domain that forms submit to.
+func foreignTargets(forms []form, pageURL, registrable string) []string {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil
+	}
+
+	seen := make(map[string]struct{})
+	var targets []string
+	for _, fm := range forms {
+		if fm.action == "" {
+			continue
+		}
+		action, err := base.Parse(strings.TrimSpace(fm.action))
+		if err != nil || (action.Scheme != "http" && action.Scheme != "https") {
+			continue
+		}
+		target, err := utils.RegistrableDomain(action.Hostname())
+		if err != nil {
+			target = action.Hostname()
+		}
+		if target == registrable {
+			continue
+		}
+		if _, ok := seen[target]; !ok {
+			seen[target] = struct{}{}
+			targets = append(targets, target)
+		}
+	}
+	return targets
+}
+
+// titleBrand finds a protected brand in the page title.
+func titleBrand(title string) (brands.Brand, bool) {
+	if title == "" {
+		return brands.Brand{}, false
+	}
+	// Match splits short keywords on dots and hyphens only
+	normalized := strings.Join(strings.FieldsFunc(strings.ToLower(title), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '.')
+	}), "-")
+
+	for _, b := range brands.All() {
+		if _, ok := b.Match(normalized); ok {
+			return b, true
+		}
+	}
+	return brands.Brand{}, false
+}
+
+// findWallets returns wallet addresses found in the text, sorted.
+func findWallets(text string) []string {
+	seen := make(map[string]struct{})
+	for _, re := range walletRes {
+		for _, w := range re.FindAllString(text, -1) {
+			seen[w] = struct{}{}
+		}
+	}
+
+	wallets := make([]string, 0, len(seen))
+	for w := range seen {
+		wallets = append(wallets, w)
+	}
+	sort.Strings(wallets)
+	return wallets
+}
+
+func isCardField(n *html.Node) bool {
+	if strings.HasPrefix(strings.ToLower(attr(n, "autocomplete")), "cc-") {
+		return true
+	}
+	for _, key := range []string{"name", "id", "placeholder"} {
+		v := strings.ToLower(attr(n, key))
+		if v == "" {
+			continue
+		}
+		for _, marker := range cardFieldMarkers {
+			if strings.Contains(v, marker) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func anyForm(forms []form, fn func(form) bool) bool {
+	for _, fm := range forms {
+		if fn(fm) {
+			return true
+		}
+	}
+	return false
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
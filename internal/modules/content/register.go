@@ -0,0 +1,29 @@
+package content
+
+import (
+	"time"
+
+	"github.com/ItsXomyak/scam-list/config"
+	"github.com/ItsXomyak/scam-list/internal/modules/registry"
+	"github.com/ItsXomyak/scam-list/internal/services/pipeline"
+	"github.com/ItsXomyak/scam-list/pkg/safehttp"
+)
+
+const Version = "1.0.0"
+
+func init() {
+	registry.Register(registry.Module{
+		Name:    ModuleName,
+		Version: Version,
+		Weight:  2,
+		Timeout: 15 * time.Second,
+		Factory: func(cfg config.Config) (pipeline.ScamChecker, error) {
+			client := safehttp.New(safehttp.Config{
+				Timeout:   cfg.Content.Timeout,
+				UserAgent: cfg.Outbound.UserAgent,
+				Allow:     cfg.Outbound.AllowCIDRs,
+			})
+			return New(client, cfg.Content.Timeout, cfg.Content.MaxBody), nil
+		},
+	})
+}
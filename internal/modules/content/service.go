@@ -0,0 +1,126 @@
+package content
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/safehttp"
+	"github.com/ItsXomyak/scam-list/pkg/utils"
+)
+
+// ModuleName is the checker name used for weights and module results.
+const ModuleName = "content"
+
+const (
+	// page content is strong evidence but kits hide from crawlers
+	confidence = 0.7
+
+	defaultMaxBody = 1 << 20
+)
+
+var ErrNotHTML = errors.New("landing page is not html")
+
+// Checker fetches the landing page of a domain and analyses its HTML.
+type Checker struct {
+	client  *http.Client
+	maxBody int64
+}
+
+// New creates a checker. A nil client uses a safehttp client with the given
+// timeout, maxBody <= 0 reads up to 1 MiB of the page.
+func New(client *http.Client, timeout time.Duration, maxBody int64) *Checker {
+	if client == nil {
+		client = safehttp.New(safehttp.Config{Timeout: timeout})
+	}
+	if maxBody <= 0 {
+		maxBody = defaultMaxBody
+	}
+	return &Checker{client: client, maxBody: maxBody}
+}
+
+func (c *Checker) Name() string {
+	return ModuleName
+}
+
+func (c *Checker) Info() string {
+	return "landing page content: credential forms, phishing kits, obfuscated scripts, wallets"
+}
+
+func (c *Checker) Check(ctx context.Context, domain string) (*entity.CheckerResult, error) {
+	host, err := utils.ExtractDomain(domain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain %q: %w", domain, err)
+	}
+
+	page, err := c.fetch(ctx, "https://"+host+"/")
+	if err != nil && ctx.Err() == nil {
+		// plenty of scam pages never bother with a certificate
+		page, err = c.fetch(ctx, "http://"+host+"/")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch landing page: %w", err)
+	}
+
+	return analyze(page), nil
+}
+
+// page is a fetched landing page.
+type page struct {
+	url        string // after redirects
+	host       string
+	statusCode int
+	doc        *html.Node
+	truncated  bool
+}
+
+func (c *Checker) fetch(ctx context.Context, url string) (*page, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if ct := resp.Header.Get("Content-Type"); ct != "" {
+		mediaType, _, _ := mime.ParseMediaType(ct)
+		if mediaType != "text/html" && mediaType != "application/xhtml+xml" {
+			return nil, fmt.Errorf("%w: %s", ErrNotHTML, mediaType)
+		}
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.maxBody+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page: %w", err)
+	}
+	truncated := int64(len(body)) > c.maxBody
+	if truncated {
+		body = body[:c.maxBody]
+	}
+
+	doc, err := html.Parse(strings.NewReader(string(body)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse page: %w", err)
+	}
+
+	return &page{
+		url:        resp.Request.URL.String(),
+		host:       resp.Request.URL.Hostname(),
+		statusCode: resp.StatusCode,
+		doc:        doc,
+		truncated:  truncated,
+	}, nil
+}
@@ -0,0 +1,195 @@
+package content
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newSite serves the fixture as the landing page of every host.
+func newSite(t *testing.T, fixture, contentType string) *httptest.Server {
+	t.Helper()
+
+	body, err := os.ReadFile("testdata/" + fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") == "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// routedClient sends requests for any host to the stand-in server.
+func routedClient(srv *httptest.Server) *http.Client {
+	addr := srv.Listener.Addr().String()
+	return &http.Client{
+		Timeout: 5 * time.Second,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, network, addr)
+			},
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name        string
+		domain      string
+		fixture     string
+		wantSignals []string
+		wantScore   float64
+		scamType    string
+	}{
+		{
+			name:        "card phishing kit",
+			domain:      "kaspi-bonus.xyz",
+			fixture:     "kaspi_phish.html",
+			wantSignals: []string{"card_form", "foreign_form", "phishing_kit", "brand_title"},
+			wantScore:   100,
+			scamType:    "phishing",
+		},
+		{
+			name:        "crypto giveaway",
+			domain:      "https://btc-giveaway.top/promo",
+			fixture:     "crypto_giveaway.html",
+			wantSignals: []string{"obfuscated_js", "crypto_wallet"},
+			wantScore:   obfuscatedScore + walletScore,
+			scamType:    "crypto",
+		},
+		{
+			name:    "benign shop",
+			domain:  "corner-bakery.kz",
+			fixture: "benign.html",
+		},
+		{
+			name:    "wordpress blog with comments and a contact form",
+			domain:  "dacha-notes.kz",
+			fixture: "wordpress.html",
+		},
+		{
+			name:    "brand on its own domain",
+			domain:  "kaspi.kz",
+			fixture: "kaspi_login.html",
+		},
+		{
+			name:        "brand login elsewhere",
+			domain:      "kaspi-login.site",
+			fixture:     "kaspi_login.html",
+			wantSignals: []string{"password_form", "brand_title"},
+			wantScore:   passwordFormScore + brandTitleScore,
+			scamType:    "phishing",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			srv := newSite(t, tc.fixture, "text/html; charset=utf-8")
+
+			res, err := New(routedClient(srv), 0, 0).Check(context.Background(), tc.domain)
+			if err != nil {
+				t.Fatalf("Check() unexpected error: %v", err)
+			}
+
+			signals, _ := res.Evidence["signals"].([]string)
+			if len(signals) != 0 || len(tc.wantSignals) != 0 {
+				if !reflect.DeepEqual(signals, tc.wantSignals) {
+					t.Errorf("signals = %v; want %v", signals, tc.wantSignals)
+				}
+			}
+			if res.TotalScore != tc.wantScore {
+				t.Errorf("TotalScore = %v; want %v (reasons %v)", res.TotalScore, tc.wantScore, res.Reasons)
+			}
+			if res.ScamType != tc.scamType {
+				t.Errorf("ScamType = %q; want %q", res.ScamType, tc.scamType)
+			}
+			if len(res.Reasons) != len(tc.wantSignals) {
+				t.Errorf("Reasons = %v; want one per signal", res.Reasons)
+			}
+		})
+	}
+}
+
+func TestCheckEvidence(t *testing.T) {
+	srv := newSite(t, "crypto_giveaway.html", "text/html")
+
+	res, err := New(routedClient(srv), 0, 0).Check(context.Background(), "btc-giveaway.top")
+	if err != nil {
+		t.Fatalf("Check() unexpected error: %v", err)
+	}
+
+	wantWallets := []string{"0x742d35Cc6634C0532925a3b844Bc454e4438f44e", "bc1qxy2kgdygjrsqtzq2n0yrf2493p83kkfjhx0wlh"}
+	if !reflect.DeepEqual(res.Evidence["wallets"], wantWallets) {
+		t.Errorf("wallets = %v; want %v", res.Evidence["wallets"], wantWallets)
+	}
+	if res.Evidence["title"] != "Elon Giveaway 5000 BTC" {
+		t.Errorf("title = %v", res.Evidence["title"])
+	}
+	// https fails against the plain stand-in, so the page comes over http
+	if url, _ := res.Evidence["url"].(string); !strings.HasPrefix(url, "http://btc-giveaway.top/") {
+		t.Errorf("url = %v; want the http landing page", res.Evidence["url"])
+	}
+}
+
+func TestCheckLimits(t *testing.T) {
+	srv := newSite(t, "kaspi_phish.html", "text/html")
+
+	// the forms are past the first 200 bytes
+	res, err := New(routedClient(srv), 0, 200).Check(context.Background(), "kaspi-bonus.xyz")
+	if err != nil {
+		t.Fatalf("Check() unexpected error: %v", err)
+	}
+	if res.Evidence["truncated"] != true {
+		t.Error("truncated = false; want true for a page over the limit")
+	}
+	if res.Evidence["forms"] != 0 {
+		t.Errorf("forms = %v; want 0 after truncation", res.Evidence["forms"])
+	}
+}
+
+func TestCheckErrors(t *testing.T) {
+	notHTML := newSite(t, "benign.html", "application/octet-stream")
+	if _, err := New(routedClient(notHTML), 0, 0).Check(context.Background(), "files.example.com"); err == nil {
+		t.Error("Check() expected error for a non-html page, got none")
+	}
+
+	down := newSite(t, "benign.html", "text/html")
+	client := routedClient(down)
+	down.Close()
+	if _, err := New(client, 0, 0).Check(context.Background(), "offline.example.com"); err == nil {
+		t.Error("Check() expected error for an unreachable site, got none")
+	}
+}
+
+func TestFindWallets(t *testing.T) {
+	tests := []struct {
+		text string
+		want int
+	}{
+		{text: "send to TJCnKsPa7y5okkXvQAidZBzqx3QyQ6sxMW now", want: 1},
+		{text: "legacy 1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2 and 3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", want: 2},
+		{text: "call +7 777 123 45 67, order 0x1234", want: 0},
+	}
+
+	for _, tc := range tests {
+		if got := findWallets(tc.text); len(got) != tc.want {
+			t.Errorf("findWallets(%q) = %v; want %d wallets", tc.text, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package dns
+
+import (
+	"time"
+
+	"github.com/ItsXomyak/scam-list/config"
+	"github.com/ItsXomyak/scam-list/internal/modules/registry"
+	"github.com/ItsXomyak/scam-list/internal/services/pipeline"
+)
+
+const Version = "1.0.0"
+
+func init() {
+	registry.Register(registry.Module{
+		Name:    ModuleName,
+		Version: Version,
+		Weight:  1,
+		Timeout: 10 * time.Second,
+		Factory: func(cfg config.Config) (pipeline.ScamChecker, error) {
+			return New(NewClient(cfg.DNS.Resolver, cfg.DNS.Timeout), cfg.DNS.AbusedNameservers), nil
+		},
+	})
+}
@@ -0,0 +1,214 @@
+package dns
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	udpPayloadSize = 4096
+)
+
+var ErrNXDomain = errors.New("domain does not exist")
+
+// Record is a single DNS answer.
+type Record struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+	TTL   uint32 `json:"ttl"`
+}
+
+// Resolver answers DNS queries. It is an interface so tests and other
+// deployments can plug in their own transport.
+type Resolver interface {
+	Lookup(ctx context.Context, name string, qtype dnsmessage.Type) ([]Record, error)
+}
+
+// Client is a Resolver that sends queries to a single recursive resolver
+// over UDP and retries truncated answers over TCP.
+type Client struct {
+	addr    string
+	timeout time.Duration
+}
+
+// NewClient creates a client for the resolver at addr, e.g. "1.1.1.1:53".
+func NewClient(addr string, timeout time.Duration) *Client {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = net.JoinHostPort(addr, "53")
+	}
+	return &Client{addr: addr, timeout: timeout}
+}
+
+// Lookup returns the answers of type qtype for name. CNAME answers are
+// returned too, so a lookup of a CNAME'd name shows the chain.
+func (c *Client) Lookup(ctx context.Context, name string, qtype dnsmessage.Type) ([]Record, error) {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	id := uint16(rand.Uint32())
+	query, err := buildQuery(id, name, qtype)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := c.exchange(ctx, "udp", id, query)
+	if err != nil {
+		return nil, err
+	}
+	if msg.Truncated {
+		if msg, err = c.exchange(ctx, "tcp", id, query); err != nil {
+			return nil, err
+		}
+	}
+
+	switch msg.RCode {
+	case dnsmessage.RCodeSuccess:
+	case dnsmessage.RCodeNameError:
+		return nil, ErrNXDomain
+	default:
+		return nil, fmt.Errorf("dns query %s %s failed: %s", name, qtype, msg.RCode)
+	}
+
+	records := make([]Record, 0, len(msg.Answers))
+	for _, a := range msg.Answers {
+		if r, ok := toRecord(a); ok {
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
+
+// exchange sends the query and returns the answer with the query ID.
+// Over UDP other datagrams, e.g. late answers to an earlier query or
+// spoofed ones, are skipped until the answer comes or the deadline passes.
+func (c *Client) exchange(ctx context.Context, network string, id uint16, query []byte) (*dnsmessage.Message, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, network, c.addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial resolver: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	if network == "udp" {
+		if _, err := conn.Write(query); err != nil {
+			return nil, fmt.Errorf("failed to send query: %w", err)
+		}
+		buf := make([]byte, udpPayloadSize)
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read answer: %w", err)
+			}
+			if msg, err := parseResponse(id, buf[:n]); err == nil {
+				return msg, nil
+			}
+		}
+	}
+
+	// TCP messages are prefixed with their length
+	framed := make([]byte, 2+len(query))
+	binary.BigEndian.PutUint16(framed, uint16(len(query)))
+	copy(framed[2:], query)
+	if _, err := conn.Write(framed); err != nil {
+		return nil, fmt.Errorf("failed to send query: %w", err)
+	}
+
+	var size [2]byte
+	if _, err := io.ReadFull(conn, size[:]); err != nil {
+		return nil, fmt.Errorf("failed to read answer: %w", err)
+	}
+	buf := make([]byte, binary.BigEndian.Uint16(size[:]))
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		return nil, fmt.Errorf("failed to read answer: %w", err)
+	}
+	return parseResponse(id, buf)
+}
+
+func buildQuery(id uint16, name string, qtype dnsmessage.Type) ([]byte, error) {
+	qname, err := dnsmessage.NewName(fqdn(name))
+	if err != nil {
+		return nil, fmt.Errorf("invalid name %q: %w", name, err)
+	}
+
+	b := dnsmessage.NewBuilder(make([]byte, 0, 512), dnsmessage.Header{ID: id, RecursionDesired: true})
+	b.EnableCompression()
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := b.Question(dnsmessage.Question{Name: qname, Type: qtype, Class: dnsmessage.ClassINET}); err != nil {
+		return nil, err
+	}
+	if err := b.StartAdditionals(); err != nil {
+		return nil, err
+	}
+
+	var opt dnsmessage.ResourceHeader
+	if err := opt.SetEDNS0(udpPayloadSize, dnsmessage.RCodeSuccess, false); err != nil {
+		return nil, err
+	}
+	if err := b.OPTResource(opt, dnsmessage.OPTResource{}); err != nil {
+		return nil, err
+	}
+
+	return b.Finish()
+}
+
+func parseResponse(id uint16, resp []byte) (*dnsmessage.Message, error) {
+	var msg dnsmessage.Message
+	if err := msg.Unpack(resp); err != nil {
+		return nil, fmt.Errorf("invalid dns answer: %w", err)
+	}
+	if msg.ID != id || !msg.Response {
+		return nil, errors.New("dns answer does not match the query")
+	}
+	return &msg, nil
+}
+
+func toRecord(r dnsmessage.Resource) (Record, bool) {
+	rec := Record{Type: typeName(r.Header.Type), TTL: r.Header.TTL}
+
+	switch b := r.Body.(type) {
+	case *dnsmessage.AResource:
+		rec.Value = net.IP(b.A[:]).String()
+	case *dnsmessage.AAAAResource:
+		rec.Value = net.IP(b.AAAA[:]).String()
+	case *dnsmessage.CNAMEResource:
+		rec.Value = trimDot(b.CNAME.String())
+	case *dnsmessage.MXResource:
+		rec.Value = fmt.Sprintf("%d %s", b.Pref, trimDot(b.MX.String()))
+	case *dnsmessage.NSResource:
+		rec.Value = trimDot(b.NS.String())
+	case *dnsmessage.TXTResource:
+		rec.Value = strings.Join(b.TXT, "")
+	default:
+		return Record{}, false
+	}
+	return rec, true
+}
+
+func fqdn(name string) string {
+	if strings.HasSuffix(name, ".") {
+		return name
+	}
+	return name + "."
+}
+
+func trimDot(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
@@ -0,0 +1,141 @@
+package dns
+
+import (
+	"net"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// zone maps a lowercase name without the trailing dot to its records.
+// A "*.example.com" name answers for any subdomain of example.com.
+type zone map[string][]testRecord
+
+type testRecord struct {
+	typ   dnsmessage.Type
+	value string
+	ttl   uint32
+}
+
+// startServer serves the zone over UDP on localhost and returns its address.
+func startServer(t *testing.T, z zone) string {
+	t.Helper()
+	return serveUDP(t, z, false)
+}
+
+// startStrayServer is startServer that sends a reply with another ID,
+// like a late answer to an earlier query, before every real answer.
+func startStrayServer(t *testing.T, z zone) string {
+	t.Helper()
+	return serveUDP(t, z, true)
+}
+
+func serveUDP(t *testing.T, z zone, stray bool) string {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			resp, err := z.answer(buf[:n])
+			if err != nil {
+				continue
+			}
+			if stray {
+				other := append([]byte(nil), resp...)
+				other[0] ^= 0xff
+				conn.WriteTo(other, addr)
+			}
+			conn.WriteTo(resp, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func (z zone) answer(query []byte) ([]byte, error) {
+	var p dnsmessage.Parser
+	h, err := p.Start(query)
+	if err != nil {
+		return nil, err
+	}
+	q, err := p.Question()
+	if err != nil {
+		return nil, err
+	}
+
+	name := strings.ToLower(strings.TrimSuffix(q.Name.String(), "."))
+	records, ok := z.lookup(name)
+
+	resp := dnsmessage.Header{ID: h.ID, Response: true, RecursionAvailable: true}
+	if !ok {
+		resp.RCode = dnsmessage.RCodeNameError
+	}
+
+	b := dnsmessage.NewBuilder(nil, resp)
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := b.Question(q); err != nil {
+		return nil, err
+	}
+	if err := b.StartAnswers(); err != nil {
+		return nil, err
+	}
+
+	for _, r := range records {
+		if r.typ != q.Type {
+			continue
+		}
+		rh := dnsmessage.ResourceHeader{Name: q.Name, Type: r.typ, Class: dnsmessage.ClassINET, TTL: r.ttl}
+		if err := addRecord(&b, rh, r); err != nil {
+			return nil, err
+		}
+	}
+
+	return b.Finish()
+}
+
+func (z zone) lookup(name string) ([]testRecord, bool) {
+	if records, ok := z[name]; ok {
+		return records, true
+	}
+	if i := strings.Index(name, "."); i >= 0 {
+		if records, ok := z["*"+name[i:]]; ok {
+			return records, true
+		}
+	}
+	return nil, false
+}
+
+func addRecord(b *dnsmessage.Builder, h dnsmessage.ResourceHeader, r testRecord) error {
+	switch r.typ {
+	case dnsmessage.TypeA:
+		var a [4]byte
+		copy(a[:], net.ParseIP(r.value).To4())
+		return b.AResource(h, dnsmessage.AResource{A: a})
+	case dnsmessage.TypeAAAA:
+		var a [16]byte
+		copy(a[:], net.ParseIP(r.value).To16())
+		return b.AAAAResource(h, dnsmessage.AAAAResource{AAAA: a})
+	case dnsmessage.TypeMX:
+		return b.MXResource(h, dnsmessage.MXResource{Pref: 10, MX: dnsmessage.MustNewName(r.value + ".")})
+	case dnsmessage.TypeNS:
+		return b.NSResource(h, dnsmessage.NSResource{NS: dnsmessage.MustNewName(r.value + ".")})
+	case dnsmessage.TypeCNAME:
+		return b.CNAMEResource(h, dnsmessage.CNAMEResource{CNAME: dnsmessage.MustNewName(r.value + ".")})
+	case dnsmessage.TypeTXT:
+		return b.TXTResource(h, dnsmessage.TXTResource{TXT: []string{r.value}})
+	}
+	return nil
+}
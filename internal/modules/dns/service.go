@@ -0,0 +1,248 @@
+package dns
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/net/dns/dnsmessage"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/utils"
+)
+
+// ModuleName is the checker name used for weights and module results.
+const ModuleName = "dns"
+
+const (
+	confidence = 0.5
+
+	nxDomainScore    = 50.0
+	noAddressScore   = 20.0
+	noMXScore        = 15.0
+	fastFluxScore    = 40.0
+	abusedNSScore    = 25.0
+	wildcardScore    = 15.0
+	fastFluxMinAddrs = 5
+	fastFluxMaxTTL   = 300
+)
+
+// DefaultAbusedNameservers are free DNS hosting providers popular with throwaway scam domains.
+var DefaultAbusedNameservers = []string{
+	"freenom.com",
+	"afraid.org",
+	"duckdns.org",
+	"dynu.com",
+	"1984.is",
+	"njal.la",
+}
+
+var queryTypes = []dnsmessage.Type{
+	dnsmessage.TypeA,
+	dnsmessage.TypeAAAA,
+	dnsmessage.TypeMX,
+	dnsmessage.TypeNS,
+	dnsmessage.TypeTXT,
+	dnsmessage.TypeCNAME,
+}
+
+// Checker scores a domain by its DNS records.
+type Checker struct {
+	resolver Resolver
+	abusedNS []string
+}
+
+// New creates a checker. Empty abusedNS means DefaultAbusedNameservers.
+func New(resolver Resolver, abusedNS []string) *Checker {
+	if len(abusedNS) == 0 {
+		abusedNS = DefaultAbusedNameservers
+	}
+
+	normalized := make([]string, 0, len(abusedNS))
+	for _, ns := range abusedNS {
+		if ns = strings.ToLower(strings.Trim(strings.TrimSpace(ns), ".")); ns != "" {
+			normalized = append(normalized, ns)
+		}
+	}
+
+	return &Checker{resolver: resolver, abusedNS: normalized}
+}
+
+func (c *Checker) Name() string {
+	return ModuleName
+}
+
+func (c *Checker) Info() string {
+	return "DNS records: missing MX, fast-flux, abused nameservers and wildcard DNS"
+}
+
+// answers holds the records of every query type.
+type answers struct {
+	records  map[string][]Record
+	errs     map[string]error
+	nxdomain bool
+}
+
+func (c *Checker) Check(ctx context.Context, domain string) (*entity.CheckerResult, error) {
+	host, err := utils.ExtractDomain(domain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain %q: %w", domain, err)
+	}
+	registrable, err := utils.RegistrableDomain(host)
+	if err != nil {
+		registrable = host
+	}
+
+	ans := c.lookupAll(ctx, host, registrable)
+	if len(ans.errs) == len(queryTypes) {
+		// the resolver is down, a verdict would only reflect that
+		return nil, fmt.Errorf("all dns queries failed: %w", firstErr(ans.errs))
+	}
+
+	res := &entity.CheckerResult{
+		Module:     ModuleName,
+		Confidence: confidence,
+		Evidence:   map[string]any{"records": ans.records},
+	}
+	if len(ans.errs) > 0 {
+		failed := make(map[string]string, len(ans.errs))
+		for t, err := range ans.errs {
+			failed[t] = err.Error()
+		}
+		res.Evidence["failed_queries"] = failed
+	}
+
+	if ans.nxdomain {
+		res.TotalScore = nxDomainScore
+		res.Reasons = []string{fmt.Sprintf("%s does not exist in DNS", host)}
+		return res, nil
+	}
+
+	var score float64
+	add := func(s float64, reason string) {
+		score += s
+		res.Reasons = append(res.Reasons, reason)
+	}
+
+	if len(ans.records["A"])+len(ans.records["AAAA"])+len(ans.records["CNAME"]) == 0 && ans.errs["A"] == nil && ans.errs["AAAA"] == nil {
+		add(noAddressScore, "domain has no A or AAAA records")
+	}
+
+	if len(ans.records["MX"]) == 0 && ans.errs["MX"] == nil {
+		add(noMXScore, "domain has no MX records")
+	}
+
+	if a := ans.records["A"]; len(a) >= fastFluxMinAddrs && minTTL(a) < fastFluxMaxTTL {
+		add(fastFluxScore, fmt.Sprintf("fast-flux pattern: %d A records with TTL %ds", len(a), minTTL(a)))
+	}
+
+	if abused := c.abusedNameservers(ans.records["NS"]); len(abused) > 0 {
+		add(abusedNSScore, fmt.Sprintf("nameservers on often abused providers: %s", strings.Join(abused, ", ")))
+		res.Evidence["abused_nameservers"] = abused
+	}
+
+	if wildcard := c.hasWildcard(ctx, registrable); wildcard {
+		add(wildcardScore, fmt.Sprintf("wildcard DNS: any subdomain of %s resolves", registrable))
+		res.Evidence["wildcard"] = true
+	}
+
+	res.TotalScore = min(score, 100)
+	return res, nil
+}
+
+// lookupAll runs all queries concurrently. NS is asked for the registrable
+// domain, as subdomains rarely have their own.
+func (c *Checker) lookupAll(ctx context.Context, host, registrable string) *answers {
+	ans := &answers{
+		records: make(map[string][]Record, len(queryTypes)),
+		errs:    make(map[string]error),
+	}
+
+	mu := &sync.Mutex{}
+	wg := &sync.WaitGroup{}
+	for _, t := range queryTypes {
+		wg.Add(1)
+		go func(t dnsmessage.Type) {
+			defer wg.Done()
+
+			name := host
+			if t == dnsmessage.TypeNS {
+				name = registrable
+			}
+			records, err := c.resolver.Lookup(ctx, name, t)
+
+			// CNAME chains are reported by every query, keep only the asked type
+			var matched []Record
+			for _, r := range records {
+				if r.Type == typeName(t) {
+					matched = append(matched, r)
+				}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case errors.Is(err, ErrNXDomain):
+				ans.nxdomain = true
+			case err != nil:
+				ans.errs[typeName(t)] = err
+			case len(matched) > 0:
+				ans.records[typeName(t)] = matched
+			}
+		}(t)
+	}
+	wg.Wait()
+
+	return ans
+}
+
+// hasWildcard asks for a random subdomain that can only exist under a wildcard record.
+func (c *Checker) hasWildcard(ctx context.Context, registrable string) bool {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return false
+	}
+
+	records, err := c.resolver.Lookup(ctx, "scamlist-"+hex.EncodeToString(b)+"."+registrable, dnsmessage.TypeA)
+	return err == nil && len(records) > 0
+}
+
+func (c *Checker) abusedNameservers(ns []Record) []string {
+	var abused []string
+	for _, r := range ns {
+		for _, provider := range c.abusedNS {
+			if r.Value == provider || strings.HasSuffix(r.Value, "."+provider) {
+				abused = append(abused, r.Value)
+				break
+			}
+		}
+	}
+	return abused
+}
+
+func minTTL(records []Record) uint32 {
+	var ttl uint32
+	for i, r := range records {
+		if i == 0 || r.TTL < ttl {
+			ttl = r.TTL
+		}
+	}
+	return ttl
+}
+
+func typeName(t dnsmessage.Type) string {
+	return strings.TrimPrefix(t.String(), "Type")
+}
+
+func firstErr(errs map[string]error) error {
+	for _, t := range queryTypes {
+		if err, ok := errs[typeName(t)]; ok {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func testZone() zone {
+	z := zone{
+		"kaspi.kz": {
+			{typ: dnsmessage.TypeA, value: "194.187.247.10", ttl: 3600},
+			{typ: dnsmessage.TypeMX, value: "mx.kaspi.kz", ttl: 3600},
+			{typ: dnsmessage.TypeNS, value: "ns1.kaspi.kz", ttl: 86400},
+			{typ: dnsmessage.TypeTXT, value: "v=spf1 -all", ttl: 3600},
+		},
+		"no-mail.kz": {
+			{typ: dnsmessage.TypeA, value: "10.1.1.1", ttl: 3600},
+			{typ: dnsmessage.TypeNS, value: "ns1.no-mail.kz", ttl: 3600},
+		},
+		"parked.kz": {
+			{typ: dnsmessage.TypeMX, value: "mx.parked.kz", ttl: 3600},
+		},
+		"bonus.xyz": {
+			{typ: dnsmessage.TypeA, value: "10.0.0.1", ttl: 3600},
+			{typ: dnsmessage.TypeMX, value: "mx.bonus.xyz", ttl: 3600},
+			{typ: dnsmessage.TypeNS, value: "ns1.afraid.org", ttl: 3600},
+			{typ: dnsmessage.TypeNS, value: "ns2.afraid.org", ttl: 3600},
+		},
+		"*.wild.kz": {
+			{typ: dnsmessage.TypeA, value: "10.0.0.2", ttl: 3600},
+		},
+		"wild.kz": {
+			{typ: dnsmessage.TypeA, value: "10.0.0.2", ttl: 3600},
+			{typ: dnsmessage.TypeMX, value: "mx.wild.kz", ttl: 3600},
+		},
+	}
+
+	var flux []testRecord
+	for i := 1; i <= 6; i++ {
+		flux = append(flux, testRecord{typ: dnsmessage.TypeA, value: fmt.Sprintf("10.0.1.%d", i), ttl: 60})
+	}
+	z["flux.top"] = append(flux, testRecord{typ: dnsmessage.TypeMX, value: "mx.flux.top", ttl: 60})
+
+	return z
+}
+
+func TestClientLookup(t *testing.T) {
+	client := NewClient(startServer(t, testZone()), time.Second)
+
+	records, err := client.Lookup(context.Background(), "kaspi.kz", dnsmessage.TypeMX)
+	if err != nil {
+		t.Fatalf("Lookup() unexpected error: %v", err)
+	}
+	if len(records) != 1 || records[0] != (Record{Type: "MX", Value: "10 mx.kaspi.kz", TTL: 3600}) {
+		t.Errorf("Lookup() = %+v", records)
+	}
+
+	if _, err := client.Lookup(context.Background(), "missing.kz", dnsmessage.TypeA); !errors.Is(err, ErrNXDomain) {
+		t.Errorf("Lookup() error = %v; want %v", err, ErrNXDomain)
+	}
+}
+
+func TestClientSkipsStrayAnswers(t *testing.T) {
+	client := NewClient(startStrayServer(t, testZone()), time.Second)
+
+	records, err := client.Lookup(context.Background(), "kaspi.kz", dnsmessage.TypeA)
+	if err != nil {
+		t.Fatalf("Lookup() unexpected error: %v", err)
+	}
+	if len(records) != 1 || records[0].Value != "194.187.247.10" {
+		t.Errorf("Lookup() = %+v", records)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	c := New(NewClient(startServer(t, testZone()), time.Second), nil)
+
+	tests := []struct {
+		domain string
+		want   float64
+	}{
+		{domain: "kaspi.kz", want: 0},
+		{domain: "no-mail.kz", want: noMXScore},
+		{domain: "parked.kz", want: noAddressScore},
+		{domain: "bonus.xyz", want: abusedNSScore},
+		{domain: "wild.kz", want: wildcardScore},
+		{domain: "flux.top", want: fastFluxScore},
+		{domain: "missing.kz", want: nxDomainScore},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.domain, func(t *testing.T) {
+			res, err := c.Check(context.Background(), tc.domain)
+			if err != nil {
+				t.Fatalf("Check() unexpected error: %v", err)
+			}
+			if res.TotalScore != tc.want {
+				t.Errorf("TotalScore = %v; want %v, reasons: %v", res.TotalScore, tc.want, res.Reasons)
+			}
+			if tc.want > 0 && len(res.Reasons) != 1 {
+				t.Errorf("Reasons = %v; want exactly one", res.Reasons)
+			}
+		})
+	}
+}
+
+func TestCheckStoresRecords(t *testing.T) {
+	c := New(NewClient(startServer(t, testZone()), time.Second), nil)
+
+	res, err := c.Check(context.Background(), "kaspi.kz")
+	if err != nil {
+		t.Fatalf("Check() unexpected error: %v", err)
+	}
+
+	records, ok := res.Evidence["records"].(map[string][]Record)
+	if !ok {
+		t.Fatalf("records evidence = %T; want map[string][]Record", res.Evidence["records"])
+	}
+	for _, typ := range []string{"A", "MX", "NS", "TXT"} {
+		if len(records[typ]) == 0 {
+			t.Errorf("records[%s] is empty", typ)
+		}
+	}
+}
+
+func TestCheckResolverDown(t *testing.T) {
+	c := New(&failingResolver{err: errors.New("connection refused")}, nil)
+
+	if _, err := c.Check(context.Background(), "kaspi.kz"); err == nil {
+		t.Error("Check() expected error when the resolver is down, got none")
+	}
+}
+
+func TestCheckAddressQueriesFailed(t *testing.T) {
+	resolver := &partialResolver{
+		Resolver: NewClient(startServer(t, testZone()), time.Second),
+		failing:  map[dnsmessage.Type]bool{dnsmessage.TypeA: true, dnsmessage.TypeAAAA: true},
+	}
+
+	res, err := New(resolver, nil).Check(context.Background(), "kaspi.kz")
+	if err != nil {
+		t.Fatalf("Check() unexpected error: %v", err)
+	}
+	if res.TotalScore != 0 {
+		t.Errorf("TotalScore = %v; want 0 when the address queries failed, reasons: %v", res.TotalScore, res.Reasons)
+	}
+}
+
+// partialResolver fails the listed query types.
+type partialResolver struct {
+	Resolver
+	failing map[dnsmessage.Type]bool
+}
+
+func (r *partialResolver) Lookup(ctx context.Context, name string, qtype dnsmessage.Type) ([]Record, error) {
+	if r.failing[qtype] {
+		return nil, errors.New("i/o timeout")
+	}
+	return r.Resolver.Lookup(ctx, name, qtype)
+}
+
+type failingResolver struct {
+	err error
+}
+
+func (r *failingResolver) Lookup(ctx context.Context, name string, qtype dnsmessage.Type) ([]Record, error) {
+	return nil, r.err
+}
@@ -0,0 +1,50 @@
+package domainage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+)
+
+// FallbackLookup asks the lookups in order and returns the first answer.
+// A "not registered" answer is final, any other error moves on to the next
+// lookup, e.g. RDAP first and WHOIS for TLDs without an RDAP server or
+// when the RDAP server does not know the domain. An answer without the
+// creation date also moves on and is returned only when no later lookup
+// has the date.
+type FallbackLookup []RegistrationLookup
+
+func (l FallbackLookup) Lookup(ctx context.Context, domain string) (*entity.Registration, error) {
+	var (
+		partial *entity.Registration
+		errs    []error
+	)
+	for _, lookup := range l {
+		reg, err := lookup.Lookup(ctx, domain)
+		switch {
+		case err == nil && reg.Created == nil:
+			if partial == nil {
+				partial = reg
+			}
+		case err == nil:
+			return reg, nil
+		case errors.Is(err, entity.ErrNotRegistered):
+			if partial != nil {
+				// an earlier lookup did find the domain
+				return partial, nil
+			}
+			return nil, err
+		default:
+			errs = append(errs, err)
+		}
+	}
+
+	if partial != nil {
+		return partial, nil
+	}
+	if len(errs) == 0 {
+		return nil, errors.New("no registration lookups configured")
+	}
+	return nil, errors.Join(errs...)
+}
@@ -0,0 +1,47 @@
+package domainage
+
+import (
+	"time"
+
+	"github.com/ItsXomyak/scam-list/config"
+	"github.com/ItsXomyak/scam-list/internal/adapter/rdap"
+	"github.com/ItsXomyak/scam-list/internal/adapter/whois"
+	"github.com/ItsXomyak/scam-list/internal/modules/registry"
+	"github.com/ItsXomyak/scam-list/internal/services/pipeline"
+	"github.com/ItsXomyak/scam-list/pkg/safehttp"
+)
+
+const Version = "1.1.0"
+
+func init() {
+	registry.Register(registry.Module{
+		Name:    ModuleName,
+		Version: Version,
+		Weight:  3,
+		Timeout: 15 * time.Second,
+		Factory: func(cfg config.Config) (pipeline.ScamChecker, error) {
+			whoisDialer := safehttp.NewDialer(nil, cfg.Outbound.AllowCIDRs, cfg.WHOIS.Timeout)
+			whoisClient := whois.NewClient(whoisDialer, cfg.WHOIS.Servers, cfg.WHOIS.Timeout)
+			if cfg.RDAP.Disabled {
+				return New(whoisClient), nil
+			}
+
+			rdapClient := safehttp.New(safehttp.Config{
+				Timeout:   cfg.RDAP.Timeout,
+				UserAgent: cfg.Outbound.UserAgent,
+				Allow:     cfg.Outbound.AllowCIDRs,
+			})
+
+			var bootstrap rdap.BootstrapSource = rdap.NewBootstrapLoader(cfg.RDAP.BootstrapURL, rdapClient)
+			if cfg.RDAP.BootstrapFile != "" {
+				file, err := rdap.LoadBootstrapFile(cfg.RDAP.BootstrapFile)
+				if err != nil {
+					return nil, err
+				}
+				bootstrap = file
+			}
+
+			return New(FallbackLookup{rdap.NewClient(bootstrap, rdapClient, cfg.RDAP.Timeout), whoisClient}), nil
+		},
+	})
+}
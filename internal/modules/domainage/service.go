@@ -0,0 +1,172 @@
+package domainage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/utils"
+)
+
+// ModuleName is the checker name used for weights and module results.
+const ModuleName = "domainage"
+
+const (
+	// registry data is authoritative, age is our strongest signal
+	confidence = 0.9
+
+	notRegisteredScore = 50.0
+	shortTermScore     = 10.0
+	shortTerm          = 366 * 24 * time.Hour
+	day                = 24 * time.Hour
+)
+
+var ErrNoCreationDate = errors.New("registry does not publish the creation date")
+
+// ageBands score younger domains higher, the first band that fits wins.
+var ageBands = []struct {
+	maxAge time.Duration
+	score  float64
+}{
+	{maxAge: 30 * day, score: 80},
+	{maxAge: 90 * day, score: 60},
+	{maxAge: 180 * day, score: 40},
+	{maxAge: 365 * day, score: 20},
+	{maxAge: 2 * 365 * day, score: 10},
+}
+
+// privacy services hide the real owner, their names are not the company
+var privacyMarkers = []string{"privacy", "redacted", "withheld", "proxy", "protected", "not disclosed", "data protected"}
+
+// RegistrationLookup returns the registry data of a registrable domain.
+type RegistrationLookup interface {
+	Lookup(ctx context.Context, domain string) (*entity.Registration, error)
+}
+
+// Checker scores a domain by its registration age.
+type Checker struct {
+	lookup RegistrationLookup
+	now    func() time.Time
+}
+
+func New(lookup RegistrationLookup) *Checker {
+	return &Checker{lookup: lookup, now: time.Now}
+}
+
+func (c *Checker) Name() string {
+	return ModuleName
+}
+
+func (c *Checker) Info() string {
+	return "domain age and registration term from the registry"
+}
+
+func (c *Checker) Check(ctx context.Context, domain string) (*entity.CheckerResult, error) {
+	host, err := utils.ExtractDomain(domain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain %q: %w", domain, err)
+	}
+	registrable, err := utils.RegistrableDomain(host)
+	if err != nil {
+		return nil, fmt.Errorf("no registrable domain in %q: %w", host, err)
+	}
+
+	reg, err := c.lookup.Lookup(ctx, registrable)
+	switch {
+	case errors.Is(err, entity.ErrNotRegistered):
+		return &entity.CheckerResult{
+			Module:     ModuleName,
+			TotalScore: notRegisteredScore,
+			Confidence: confidence,
+			Reasons:    []string{fmt.Sprintf("%s is not registered", registrable)},
+			Evidence:   map[string]any{"registrable_domain": registrable, "registered": false},
+		}, nil
+	case err != nil:
+		return nil, err
+	case reg.Created == nil:
+		return nil, ErrNoCreationDate
+	}
+
+	return c.score(registrable, reg), nil
+}
+
+func (c *Checker) score(registrable string, reg *entity.Registration) *entity.CheckerResult {
+	age := c.now().Sub(*reg.Created)
+
+	res := &entity.CheckerResult{
+		Module:     ModuleName,
+		Confidence: confidence,
+		Evidence:   evidence(registrable, reg, age),
+	}
+
+	for _, band := range ageBands {
+		if age < band.maxAge {
+			res.TotalScore = band.score
+			res.Reasons = append(res.Reasons, fmt.Sprintf("domain was registered %d days ago", int(age/day)))
+			break
+		}
+	}
+
+	if reg.Expires != nil && age < shortTerm && reg.Expires.Sub(*reg.Created) <= shortTerm {
+		res.TotalScore += shortTermScore
+		res.Reasons = append(res.Reasons, "domain is registered for a single year")
+	}
+
+	if !isPrivacy(reg.RegistrantOrg) {
+		res.CompanyName = reg.RegistrantOrg
+		res.Country = reg.RegistrantCountry
+	}
+
+	return res
+}
+
+func evidence(registrable string, reg *entity.Registration, age time.Duration) map[string]any {
+	e := map[string]any{
+		"registrable_domain": registrable,
+		"registered":         true,
+		"source":             reg.Source,
+		"server":             reg.Server,
+		"created":            reg.Created.UTC().Format(time.RFC3339),
+		"age_days":           int(age / day),
+	}
+
+	if reg.Updated != nil {
+		e["updated"] = reg.Updated.UTC().Format(time.RFC3339)
+	}
+	if reg.Expires != nil {
+		e["expires"] = reg.Expires.UTC().Format(time.RFC3339)
+	}
+	if reg.Registrar != "" {
+		e["registrar"] = reg.Registrar
+	}
+	if reg.RegistrarIANAID != "" {
+		e["registrar_iana_id"] = reg.RegistrarIANAID
+	}
+	if len(reg.NameServers) > 0 {
+		e["name_servers"] = reg.NameServers
+	}
+	if len(reg.Statuses) > 0 {
+		e["statuses"] = reg.Statuses
+	}
+	if reg.AbuseEmail != "" {
+		e["abuse_email"] = reg.AbuseEmail
+	}
+
+	return e
+}
+
+func isPrivacy(org string) bool {
+	org = strings.ToLower(org)
+	if org == "" {
+		return true
+	}
+	for _, m := range privacyMarkers {
+		if strings.Contains(org, m) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,151 @@
+package domainage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/adapter/rdap"
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+)
+
+type fakeLookup struct {
+	reg   *entity.Registration
+	err   error
+	asked string
+}
+
+func (f *fakeLookup) Lookup(ctx context.Context, domain string) (*entity.Registration, error) {
+	f.asked = domain
+	return f.reg, f.err
+}
+
+var now = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func daysAgo(n int) *time.Time {
+	t := now.Add(-time.Duration(n) * day)
+	return &t
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		reg     *entity.Registration
+		err     error
+		want    float64
+		company string
+		wantErr bool
+	}{
+		{
+			name:    "old company domain",
+			reg:     &entity.Registration{Created: daysAgo(7600), RegistrantOrg: "Kaspi Bank JSC", RegistrantCountry: "KZ"},
+			want:    0,
+			company: "Kaspi Bank JSC",
+		},
+		{name: "one week", reg: &entity.Registration{Created: daysAgo(7)}, want: 80},
+		{name: "two months", reg: &entity.Registration{Created: daysAgo(60)}, want: 60},
+		{name: "half a year", reg: &entity.Registration{Created: daysAgo(170)}, want: 40},
+		{name: "under two years", reg: &entity.Registration{Created: daysAgo(400)}, want: 10},
+		{
+			name: "one year term",
+			reg:  &entity.Registration{Created: daysAgo(20), Expires: daysAgo(20 - 365), RegistrantOrg: "Privacy service provided by Withheld for Privacy ehf"},
+			want: 90,
+		},
+		{name: "not registered", err: entity.ErrNotRegistered, want: notRegisteredScore},
+		{name: "no creation date", reg: &entity.Registration{Registrar: "X"}, wantErr: true},
+		{name: "lookup failed", err: errors.New("connection refused"), wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			lookup := &fakeLookup{reg: tc.reg, err: tc.err}
+			c := New(lookup)
+			c.now = func() time.Time { return now }
+
+			res, err := c.Check(context.Background(), "https://login.example.kz/path")
+			if tc.wantErr {
+				if err == nil {
+					t.Fatal("Check() expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Check() unexpected error: %v", err)
+			}
+
+			if lookup.asked != "example.kz" {
+				t.Errorf("looked up %q; want the registrable domain", lookup.asked)
+			}
+			if res.TotalScore != tc.want {
+				t.Errorf("TotalScore = %v; want %v, reasons: %v", res.TotalScore, tc.want, res.Reasons)
+			}
+			if res.CompanyName != tc.company {
+				t.Errorf("CompanyName = %q; want %q", res.CompanyName, tc.company)
+			}
+		})
+	}
+}
+
+func TestFallbackLookup(t *testing.T) {
+	created := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	reg := &entity.Registration{Source: entity.RegistrationSourceWHOIS, Created: &created}
+	noDate := &entity.Registration{Source: entity.RegistrationSourceRDAP}
+	errDown := errors.New("server down")
+
+	tests := []struct {
+		name    string
+		lookups FallbackLookup
+		want    *entity.Registration
+		wantErr error
+	}{
+		{
+			name:    "first answer wins",
+			lookups: FallbackLookup{&fakeLookup{reg: reg}, &fakeLookup{err: errDown}},
+			want:    reg,
+		},
+		{
+			name:    "falls back on error",
+			lookups: FallbackLookup{&fakeLookup{err: errDown}, &fakeLookup{reg: reg}},
+			want:    reg,
+		},
+		{
+			name:    "rdap 404 falls back",
+			lookups: FallbackLookup{&fakeLookup{err: fmt.Errorf("%w: example.kz", rdap.ErrNotFound)}, &fakeLookup{reg: reg}},
+			want:    reg,
+		},
+		{
+			name:    "not registered is final",
+			lookups: FallbackLookup{&fakeLookup{err: entity.ErrNotRegistered}, &fakeLookup{reg: reg}},
+			wantErr: entity.ErrNotRegistered,
+		},
+		{
+			name:    "no creation date falls back",
+			lookups: FallbackLookup{&fakeLookup{reg: noDate}, &fakeLookup{reg: reg}},
+			want:    reg,
+		},
+		{
+			name:    "no creation date when nothing better",
+			lookups: FallbackLookup{&fakeLookup{reg: noDate}, &fakeLookup{err: entity.ErrNotRegistered}},
+			want:    noDate,
+		},
+		{
+			name:    "all failed",
+			lookups: FallbackLookup{&fakeLookup{err: errDown}, &fakeLookup{err: errDown}},
+			wantErr: errDown,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.lookups.Lookup(context.Background(), "example.kz")
+			if !errors.Is(err, tc.wantErr) || (tc.wantErr == nil && err != nil) {
+				t.Fatalf("Lookup() error = %v; want %v", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("Lookup() = %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
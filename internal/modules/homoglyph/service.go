@@ -0,0 +1,163 @@
+package homoglyph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/idna"
+	"golang.org/x/net/publicsuffix"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/internal/modules/brands"
+	"github.com/ItsXomyak/scam-list/pkg/utils"
+)
+
+// ModuleName is the checker name used for weights and module results.
+const ModuleName = "homoglyph"
+
+const (
+	brandCollisionScore = 90.0
+	mixedScriptScore    = 60.0
+	confusableScore     = 40.0
+
+	// a look-alike brand name is hard evidence, its absence is not
+	foundConfidence    = 0.9
+	notFoundConfidence = 0.2
+
+	// shorter brand labels ("t", "wb", "fb") collide with too many names
+	minBrandLabelLen = 4
+)
+
+// brandLabel is the skeleton of a protected brand domain label.
+type brandLabel struct {
+	brand    brands.Brand
+	label    string
+	skeleton string
+}
+
+// Checker looks for IDN and homoglyph impersonation of protected brands.
+type Checker struct {
+	brandLabels []brandLabel
+}
+
+func New() *Checker {
+	return &Checker{brandLabels: skeletons(brands.All())}
+}
+
+func skeletons(list []brands.Brand) []brandLabel {
+	var out []brandLabel
+	for _, b := range list {
+		for _, d := range b.Domains {
+			label := registrableLabel(d)
+			if len(label) < minBrandLabelLen {
+				continue
+			}
+			out = append(out, brandLabel{brand: b, label: label, skeleton: Skeleton(label)})
+		}
+	}
+	return out
+}
+
+func (c *Checker) Name() string {
+	return ModuleName
+}
+
+func (c *Checker) Info() string {
+	return "decodes punycode and looks for mixed scripts and look-alike characters imitating protected brands"
+}
+
+func (c *Checker) Check(ctx context.Context, domain string) (*entity.CheckerResult, error) {
+	host, err := utils.NormalizeDomain(domain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain %q: %w", domain, err)
+	}
+
+	return c.analyze(host), nil
+}
+
+func (c *Checker) analyze(host string) *entity.CheckerResult {
+	res := &entity.CheckerResult{
+		Module:     ModuleName,
+		Confidence: notFoundConfidence,
+		Evidence:   map[string]any{"host": host},
+	}
+
+	unicodeHost, err := idna.ToUnicode(host)
+	if err != nil {
+		unicodeHost = host
+	}
+	if unicodeHost != host {
+		res.Evidence["unicode_host"] = unicodeHost
+	}
+
+	registrable, err := utils.RegistrableDomain(host)
+	if err != nil {
+		registrable = host
+	}
+	if _, ok := brands.OwnedBy(registrable); ok {
+		return res
+	}
+
+	var score float64
+	for _, label := range strings.Split(unicodeHost, ".") {
+		scripts := Scripts(label)
+		skeleton := Skeleton(label)
+
+		if len(scripts) > 1 {
+			score = max(score, mixedScriptScore)
+			res.Reasons = append(res.Reasons, fmt.Sprintf("label %q mixes %s scripts", label, strings.Join(scripts, " and ")))
+			res.Evidence["scripts"] = scripts
+		} else if isNonASCII(label) && isASCII(skeleton) {
+			// a whole label of look-alikes, e.g. Cyrillic "рау"
+			score = max(score, confusableScore)
+			res.Reasons = append(res.Reasons, fmt.Sprintf("label %q is made of characters that look like %q", label, skeleton))
+		}
+
+		if bl, ok := c.collision(label, skeleton); ok {
+			score = max(score, brandCollisionScore)
+			res.Reasons = append(res.Reasons, fmt.Sprintf("label %q looks like %s domain %q", label, bl.brand.Name, bl.label))
+			res.Evidence["brand"] = bl.brand.Name
+			res.Evidence["skeleton"] = skeleton
+			res.ScamType = "phishing"
+		}
+	}
+
+	if len(res.Reasons) > 0 {
+		res.Confidence = foundConfidence
+	}
+	res.TotalScore = score
+
+	return res
+}
+
+// collision finds a brand label with the same skeleton that is not
+// the label itself: "kaspi" is the brand, "kаspі" and "kasp1" imitate it.
+func (c *Checker) collision(label, skeleton string) (brandLabel, bool) {
+	for _, bl := range c.brandLabels {
+		if bl.skeleton == skeleton && bl.label != label {
+			return bl, true
+		}
+	}
+	return brandLabel{}, false
+}
+
+// registrableLabel returns the registrable domain without its public suffix,
+// "kaspi" for "kaspi.kz".
+func registrableLabel(domain string) string {
+	suffix, _ := publicsuffix.PublicSuffix(domain)
+	return strings.TrimSuffix(strings.TrimSuffix(domain, suffix), ".")
+}
+
+func isASCII(s string) bool {
+	for _, r := range s {
+		if r > 127 {
+			return false
+		}
+	}
+	return true
+}
+
+func isNonASCII(s string) bool {
+	return s != "" && !isASCII(s)
+}
@@ -0,0 +1,89 @@
+package homoglyph
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSkeleton(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{in: "kaspi", want: "kaspl"},
+		{in: "kаspі", want: "kaspl"}, // Cyrillic а and і
+		{in: "KASPI", want: "kaspl"},
+		{in: "g00gle", want: "google"},
+		{in: "rnicrosoft", want: "mlcrosoft"},
+		{in: "pаypаl", want: "paypal"},
+		{in: "hálуk", want: "halyk"},
+		{in: "пример", want: "пpиmep"}, // п and и have no Latin look-alike
+	}
+
+	for _, tc := range tests {
+		if got := Skeleton(tc.in); got != tc.want {
+			t.Errorf("Skeleton(%q) = %q; want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestScripts(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int
+	}{
+		{in: "kaspi-2024", want: 1},
+		{in: "kаspi", want: 2},
+		{in: "пример", want: 1},
+		{in: "123", want: 0},
+	}
+
+	for _, tc := range tests {
+		if got := Scripts(tc.in); len(got) != tc.want {
+			t.Errorf("Scripts(%q) = %v; want %d scripts", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		domain   string
+		want     float64
+		scamType string
+		brand    string
+	}{
+		{domain: "kaspi.kz", want: 0},
+		{domain: "example.com", want: 0},
+		{domain: "пример.рф", want: 0},
+		{domain: "xn--e1afmkfd.xn--p1ai", want: 0},
+		{domain: "kаspi.kz", want: brandCollisionScore, scamType: "phishing", brand: "Kaspi"},
+		{domain: "xn--kspi-53d.kz", want: brandCollisionScore, scamType: "phishing", brand: "Kaspi"},
+		{domain: "https://pаypаl.com/login", want: brandCollisionScore, scamType: "phishing", brand: "PayPal"},
+		{domain: "goog1e.com", want: brandCollisionScore, scamType: "phishing", brand: "Google"},
+		{domain: "login.kaspl.xyz", want: brandCollisionScore, scamType: "phishing", brand: "Kaspi"},
+		{domain: "shоp.kz", want: mixedScriptScore}, // Cyrillic о, no brand
+		{domain: "рау.com", want: confusableScore},  // all Cyrillic
+	}
+
+	c := New()
+	for _, tc := range tests {
+		t.Run(tc.domain, func(t *testing.T) {
+			res, err := c.Check(context.Background(), tc.domain)
+			if err != nil {
+				t.Fatalf("Check() unexpected error: %v", err)
+			}
+
+			if res.TotalScore != tc.want {
+				t.Errorf("TotalScore = %v; want %v, reasons: %v", res.TotalScore, tc.want, res.Reasons)
+			}
+			if res.ScamType != tc.scamType {
+				t.Errorf("ScamType = %q; want %q", res.ScamType, tc.scamType)
+			}
+			if brand, _ := res.Evidence["brand"].(string); brand != tc.brand {
+				t.Errorf("brand = %q; want %q", brand, tc.brand)
+			}
+			if tc.want > 0 && res.Confidence != foundConfidence {
+				t.Errorf("Confidence = %v; want %v", res.Confidence, foundConfidence)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package homoglyph
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// confusables maps characters to the ASCII letters they look like.
+// It is the part of the Unicode confusables table that matters for
+// Latin domain names: Cyrillic, Greek and Armenian look-alikes plus
+// ASCII digit tricks.
+var confusables = map[rune]string{
+	// Cyrillic
+	'а': "a", 'в': "b", 'е': "e", 'ё': "e", 'һ': "h", 'і': "i", 'ї': "i", 'ј': "j",
+	'к': "k", 'м': "m", 'н': "h", 'о': "o", 'р': "p", 'с': "c", 'т': "t", 'у': "y",
+	'х': "x", 'ѕ': "s", 'ԁ': "d", 'ԛ': "q", 'ԝ': "w", 'ү': "y", 'ӏ': "l",
+	// Greek
+	'α': "a", 'β': "b", 'ε': "e", 'η': "n", 'ι': "i", 'κ': "k", 'ν': "v", 'ο': "o",
+	'ρ': "p", 'τ': "t", 'υ': "u", 'χ': "x", 'ϲ': "c", 'ω': "w",
+	// Armenian
+	'օ': "o", 'ս': "u", 'ց': "g", 'հ': "h", 'ո': "n",
+	// Latin
+	'ɡ': "g", 'ı': "i", 'ɩ': "i",
+	// ASCII
+	'0': "o", '1': "l", '|': "l",
+}
+
+// multi-character look-alikes applied after the single characters
+var sequences = strings.NewReplacer("rn", "m", "vv", "w", "cl", "d")
+
+// Skeleton maps s to the string it looks like, so two names with the
+// same skeleton are visually confusable. "kаspі" (Cyrillic а and і)
+// and "kaspi" have the same skeleton.
+func Skeleton(s string) string {
+	var b strings.Builder
+	for _, r := range norm.NFKD.String(strings.ToLower(s)) {
+		if unicode.Is(unicode.Mn, r) {
+			// drop accents: "á" looks like "a"
+			continue
+		}
+		if c, ok := confusables[r]; ok {
+			b.WriteString(c)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	// "i" and "l" are confusable in most fonts
+	return strings.ReplaceAll(sequences.Replace(b.String()), "i", "l")
+}
+
+// Scripts returns the scripts used in s. Digits, hyphens and other
+// characters shared by all scripts are ignored.
+func Scripts(s string) []string {
+	var scripts []string
+	seen := make(map[string]struct{})
+	for _, r := range s {
+		name := scriptOf(r)
+		if name == "" {
+			continue
+		}
+		if _, ok := seen[name]; ok {
+			continue
+		}
+		seen[name] = struct{}{}
+		scripts = append(scripts, name)
+	}
+	return scripts
+}
+
+var scriptTables = []struct {
+	name  string
+	table *unicode.RangeTable
+}{
+	{"Latin", unicode.Latin},
+	{"Cyrillic", unicode.Cyrillic},
+	{"Greek", unicode.Greek},
+	{"Armenian", unicode.Armenian},
+	{"Georgian", unicode.Georgian},
+	{"Hebrew", unicode.Hebrew},
+	{"Arabic", unicode.Arabic},
+	{"Han", unicode.Han},
+	{"Hiragana", unicode.Hiragana},
+	{"Katakana", unicode.Katakana},
+	{"Hangul", unicode.Hangul},
+	{"Thai", unicode.Thai},
+}
+
+func scriptOf(r rune) string {
+	for _, s := range scriptTables {
+		if unicode.Is(s.table, r) {
+			return s.name
+		}
+	}
+	if unicode.IsLetter(r) {
+		return "Other"
+	}
+	return ""
+}
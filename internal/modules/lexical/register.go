@@ -0,0 +1,23 @@
+package lexical
+
+import (
+	"time"
+
+	"github.com/ItsXomyak/scam-list/config"
+	"github.com/ItsXomyak/scam-list/internal/modules/registry"
+	"github.com/ItsXomyak/scam-list/internal/services/pipeline"
+)
+
+const Version = "1.0.0"
+
+func init() {
+	registry.Register(registry.Module{
+		Name:    ModuleName,
+		Version: Version,
+		Weight:  1,
+		Timeout: time.Second,
+		Factory: func(config.Config) (pipeline.ScamChecker, error) {
+			return New(), nil
+		},
+	})
+}
@@ -0,0 +1,235 @@
+package lexical
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/publicsuffix"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/internal/modules/brands"
+	"github.com/ItsXomyak/scam-list/pkg/utils"
+)
+
+// ModuleName is the checker name used for weights and module results.
+const ModuleName = "lexical"
+
+const (
+	// the name alone says little, so this module only nudges the score
+	confidence = 0.4
+
+	ipHostScore      = 40.0
+	brandScore       = 35.0
+	tldScore         = 20.0
+	subdomainScore   = 15.0
+	entropyScore     = 15.0
+	digitsScore      = 10.0
+	hyphensScore     = 10.0
+	keywordScore     = 10.0
+	maxKeywordsScore = 30.0
+
+	minEntropyLen    = 10
+	maxEntropy       = 3.5
+	maxDigitRatio    = 0.3
+	maxHyphens       = 2
+	maxSubdomainDeep = 3
+)
+
+var (
+	riskyKeywords = []string{
+		"login", "signin", "secure", "verify", "account", "update", "confirm",
+		"bonus", "promo", "prize", "gift", "wallet", "support", "payment", "refund",
+		"kaspi-", "gov-",
+	}
+
+	suspiciousTLDs = map[string]struct{}{
+		"xyz": {}, "top": {}, "click": {}, "icu": {}, "buzz": {}, "tk": {}, "ml": {},
+		"ga": {}, "cf": {}, "gq": {}, "work": {}, "loan": {}, "rest": {}, "fun": {},
+		"monster": {}, "cyou": {}, "sbs": {}, "cfd": {}, "online": {}, "site": {},
+	}
+)
+
+// Checker scores a hostname by its text only, without network calls.
+type Checker struct{}
+
+func New() *Checker {
+	return &Checker{}
+}
+
+func (c *Checker) Name() string {
+	return ModuleName
+}
+
+func (c *Checker) Info() string {
+	return "offline heuristics on the domain name: entropy, digits, hyphens, keywords, TLD and brand names"
+}
+
+func (c *Checker) Check(ctx context.Context, domain string) (*entity.CheckerResult, error) {
+	host, err := utils.ExtractDomain(domain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain %q: %w", domain, err)
+	}
+
+	return analyze(host), nil
+}
+
+// analysis collects triggered rules.
+type analysis struct {
+	score    float64
+	reasons  []string
+	rules    []string
+	scamType string
+}
+
+func (a *analysis) add(rule string, score float64, reason string) {
+	a.score += score
+	a.rules = append(a.rules, rule)
+	a.reasons = append(a.reasons, reason)
+}
+
+func analyze(host string) *entity.CheckerResult {
+	res := &entity.CheckerResult{
+		Module:     ModuleName,
+		Confidence: confidence,
+		Evidence:   map[string]any{"host": host},
+	}
+
+	if net.ParseIP(host) != nil {
+		res.TotalScore = ipHostScore
+		res.Reasons = []string{"hostname is a bare IP address"}
+		res.Evidence["rules"] = []string{"ip_host"}
+		return res
+	}
+
+	registrable, err := utils.RegistrableDomain(host)
+	if err != nil {
+		registrable = host
+	}
+	suffix, _ := publicsuffix.PublicSuffix(host)
+	label := strings.TrimSuffix(strings.TrimSuffix(registrable, suffix), ".")
+	subdomains := strings.TrimSuffix(strings.TrimSuffix(host, registrable), ".")
+
+	res.Evidence["registrable_domain"] = registrable
+	res.Evidence["public_suffix"] = suffix
+
+	// text of a real brand domain is fine whatever it contains
+	if owner, ok := brands.OwnedBy(registrable); ok {
+		res.Reasons = []string{fmt.Sprintf("registrable domain %s belongs to %s", registrable, owner.Name)}
+		res.Evidence["brand"] = owner.Name
+		return res
+	}
+
+	a := &analysis{}
+
+	// words joined with hyphens are not random, so only the longest part is measured
+	if part := longestPart(label); len(part) >= minEntropyLen && entropy(part) >= maxEntropy {
+		e := entropy(part)
+		a.add("entropy", entropyScore, fmt.Sprintf("domain name part %q looks random (entropy %.2f)", part, e))
+		res.Evidence["entropy"] = math.Round(e*100) / 100
+	}
+
+	if digits := countFunc(label, unicode.IsDigit); len(label) > 0 && float64(digits)/float64(len(label)) >= maxDigitRatio {
+		a.add("digits", digitsScore, fmt.Sprintf("domain name %q is %d%% digits", label, digits*100/len(label)))
+	}
+
+	if hyphens := strings.Count(label, "-"); hyphens >= maxHyphens {
+		a.add("hyphens", hyphensScore, fmt.Sprintf("domain name %q has %d hyphens", label, hyphens))
+	}
+
+	if deep := subdomainDepth(subdomains); deep >= maxSubdomainDeep {
+		a.add("subdomains", subdomainScore, fmt.Sprintf("hostname has a chain of %d subdomains", deep))
+	}
+
+	if found := keywords(host); len(found) > 0 {
+		a.add("keywords", math.Min(keywordScore*float64(len(found)), maxKeywordsScore),
+			fmt.Sprintf("hostname contains risky keywords: %s", strings.Join(found, ", ")))
+		res.Evidence["keywords"] = found
+	}
+
+	tld := suffix[strings.LastIndex(suffix, ".")+1:]
+	if _, ok := suspiciousTLDs[tld]; ok {
+		a.add("tld", tldScore, fmt.Sprintf("TLD .%s is popular with scam sites", tld))
+	}
+
+	for _, b := range brands.All() {
+		if kw, ok := b.Match(host); ok {
+			a.add("brand", brandScore, fmt.Sprintf("hostname mentions %s (%q) but %s is not a %s domain", b.Name, kw, registrable, b.Name))
+			a.scamType = "phishing"
+			res.Evidence["brand"] = b.Name
+			break
+		}
+	}
+
+	res.TotalScore = math.Min(a.score, 100)
+	res.Reasons = a.reasons
+	res.ScamType = a.scamType
+	if len(a.rules) > 0 {
+		res.Evidence["rules"] = a.rules
+	}
+
+	return res
+}
+
+// entropy is the Shannon entropy of s in bits per character.
+func entropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+
+	var e float64
+	n := float64(len([]rune(s)))
+	for _, c := range counts {
+		p := float64(c) / n
+		e -= p * math.Log2(p)
+	}
+	return e
+}
+
+func longestPart(label string) string {
+	longest := ""
+	for _, part := range strings.Split(label, "-") {
+		if len(part) > len(longest) {
+			longest = part
+		}
+	}
+	return longest
+}
+
+func countFunc(s string, f func(rune) bool) int {
+	n := 0
+	for _, r := range s {
+		if f(r) {
+			n++
+		}
+	}
+	return n
+}
+
+// subdomainDepth counts subdomain labels, a leading www does not count.
+func subdomainDepth(subdomains string) int {
+	subdomains = strings.TrimPrefix(subdomains, "www")
+	subdomains = strings.TrimPrefix(subdomains, ".")
+	if subdomains == "" {
+		return 0
+	}
+	return strings.Count(subdomains, ".") + 1
+}
+
+func keywords(host string) []string {
+	var found []string
+	for _, kw := range riskyKeywords {
+		if strings.Contains(host, kw) {
+			found = append(found, kw)
+		}
+	}
+	return found
+}
@@ -0,0 +1,97 @@
+package lexical
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		domain    string
+		wantRules []string
+		minScore  float64
+		maxScore  float64
+		scamType  string
+	}{
+		{domain: "kaspi.kz", maxScore: 0},
+		{domain: "https://login.kaspi.kz/auth", maxScore: 0},
+		{domain: "example.com", maxScore: 0},
+		{domain: "www.google.com", maxScore: 0},
+		{
+			domain:    "kaspi.kz.secure-login.xyz",
+			wantRules: []string{"keywords", "tld", "brand"},
+			minScore:  70, maxScore: 80,
+			scamType: "phishing",
+		},
+		{
+			domain:    "kaspi-bonus-2024.top",
+			wantRules: []string{"hyphens", "keywords", "tld", "brand"},
+			minScore:  80, maxScore: 100,
+			scamType: "phishing",
+		},
+		{domain: "gov-kz-payment.com", wantRules: []string{"hyphens", "keywords"}, minScore: 30, maxScore: 30},
+		{domain: "a.b.c.d.example.com", wantRules: []string{"subdomains"}, minScore: 15, maxScore: 15},
+		{domain: "xk7qz9vbw2lmp.com", wantRules: []string{"entropy"}, minScore: 15, maxScore: 15},
+		{domain: "88005553535.kz", wantRules: []string{"digits"}, minScore: 10, maxScore: 10},
+		{domain: "olx-kz.delivery.com", wantRules: []string{"brand"}, minScore: 35, maxScore: 35, scamType: "phishing"},
+		{domain: "obcc.kz", maxScore: 0},
+		{domain: "snapple.com", maxScore: 0},
+		{domain: "pianoforte.com", maxScore: 0},
+		{domain: "fonts.googleapis.com", maxScore: 0},
+		{domain: "lh3.googleusercontent.com", maxScore: 0},
+		{domain: "login.microsoftonline.com", maxScore: 0},
+		{domain: "mmg.whatsapp.net", maxScore: 0},
+		{domain: "telegram.me", maxScore: 0},
+		{domain: "my-apple.id-check.com", wantRules: []string{"brand"}, minScore: 35, maxScore: 35, scamType: "phishing"},
+		{domain: "http://10.0.0.1/login", wantRules: []string{"ip_host"}, minScore: 40, maxScore: 40},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.domain, func(t *testing.T) {
+			res, err := New().Check(context.Background(), tc.domain)
+			if err != nil {
+				t.Fatalf("Check() unexpected error: %v", err)
+			}
+
+			if res.TotalScore < tc.minScore || res.TotalScore > tc.maxScore {
+				t.Errorf("TotalScore = %v; want between %v and %v, reasons: %v", res.TotalScore, tc.minScore, tc.maxScore, res.Reasons)
+			}
+			if res.ScamType != tc.scamType {
+				t.Errorf("ScamType = %q; want %q", res.ScamType, tc.scamType)
+			}
+
+			rules, _ := res.Evidence["rules"].([]string)
+			if strings.Join(rules, ",") != strings.Join(tc.wantRules, ",") {
+				t.Errorf("rules = %v; want %v", rules, tc.wantRules)
+			}
+			if len(rules) != 0 && len(res.Reasons) != len(rules) {
+				t.Errorf("Reasons = %v; want one per rule", res.Reasons)
+			}
+		})
+	}
+}
+
+func TestCheckInvalidDomain(t *testing.T) {
+	if _, err := New().Check(context.Background(), "  "); err == nil {
+		t.Error("Check() expected error for an empty domain, got none")
+	}
+}
+
+func TestEntropy(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+	}{
+		{in: "", want: 0},
+		{in: "aaaa", want: 0},
+		{in: "ab", want: 1},
+		{in: "abcd", want: 2},
+	}
+
+	for _, tc := range tests {
+		if got := entropy(tc.in); got != tc.want {
+			t.Errorf("entropy(%q) = %v; want %v", tc.in, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/config"
+	"github.com/ItsXomyak/scam-list/internal/services/pipeline"
+)
+
+var (
+	ErrDuplicateModule = errors.New("module already registered")
+	ErrUnknownModule   = errors.New("unknown module")
+)
+
+// Factory builds a checker from the application config.
+type Factory func(cfg config.Config) (pipeline.ScamChecker, error)
+
+// Module describes a checker module and its defaults.
+type Module struct {
+	Name    string
+	Version string
+	Weight  float64       // default weight in the risk score
+	Timeout time.Duration // default deadline, 0 means the pipeline default
+	Factory Factory
+}
+
+// ActiveModule is a module enabled by the config, with its effective settings.
+type ActiveModule struct {
+	Name    string
+	Version string
+	Weight  float64
+	Timeout time.Duration
+	Checker pipeline.ScamChecker
+}
+
+// Registry keeps all known checker modules.
+type Registry struct {
+	mu      sync.RWMutex
+	modules map[string]Module
+}
+
+var defaultRegistry = New()
+
+func New() *Registry {
+	return &Registry{modules: make(map[string]Module)}
+}
+
+// Default returns the registry modules add themselves to in init().
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// Register adds a module to the default registry. It panics on a duplicate
+// or incomplete module, so it should only be called from init().
+func Register(m Module) {
+	if err := defaultRegistry.Register(m); err != nil {
+		panic(err)
+	}
+}
+
+func (r *Registry) Register(m Module) error {
+	if m.Name == "" || m.Factory == nil {
+		return fmt.Errorf("module %q must have a name and a factory", m.Name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.modules[m.Name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateModule, m.Name)
+	}
+	r.modules[m.Name] = m
+
+	return nil
+}
+
+// Modules returns all registered modules sorted by name.
+func (r *Registry) Modules() []Module {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]Module, 0, len(r.modules))
+	for _, m := range r.modules {
+		out = append(out, m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+
+	return out
+}
+
+// Build creates checkers for the modules enabled in cfg.Checkers.
+// An empty enabled list means every registered module. Weights from
+// cfg.Scoring and timeouts from cfg.Checkers override module defaults.
+func (r *Registry) Build(cfg config.Config) ([]*ActiveModule, error) {
+	modules := r.Modules()
+
+	known := make(map[string]struct{}, len(modules))
+	for _, m := range modules {
+		known[m.Name] = struct{}{}
+	}
+
+	enabled, err := nameSet(cfg.Checkers.Enabled, known)
+	if err != nil {
+		return nil, fmt.Errorf("CHECKERS_ENABLED: %w", err)
+	}
+	disabled, err := nameSet(cfg.Checkers.Disabled, known)
+	if err != nil {
+		return nil, fmt.Errorf("CHECKERS_DISABLED: %w", err)
+	}
+
+	var active []*ActiveModule
+	for _, m := range modules {
+		if _, ok := enabled[m.Name]; len(enabled) > 0 && !ok {
+			continue
+		}
+		if _, ok := disabled[m.Name]; ok {
+			continue
+		}
+
+		checker, err := m.Factory(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build module %s: %w", m.Name, err)
+		}
+
+		am := &ActiveModule{
+			Name:    m.Name,
+			Version: m.Version,
+			Weight:  m.Weight,
+			Timeout: m.Timeout,
+		}
+		if w, ok := cfg.Scoring.Weights[m.Name]; ok {
+			am.Weight = w
+		}
+		if tc, ok := checker.(pipeline.TimeoutChecker); ok && tc.Timeout() > 0 {
+			am.Timeout = tc.Timeout()
+		}
+		if t, ok := cfg.Checkers.Timeouts[m.Name]; ok && t > 0 {
+			am.Timeout = t
+		}
+		am.Checker = &moduleChecker{ScamChecker: checker, timeout: am.Timeout}
+
+		active = append(active, am)
+	}
+
+	return active, nil
+}
+
+// Checkers returns the checkers of the active modules.
+func Checkers(active []*ActiveModule) []pipeline.ScamChecker {
+	out := make([]pipeline.ScamChecker, 0, len(active))
+	for _, m := range active {
+		out = append(out, m.Checker)
+	}
+	return out
+}
+
+// Weights returns the risk score weights of the active modules.
+func Weights(active []*ActiveModule) map[string]float64 {
+	out := make(map[string]float64, len(active))
+	for _, m := range active {
+		out[m.Name] = m.Weight
+	}
+	return out
+}
+
+// moduleChecker applies the effective module timeout to a checker.
+type moduleChecker struct {
+	pipeline.ScamChecker
+	timeout time.Duration
+}
+
+func (c *moduleChecker) Timeout() time.Duration {
+	return c.timeout
+}
+
+func nameSet(names []string, known map[string]struct{}) (map[string]struct{}, error) {
+	set := make(map[string]struct{}, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if _, ok := known[name]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownModule, name)
+		}
+		set[name] = struct{}{}
+	}
+	return set, nil
+}
@@ -0,0 +1,165 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/config"
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/internal/services/pipeline"
+)
+
+type stubChecker struct {
+	name string
+}
+
+func (c *stubChecker) Check(ctx context.Context, domain string) (*entity.CheckerResult, error) {
+	return &entity.CheckerResult{Module: c.name}, nil
+}
+
+func (c *stubChecker) Name() string { return c.name }
+func (c *stubChecker) Info() string { return "stub " + c.name }
+
+func stubModule(name string, weight float64, timeout time.Duration) Module {
+	return Module{
+		Name:    name,
+		Version: "0.1.0",
+		Weight:  weight,
+		Timeout: timeout,
+		Factory: func(config.Config) (pipeline.ScamChecker, error) {
+			return &stubChecker{name: name}, nil
+		},
+	}
+}
+
+func newTestRegistry(t *testing.T) *Registry {
+	t.Helper()
+
+	r := New()
+	for _, m := range []Module{
+		stubModule("dns", 1, 5*time.Second),
+		stubModule("lexical", 2, 0),
+		stubModule("scamdetector", 3, 40*time.Second),
+	} {
+		if err := r.Register(m); err != nil {
+			t.Fatalf("Register(%s) unexpected error: %v", m.Name, err)
+		}
+	}
+	return r
+}
+
+func names(active []*ActiveModule) []string {
+	out := make([]string, 0, len(active))
+	for _, m := range active {
+		out = append(out, m.Name)
+	}
+	return out
+}
+
+func TestRegisterDuplicate(t *testing.T) {
+	r := newTestRegistry(t)
+
+	err := r.Register(stubModule("dns", 1, 0))
+	if !errors.Is(err, ErrDuplicateModule) {
+		t.Errorf("Register() error = %v; want %v", err, ErrDuplicateModule)
+	}
+}
+
+func TestBuildSelection(t *testing.T) {
+	tests := []struct {
+		name     string
+		enabled  []string
+		disabled []string
+		want     []string
+		wantErr  error
+	}{
+		{name: "all by default", want: []string{"dns", "lexical", "scamdetector"}},
+		{name: "enabled subset", enabled: []string{"scamdetector", " lexical"}, want: []string{"lexical", "scamdetector"}},
+		{name: "disabled", disabled: []string{"dns"}, want: []string{"lexical", "scamdetector"}},
+		{name: "disabled wins", enabled: []string{"dns", "lexical"}, disabled: []string{"lexical"}, want: []string{"dns"}},
+		{name: "nothing left", disabled: []string{"dns", "lexical", "scamdetector"}, want: []string{}},
+		{name: "unknown enabled", enabled: []string{"whois"}, wantErr: ErrUnknownModule},
+		{name: "unknown disabled", disabled: []string{"whois"}, wantErr: ErrUnknownModule},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := config.Config{}
+			cfg.Checkers.Enabled = tc.enabled
+			cfg.Checkers.Disabled = tc.disabled
+
+			active, err := newTestRegistry(t).Build(cfg)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("Build() error = %v; want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Build() unexpected error: %v", err)
+			}
+
+			got := names(active)
+			if len(got) != len(tc.want) {
+				t.Fatalf("Build() modules = %v; want %v", got, tc.want)
+			}
+			for i := range got {
+				if got[i] != tc.want[i] {
+					t.Errorf("Build() modules = %v; want %v", got, tc.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestBuildOverrides(t *testing.T) {
+	cfg := config.Config{}
+	cfg.Scoring.Weights = map[string]float64{"dns": 0.5}
+	cfg.Checkers.Timeouts = map[string]time.Duration{"scamdetector": time.Minute}
+
+	active, err := newTestRegistry(t).Build(cfg)
+	if err != nil {
+		t.Fatalf("Build() unexpected error: %v", err)
+	}
+
+	weights := Weights(active)
+	wantWeights := map[string]float64{"dns": 0.5, "lexical": 2, "scamdetector": 3}
+	for name, want := range wantWeights {
+		if weights[name] != want {
+			t.Errorf("weight of %s = %v; want %v", name, weights[name], want)
+		}
+	}
+
+	wantTimeouts := map[string]time.Duration{"dns": 5 * time.Second, "lexical": 0, "scamdetector": time.Minute}
+	for _, m := range active {
+		if m.Timeout != wantTimeouts[m.Name] {
+			t.Errorf("timeout of %s = %v; want %v", m.Name, m.Timeout, wantTimeouts[m.Name])
+		}
+
+		tc, ok := m.Checker.(pipeline.TimeoutChecker)
+		if !ok {
+			t.Fatalf("checker of %s does not expose its timeout", m.Name)
+		}
+		if tc.Timeout() != m.Timeout {
+			t.Errorf("checker timeout of %s = %v; want %v", m.Name, tc.Timeout(), m.Timeout)
+		}
+	}
+}
+
+func TestBuildFactoryError(t *testing.T) {
+	r := New()
+	boom := errors.New("boom")
+	if err := r.Register(Module{
+		Name:    "broken",
+		Factory: func(config.Config) (pipeline.ScamChecker, error) { return nil, boom },
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := r.Build(config.Config{}); !errors.Is(err, boom) {
+		t.Errorf("Build() error = %v; want %v", err, boom)
+	}
+}
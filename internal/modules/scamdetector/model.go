@@ -1,5 +1,98 @@
 package scamdetector
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ParseResponse is the body returned by the scamcheck-parser /parse-domain endpoint.
+type ParseResponse struct {
+	TechnicalAnalysis map[string]Panel `json:"technicalAnalysis"`
+	Summary           Summary          `json:"summary"`
+}
+
+// Summary is the main block of the scam-detector.com review page.
+type Summary struct {
+	TotalPercent    string `json:"totalPercent"`
+	DomainAge       string `json:"domainAge"`
+	DomainDate      string `json:"domainDate"`
+	BlackList       string `json:"blackList"`
+	HttpsConnection string `json:"httpsConnection"`
+	SiteDescription string `json:"siteDescription"`
+}
+
+// Panel is one accordion panel of the "Technical Analysis" block.
+// The parser returns either null, an object of "label: value" pairs
+// or a list of plain text lines, depending on the page markup.
+type Panel struct {
+	Fields map[string]string
+	Lines  []string
+}
+
+func (p *Panel) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+
+	switch data[0] {
+	case '{':
+		var obj map[string]any
+		if err := json.Unmarshal(data, &obj); err != nil {
+			return err
+		}
+		p.Fields = make(map[string]string, len(obj))
+		for k, v := range obj {
+			p.Fields[k] = stringify(v)
+		}
+	case '[':
+		var items []any
+		if err := json.Unmarshal(data, &items); err != nil {
+			return err
+		}
+		for _, item := range items {
+			if obj, ok := item.(map[string]any); ok {
+				if p.Fields == nil {
+					p.Fields = make(map[string]string, len(obj))
+				}
+				for k, v := range obj {
+					p.Fields[k] = stringify(v)
+				}
+				continue
+			}
+			p.Lines = append(p.Lines, stringify(item))
+		}
+	default:
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("unexpected panel value: %s", data)
+		}
+		p.Lines = []string{s}
+	}
+
+	return nil
+}
+
+// String joins all panel values into a single line.
+func (p Panel) String() string {
+	keys := make([]string, 0, len(p.Fields))
+	for k := range p.Fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(p.Fields)+len(p.Lines))
+	for _, k := range keys {
+		parts = append(parts, k+": "+p.Fields[k])
+	}
+	parts = append(parts, p.Lines...)
+	return strings.Join(parts, "; ")
+}
+
+// ScamDetectorRes is a flat view of ParseResponse.
 type ScamDetectorRes struct {
 	// ===== Technical Analysis =====
 	DomainAge string `json:"Domain age"`
@@ -32,3 +125,53 @@ type ScamDetectorRes struct {
 	HttpsConnection string `json:"httpsConnection"`
 	SiteDescription string `json:"siteDescription"`
 }
+
+// Flatten converts the nested parser response into ScamDetectorRes.
+func (r *ParseResponse) Flatten() *ScamDetectorRes {
+	ta := r.TechnicalAnalysis
+	website := ta["Website Data"].Fields
+	registrar := ta["Registrar"].Fields
+
+	return &ScamDetectorRes{
+		DomainAge: ta["Key Facts"].Fields["Domain age"],
+
+		CompanyData: ta["Company Data"].String(),
+		Website:     website["Website"],
+		SSLValid:    website["SSL certificate valid"],
+		SSLIssuer:   website["SSL issuer"],
+		WHOISReg:    website["WHOIS registration date"],
+		WHOISUpd:    website["WHOIS last update date"],
+		WHOISRenew:  website["WHOIS renew date"],
+
+		Owner:            ta["Owner"].String(),
+		Administrator:    ta["Administrator"].String(),
+		TechnicalContact: ta["Technical Contact"].String(),
+
+		RegistrarName:    registrar["Name"],
+		RegistrarIanaID:  registrar["IANA ID"],
+		RegistrarWebsite: registrar["Register website"],
+		RegistrarEmail:   registrar["E-mail"],
+		RegistrarPhone:   registrar["Phone"],
+
+		ServerName: ta["Server Name"].Lines,
+
+		TotalPercent:    r.Summary.TotalPercent,
+		DomainAgeSum:    r.Summary.DomainAge,
+		DomainDate:      r.Summary.DomainDate,
+		BlackList:       r.Summary.BlackList,
+		HttpsConnection: r.Summary.HttpsConnection,
+		SiteDescription: r.Summary.SiteDescription,
+	}
+}
+
+func stringify(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	default:
+		b, _ := json.Marshal(x)
+		return string(b)
+	}
+}
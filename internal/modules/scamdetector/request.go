@@ -1,10 +1,73 @@
 package scamdetector
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
 )
 
-func Request(ctx context.Context, domain string) {
-	http.Get("https://www.scam-detector.com/validator/youtube-com-review")
-}
\ No newline at end of file
+const (
+	parseDomainPath = "/parse-domain"
+	maxResponseSize = 1 << 20 // 1 MiB
+)
+
+type parseDomainRequest struct {
+	Domain string `json:"domain"`
+}
+
+type parseDomainError struct {
+	Error   string `json:"error"`
+	Details string `json:"details"`
+}
+
+// request asks the scamcheck-parser service to scrape the review page of the domain.
+func (c *Checker) request(ctx context.Context, domain string) (*ParseResponse, error) {
+	body, err := json.Marshal(parseDomainRequest{Domain: reviewSlug(domain)})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+parseDomainPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call scamcheck-parser: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scamcheck-parser response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var e parseDomainError
+		if json.Unmarshal(raw, &e) == nil && e.Error != "" {
+			if e.Details != "" {
+				return nil, fmt.Errorf("scamcheck-parser returned %d: %s: %s", resp.StatusCode, e.Error, e.Details)
+			}
+			return nil, fmt.Errorf("scamcheck-parser returned %d: %s", resp.StatusCode, e.Error)
+		}
+		return nil, fmt.Errorf("scamcheck-parser returned %d", resp.StatusCode)
+	}
+
+	var res ParseResponse
+	if err := json.Unmarshal(raw, &res); err != nil {
+		return nil, fmt.Errorf("failed to decode scamcheck-parser response: %w", err)
+	}
+
+	return &res, nil
+}
+
+// reviewSlug converts "vk.com" into "vk-com" as used in scam-detector.com review URLs.
+func reviewSlug(domain string) string {
+	return strings.ReplaceAll(strings.ToLower(domain), ".", "-")
+}
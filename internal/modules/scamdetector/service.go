@@ -1 +1,151 @@
-package scamdetector
\ No newline at end of file
+package scamdetector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+)
+
+// ModuleName is the checker name used for weights and module results.
+const ModuleName = "scamdetector"
+
+const (
+	// scraped third-party verdict, so we never trust it completely
+	confidence = 0.8
+	// blacklisted domains are at least this risky whatever the trust score says
+	blacklistedMinRisk = 90.0
+
+	scamDetectorSource = "scam-detector.com"
+)
+
+var (
+	ErrNoTrustScore = errors.New("scam-detector returned no trust score")
+
+	trustRe = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*/\s*(\d+(?:\.\d+)?)`)
+)
+
+// Checker is a pipeline.ScamChecker backed by the scamcheck-parser service.
+type Checker struct {
+	baseURL string
+	client  *http.Client
+	timeout time.Duration
+}
+
+// New creates a checker that calls scamcheck-parser at baseURL, e.g. "http://localhost:4000".
+func New(baseURL string, timeout time.Duration) *Checker {
+	return &Checker{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  &http.Client{Timeout: timeout},
+		timeout: timeout,
+	}
+}
+
+func (c *Checker) Name() string {
+	return ModuleName
+}
+
+// Timeout is longer than the pipeline default because the parser drives a real browser.
+func (c *Checker) Timeout() time.Duration {
+	return c.timeout
+}
+
+func (c *Checker) Info() string {
+	return "scam-detector.com trust score scraped by scamcheck-parser"
+}
+
+func (c *Checker) Check(ctx context.Context, domain string) (*entity.CheckerResult, error) {
+	res, err := c.request(ctx, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	return score(res.Flatten())
+}
+
+// score turns the scam-detector trust percent into a risk score.
+func score(r *ScamDetectorRes) (*entity.CheckerResult, error) {
+	trust, ok := parseTrust(r.TotalPercent)
+	if !ok {
+		return nil, ErrNoTrustScore
+	}
+
+	res := &entity.CheckerResult{
+		Module:     ModuleName,
+		TotalScore: 100 - trust,
+		Confidence: confidence,
+		Reasons:    []string{fmt.Sprintf("scam-detector.com trust score is %s", strings.TrimSpace(r.TotalPercent))},
+		Evidence:   evidence(r, trust),
+	}
+
+	if isBlacklisted(r.BlackList) {
+		res.TotalScore = math.Max(res.TotalScore, blacklistedMinRisk)
+		res.Reasons = append(res.Reasons, "scam-detector.com: "+strings.TrimSpace(r.BlackList))
+		res.ScamSources = []string{scamDetectorSource}
+	}
+	if r.HttpsConnection != "" && !strings.Contains(strings.ToLower(r.HttpsConnection), "valid https") {
+		res.Reasons = append(res.Reasons, "scam-detector.com: "+strings.TrimSpace(r.HttpsConnection))
+	}
+
+	return res, nil
+}
+
+// evidence keeps the scraped values we may need to explain the verdict later.
+func evidence(r *ScamDetectorRes, trust float64) map[string]any {
+	e := map[string]any{
+		"trust_score": trust,
+	}
+
+	fields := map[string]string{
+		"domain_age":              r.DomainAge,
+		"whois_registration_date": r.WHOISReg,
+		"whois_renew_date":        r.WHOISRenew,
+		"ssl_issuer":              r.SSLIssuer,
+		"ssl_valid_until":         r.SSLValid,
+		"registrar":               r.RegistrarName,
+		"registrar_iana_id":       r.RegistrarIanaID,
+		"blacklist":               r.BlackList,
+		"https":                   r.HttpsConnection,
+	}
+	for k, v := range fields {
+		if v != "" {
+			e[k] = v
+		}
+	}
+	if len(r.ServerName) > 0 {
+		e["name_servers"] = r.ServerName
+	}
+
+	return e
+}
+
+// parseTrust parses "87/100" into a 0-100 trust value.
+func parseTrust(s string) (float64, bool) {
+	m := trustRe.FindStringSubmatch(s)
+	if m == nil {
+		return 0, false
+	}
+
+	value, err := strconv.ParseFloat(m[1], 64)
+	if err != nil {
+		return 0, false
+	}
+	total, err := strconv.ParseFloat(m[2], 64)
+	if err != nil || total <= 0 {
+		return 0, false
+	}
+
+	return math.Max(0, math.Min(100, value/total*100)), true
+}
+
+func isBlacklisted(s string) bool {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return s != "" && !strings.Contains(s, "not detected")
+}
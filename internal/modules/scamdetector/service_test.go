@@ -0,0 +1,169 @@
+package scamdetector
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const parserFixtures = "../../../pkg/scamcheck-parser/responses/response_*.json"
+
+// newParserStub serves the given file for every /parse-domain request
+// and records the requested slug.
+func newParserStub(t *testing.T, status int, fixture string, gotSlug *string) *httptest.Server {
+	t.Helper()
+
+	body := []byte(`{"error":"Parsing failed","details":"timeout"}`)
+	if fixture != "" {
+		var err error
+		body, err = os.ReadFile(fixture)
+		if err != nil {
+			t.Fatalf("failed to read fixture %s: %v", fixture, err)
+		}
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != parseDomainPath {
+			http.NotFound(w, r)
+			return
+		}
+
+		var req parseDomainRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if gotSlug != nil {
+			*gotSlug = req.Domain
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func TestCheckerSavedResponses(t *testing.T) {
+	fixtures, err := filepath.Glob(parserFixtures)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixtures) == 0 {
+		t.Fatalf("no fixtures found by %s", parserFixtures)
+	}
+
+	for _, fixture := range fixtures {
+		t.Run(filepath.Base(fixture), func(t *testing.T) {
+			var slug string
+			srv := newParserStub(t, http.StatusOK, fixture, &slug)
+
+			res, err := New(srv.URL, 5*time.Second).Check(context.Background(), "vk.com")
+			if err != nil {
+				t.Fatalf("Check() unexpected error: %v", err)
+			}
+			if slug != "vk-com" {
+				t.Errorf("requested slug = %q; want %q", slug, "vk-com")
+			}
+			if res.Module != ModuleName {
+				t.Errorf("Module = %q; want %q", res.Module, ModuleName)
+			}
+			if res.TotalScore != 0 {
+				t.Errorf("TotalScore = %v; want 0 for a 100/100 trust score", res.TotalScore)
+			}
+			if res.Confidence != confidence {
+				t.Errorf("Confidence = %v; want %v", res.Confidence, confidence)
+			}
+		})
+	}
+}
+
+func TestCheckerBlacklisted(t *testing.T) {
+	srv := newParserStub(t, http.StatusOK, "testdata/low_trust.json", nil)
+
+	res, err := New(srv.URL, 5*time.Second).Check(context.Background(), "kaspi-bonus.xyz")
+	if err != nil {
+		t.Fatalf("Check() unexpected error: %v", err)
+	}
+	if res.TotalScore != blacklistedMinRisk {
+		t.Errorf("TotalScore = %v; want %v", res.TotalScore, blacklistedMinRisk)
+	}
+	if len(res.Reasons) != 2 {
+		t.Errorf("Reasons = %v; want trust score and blacklist", res.Reasons)
+	}
+	if len(res.ScamSources) != 1 || res.ScamSources[0] != scamDetectorSource {
+		t.Errorf("ScamSources = %v; want [%s]", res.ScamSources, scamDetectorSource)
+	}
+	if res.Evidence["registrar_iana_id"] != "1068" || res.Evidence["trust_score"] != 23.0 {
+		t.Errorf("Evidence = %v", res.Evidence)
+	}
+}
+
+func TestCheckerParserError(t *testing.T) {
+	srv := newParserStub(t, http.StatusInternalServerError, "", nil)
+
+	if _, err := New(srv.URL, 5*time.Second).Check(context.Background(), "vk.com"); err == nil {
+		t.Error("Check() expected error for a failed parse, got none")
+	}
+}
+
+func TestParseResponseFlatten(t *testing.T) {
+	raw, err := os.ReadFile("testdata/low_trust.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var resp ParseResponse
+	if err := json.Unmarshal(raw, &resp); err != nil {
+		t.Fatalf("Unmarshal() unexpected error: %v", err)
+	}
+	got := resp.Flatten()
+
+	checks := []struct {
+		field, got, want string
+	}{
+		{"DomainAge", got.DomainAge, "2 months"},
+		{"CompanyData", got.CompanyData, "No company data found"},
+		{"Owner", got.Owner, "Country: IS; Organization: Privacy service provided by Withheld for Privacy ehf"},
+		{"SSLIssuer", got.SSLIssuer, "Let's Encrypt"},
+		{"RegistrarIanaID", got.RegistrarIanaID, "1068"},
+		{"TotalPercent", got.TotalPercent, "23/100"},
+	}
+	for _, c := range checks {
+		if c.got != c.want {
+			t.Errorf("%s = %q; want %q", c.field, c.got, c.want)
+		}
+	}
+	if len(got.ServerName) != 2 {
+		t.Errorf("ServerName = %v; want 2 entries", got.ServerName)
+	}
+}
+
+func TestParseTrust(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   float64
+		wantOk bool
+	}{
+		{in: "100/100", want: 100, wantOk: true},
+		{in: "23 / 100", want: 23, wantOk: true},
+		{in: "4/5", want: 80, wantOk: true},
+		{in: "", wantOk: false},
+		{in: "n/a", wantOk: false},
+		{in: "5/0", wantOk: false},
+	}
+
+	for _, tc := range tests {
+		got, ok := parseTrust(tc.in)
+		if ok != tc.wantOk || got != tc.want {
+			t.Errorf("parseTrust(%q) = %v, %v; want %v, %v", tc.in, got, ok, tc.want, tc.wantOk)
+		}
+	}
+}
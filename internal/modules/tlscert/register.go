@@ -0,0 +1,24 @@
+package tlscert
+
+import (
+	"time"
+
+	"github.com/ItsXomyak/scam-list/config"
+	"github.com/ItsXomyak/scam-list/internal/modules/registry"
+	"github.com/ItsXomyak/scam-list/internal/services/pipeline"
+	"github.com/ItsXomyak/scam-list/pkg/safehttp"
+)
+
+const Version = "1.0.0"
+
+func init() {
+	registry.Register(registry.Module{
+		Name:    ModuleName,
+		Version: Version,
+		Weight:  1,
+		Timeout: 10 * time.Second,
+		Factory: func(cfg config.Config) (pipeline.ScamChecker, error) {
+			return New(safehttp.NewDialer(nil, cfg.Outbound.AllowCIDRs, 0), nil), nil
+		},
+	})
+}
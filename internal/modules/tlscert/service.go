@@ -0,0 +1,202 @@
+package tlscert
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/internal/modules/brands"
+	"github.com/ItsXomyak/scam-list/pkg/safehttp"
+	"github.com/ItsXomyak/scam-list/pkg/utils"
+)
+
+// ModuleName is the checker name used for weights and module results.
+const ModuleName = "tlscert"
+
+const (
+	confidence = 0.6
+
+	noTLSScore       = 30.0
+	invalidScore     = 40.0
+	newCertScore     = 25.0
+	brandSANScore    = 40.0
+	newCertMaxAge    = 7 * 24 * time.Hour
+	defaultHTTPSPort = "443"
+)
+
+// Dialer opens the TCP connection for the handshake.
+type Dialer interface {
+	DialContext(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// Checker does a TLS handshake with the domain and inspects its certificate.
+type Checker struct {
+	dialer Dialer
+	roots  *x509.CertPool // nil means the system roots
+	now    func() time.Time
+}
+
+// New creates a checker. A nil dialer is a safehttp.Dialer, nil roots use the system pool.
+func New(dialer Dialer, roots *x509.CertPool) *Checker {
+	if dialer == nil {
+		dialer = safehttp.NewDialer(nil, nil, 0)
+	}
+	return &Checker{dialer: dialer, roots: roots, now: time.Now}
+}
+
+func (c *Checker) Name() string {
+	return ModuleName
+}
+
+func (c *Checker) Info() string {
+	return "TLS certificate of the domain: chain validity, age and SANs"
+}
+
+func (c *Checker) Check(ctx context.Context, domain string) (*entity.CheckerResult, error) {
+	host, err := utils.ExtractDomain(domain)
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain %q: %w", domain, err)
+	}
+
+	conn, err := c.dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, defaultHTTPSPort))
+	if err != nil {
+		// unreachable says nothing about the certificate
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	res := &entity.CheckerResult{
+		Module:     ModuleName,
+		Confidence: confidence,
+		Evidence:   map[string]any{"host": host},
+	}
+
+	// verification is done below to report why the chain is invalid
+	tlsConn := tls.Client(conn, &tls.Config{ServerName: host, InsecureSkipVerify: true})
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		res.TotalScore = noTLSScore
+		res.Reasons = []string{"TLS handshake failed, the site has no working HTTPS"}
+		res.Evidence["handshake_error"] = err.Error()
+		return res, nil
+	}
+
+	state := tlsConn.ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		res.TotalScore = noTLSScore
+		res.Reasons = []string{"server sent no certificate"}
+		return res, nil
+	}
+
+	c.inspect(res, host, state)
+	return res, nil
+}
+
+func (c *Checker) inspect(res *entity.CheckerResult, host string, state tls.ConnectionState) {
+	leaf := state.PeerCertificates[0]
+	now := c.now()
+	age := now.Sub(leaf.NotBefore)
+
+	res.Evidence["issuer"] = leaf.Issuer.String()
+	res.Evidence["subject"] = leaf.Subject.String()
+	res.Evidence["sans"] = leaf.DNSNames
+	res.Evidence["not_before"] = leaf.NotBefore.UTC().Format(time.RFC3339)
+	res.Evidence["not_after"] = leaf.NotAfter.UTC().Format(time.RFC3339)
+	res.Evidence["age_days"] = int(age.Hours() / 24)
+	res.Evidence["tls_version"] = tls.VersionName(state.Version)
+
+	var score float64
+	add := func(s float64, reason string) {
+		score += s
+		res.Reasons = append(res.Reasons, reason)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	_, err := leaf.Verify(x509.VerifyOptions{
+		DNSName:       host,
+		Roots:         c.roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+	})
+	res.Evidence["chain_valid"] = err == nil
+	if err != nil {
+		res.Evidence["chain_error"] = err.Error()
+		add(invalidScore, "certificate is not valid: "+verifyReason(err))
+	}
+
+	if age >= 0 && age < newCertMaxAge {
+		add(newCertScore, fmt.Sprintf("certificate was issued %s ago", roundAge(age)))
+	}
+
+	if found := c.brandSANs(host, leaf.DNSNames); len(found) > 0 {
+		add(brandSANScore, fmt.Sprintf("certificate also covers brand names: %s", strings.Join(found, ", ")))
+		res.Evidence["brand_sans"] = found
+		res.ScamType = "phishing"
+	}
+
+	res.TotalScore = min(score, 100)
+}
+
+// brandSANs returns SANs of other domains that mention a protected brand
+// the domain does not belong to. Names under the host's own registrable
+// domain are left to the lexical checker.
+func (c *Checker) brandSANs(host string, sans []string) []string {
+	hostRegistrable, _ := utils.RegistrableDomain(host)
+
+	var found []string
+	for _, san := range sans {
+		name := strings.TrimPrefix(strings.ToLower(san), "*.")
+		registrable, err := utils.RegistrableDomain(name)
+		if err != nil || registrable == hostRegistrable {
+			continue
+		}
+
+		for _, b := range brands.All() {
+			if _, ok := b.Match(name); !ok {
+				continue
+			}
+			if b.Owns(hostRegistrable) {
+				// the brand's own certificate covers its other domains
+				continue
+			}
+			found = append(found, san)
+			break
+		}
+	}
+	return found
+}
+
+func verifyReason(err error) string {
+	switch e := err.(type) {
+	case x509.UnknownAuthorityError:
+		return "issued by an unknown or self-signed authority"
+	case x509.HostnameError:
+		return "issued for another host"
+	case x509.CertificateInvalidError:
+		if e.Reason == x509.Expired {
+			return "expired or not yet valid"
+		}
+	}
+	return err.Error()
+}
+
+func roundAge(d time.Duration) string {
+	if d < 24*time.Hour {
+		return d.Round(time.Hour).String()
+	}
+	return fmt.Sprintf("%d days", int(d.Hours()/24))
+}
@@ -0,0 +1,188 @@
+package tlscert
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fixedDialer sends every connection to the test server.
+type fixedDialer struct {
+	addr string
+}
+
+func (d *fixedDialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	var nd net.Dialer
+	return nd.DialContext(ctx, network, d.addr)
+}
+
+func newTLSServer(t *testing.T, cert *tls.Certificate) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	if cert != nil {
+		srv.TLS = &tls.Config{Certificates: []tls.Certificate{*cert}}
+	}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// issueCert creates a self-signed certificate for the given names.
+func issueCert(t *testing.T, notBefore time.Time, names ...string) (*tls.Certificate, *x509.Certificate) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: names[0]},
+		Issuer:                pkix.Name{CommonName: "Test CA"},
+		DNSNames:              names,
+		NotBefore:             notBefore,
+		NotAfter:              notBefore.Add(90 * 24 * time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}, leaf
+}
+
+func TestCheck(t *testing.T) {
+	srv := newTLSServer(t, nil)
+	trusted := x509.NewCertPool()
+	trusted.AddCert(srv.Certificate())
+
+	fresh, freshLeaf := issueCert(t, time.Now().Add(-2*time.Hour), "bonus-shop.xyz", "kaspi-login.xyz", "halyk-bonus.top")
+	freshSrv := newTLSServer(t, fresh)
+	freshRoots := x509.NewCertPool()
+	freshRoots.AddCert(freshLeaf)
+
+	tests := []struct {
+		name      string
+		addr      string
+		roots     *x509.CertPool
+		domain    string
+		want      float64
+		wantValid bool
+	}{
+		{name: "valid", addr: srv.Listener.Addr().String(), roots: trusted, domain: "example.com", want: 0, wantValid: true},
+		{name: "unknown authority", addr: srv.Listener.Addr().String(), roots: x509.NewCertPool(), domain: "example.com", want: invalidScore},
+		{name: "other host", addr: srv.Listener.Addr().String(), roots: trusted, domain: "kaspi.kz", want: invalidScore},
+		{name: "fresh with brands", addr: freshSrv.Listener.Addr().String(), roots: freshRoots, domain: "bonus-shop.xyz", want: newCertScore + brandSANScore, wantValid: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			c := New(&fixedDialer{addr: tc.addr}, tc.roots)
+
+			res, err := c.Check(context.Background(), tc.domain)
+			if err != nil {
+				t.Fatalf("Check() unexpected error: %v", err)
+			}
+			if res.TotalScore != tc.want {
+				t.Errorf("TotalScore = %v; want %v, reasons: %v", res.TotalScore, tc.want, res.Reasons)
+			}
+			if valid, _ := res.Evidence["chain_valid"].(bool); valid != tc.wantValid {
+				t.Errorf("chain_valid = %v; want %v, error: %v", valid, tc.wantValid, res.Evidence["chain_error"])
+			}
+			for _, key := range []string{"issuer", "sans", "not_before", "not_after", "age_days"} {
+				if _, ok := res.Evidence[key]; !ok {
+					t.Errorf("evidence %q is missing", key)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckBrandSANs(t *testing.T) {
+	tests := []struct {
+		name   string
+		domain string
+		sans   []string
+		want   []string
+	}{
+		{name: "other brand domain", domain: "bonus-shop.xyz", sans: []string{"bonus-shop.xyz", "kaspi-login.xyz"}, want: []string{"kaspi-login.xyz"}},
+		{name: "brand certificate", domain: "google.com", sans: []string{"google.com", "*.googleapis.cn", "google-analytics.com", "*.google.kz"}},
+		{name: "own names only", domain: "kaspi-bonus.xyz", sans: []string{"kaspi-bonus.xyz", "www.kaspi-bonus.xyz", "kaspi.kaspi-bonus.xyz"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fresh, leaf := issueCert(t, time.Now().Add(-2*time.Hour), tc.sans...)
+			srv := newTLSServer(t, fresh)
+			roots := x509.NewCertPool()
+			roots.AddCert(leaf)
+
+			res, err := New(&fixedDialer{addr: srv.Listener.Addr().String()}, roots).Check(context.Background(), tc.domain)
+			if err != nil {
+				t.Fatalf("Check() unexpected error: %v", err)
+			}
+
+			found, _ := res.Evidence["brand_sans"].([]string)
+			if !reflect.DeepEqual(found, tc.want) {
+				t.Errorf("brand_sans = %v; want %v", found, tc.want)
+			}
+			wantType := ""
+			if len(tc.want) > 0 {
+				wantType = "phishing"
+			}
+			if res.ScamType != wantType {
+				t.Errorf("ScamType = %q; want %q", res.ScamType, wantType)
+			}
+		})
+	}
+}
+
+func TestCheckNoTLS(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	t.Cleanup(srv.Close)
+
+	res, err := New(&fixedDialer{addr: srv.Listener.Addr().String()}, nil).Check(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("Check() unexpected error: %v", err)
+	}
+	if res.TotalScore != noTLSScore {
+		t.Errorf("TotalScore = %v; want %v", res.TotalScore, noTLSScore)
+	}
+}
+
+func TestCheckUnreachable(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+
+	_, err = New(&fixedDialer{addr: addr}, nil).Check(context.Background(), "example.com")
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		t.Errorf("Check() error = %v; want a dial error", err)
+	}
+}
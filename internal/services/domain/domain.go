@@ -2,6 +2,9 @@ package domain
 
 import (
 	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
 
 	"github.com/ItsXomyak/scam-list/internal/domain/entity"
 )
@@ -11,21 +14,79 @@ type DomainRepository interface {
 	GetDomain(ctx context.Context, domain string) (*entity.Domain, error)
 	GetAllDomains(ctx context.Context) ([]*entity.Domain, error)
 	UpdateDomain(ctx context.Context, updated *entity.Domain) (*entity.Domain, error)
+	UpsertDomain(ctx context.Context, arg *entity.UpsertDomainParams) (*entity.Domain, error)
 	DeleteDomain(ctx context.Context, domain string) error
 }
 
+// Moderation is told about every saved verdict, it queues suspicious
+// domains for review.
+type Moderation interface {
+	Track(ctx context.Context, d *entity.Domain, checkID string)
+}
+
 type DomainService struct {
-	repo DomainRepository
+	repo       DomainRepository
+	moderation Moderation
 }
 
-func NewDomainService(repo DomainRepository) *DomainService {
-	return &DomainService{repo: repo}
+// NewDomainService creates the service. With a nil moderation suspicious
+// domains are not queued for review.
+func NewDomainService(repo DomainRepository, moderation Moderation) *DomainService {
+	return &DomainService{
+		repo:       repo,
+		moderation: moderation,
+	}
 }
 
 func (s *DomainService) CreateDomain(ctx context.Context, params *entity.CreateDomainParams) (*entity.Domain, error) {
-	return s.repo.CreateDomain(ctx, params)
+	d, err := s.repo.CreateDomain(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	s.track(ctx, d, "")
+	return d, nil
 }
 
+func (s *DomainService) GetAllDomains(ctx context.Context) ([]*entity.Domain, error) {
+	return s.repo.GetAllDomains(ctx)
+}
+
+// GetDomain returns entity.ErrDomainNotFound if the domain is not in the list.
 func (s *DomainService) GetDomain(ctx context.Context, domain string) (*entity.Domain, error) {
-	return s.repo.GetDomain(ctx, domain)
+	d, err := s.repo.GetDomain(ctx, domain)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrDomainNotFound
+	}
+	return d, err
+}
+
+// UpsertDomain saves a verdict, keeping manual moderator verdicts
+// unless params.OverwriteManual is set.
+func (s *DomainService) UpsertDomain(ctx context.Context, params *entity.UpsertDomainParams) (*entity.Domain, error) {
+	d, err := s.repo.UpsertDomain(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	s.track(ctx, d, params.CheckID)
+	return d, nil
+}
+
+func (s *DomainService) UpdateDomain(ctx context.Context, updated *entity.Domain) (*entity.Domain, error) {
+	d, err := s.repo.UpdateDomain(ctx, updated)
+	if err != nil {
+		return nil, err
+	}
+	s.track(ctx, d, "")
+	return d, nil
+}
+
+// DeleteDomain removes the domain, its moderation task goes with it.
+func (s *DomainService) DeleteDomain(ctx context.Context, domain string) error {
+	return s.repo.DeleteDomain(ctx, domain)
+}
+
+func (s *DomainService) track(ctx context.Context, d *entity.Domain, checkID string) {
+	if s.moderation != nil {
+		s.moderation.Track(ctx, d, checkID)
+	}
 }
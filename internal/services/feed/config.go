@@ -0,0 +1,120 @@
+package feed
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+)
+
+const (
+	defaultInterval  = 6 * time.Hour
+	defaultRiskScore = 90.0
+	minInterval      = time.Minute
+	maxNameLen       = 100
+)
+
+var ErrInvalidFeed = errors.New("invalid feed config")
+
+// fileConfig is the FEEDS_FILE format:
+//
+//	{"feeds": [{"name": "openphish", "format": "openphish",
+//	  "source": "https://openphish.com/feed.txt", "interval": "6h",
+//	  "removal": "remove", "remove_after": "72h"}]}
+type fileConfig struct {
+	Feeds []struct {
+		Name        string   `json:"name"`
+		Format      string   `json:"format"`
+		Source      string   `json:"source"`
+		Interval    string   `json:"interval"`
+		ScamType    string   `json:"scam_type"`
+		RiskScore   *float64 `json:"risk_score"`
+		Removal     string   `json:"removal"`
+		RemoveAfter string   `json:"remove_after"`
+	} `json:"feeds"`
+}
+
+// LoadFile reads and validates the feeds config file.
+func LoadFile(path string) ([]*entity.Feed, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feeds file: %w", err)
+	}
+	return ParseConfig(raw)
+}
+
+// ParseConfig reads the feeds config and fills in defaults: a 6h interval,
+// risk score 90 and immediate removal of entries that leave the feed.
+func ParseConfig(raw []byte) ([]*entity.Feed, error) {
+	var cfg fileConfig
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFeed, err)
+	}
+
+	feeds := make([]*entity.Feed, 0, len(cfg.Feeds))
+	names := make(map[string]struct{}, len(cfg.Feeds))
+	for i, f := range cfg.Feeds {
+		feed := &entity.Feed{
+			Name:      f.Name,
+			Format:    f.Format,
+			Source:    f.Source,
+			Interval:  defaultInterval,
+			ScamType:  f.ScamType,
+			RiskScore: defaultRiskScore,
+			Removal:   f.Removal,
+		}
+		if feed.Removal == "" {
+			feed.Removal = entity.FeedRemovalRemove
+		}
+		if f.RiskScore != nil {
+			feed.RiskScore = *f.RiskScore
+		}
+
+		var err error
+		if f.Interval != "" {
+			if feed.Interval, err = time.ParseDuration(f.Interval); err != nil {
+				return nil, fmt.Errorf("%w: feed %d interval: %v", ErrInvalidFeed, i, err)
+			}
+		}
+		if f.RemoveAfter != "" {
+			if feed.RemoveAfter, err = time.ParseDuration(f.RemoveAfter); err != nil {
+				return nil, fmt.Errorf("%w: feed %d remove_after: %v", ErrInvalidFeed, i, err)
+			}
+		}
+
+		if err := validate(feed); err != nil {
+			return nil, fmt.Errorf("%w: feed %d %q: %v", ErrInvalidFeed, i, feed.Name, err)
+		}
+		if _, dup := names[feed.Name]; dup {
+			return nil, fmt.Errorf("%w: duplicate feed name %q", ErrInvalidFeed, feed.Name)
+		}
+		names[feed.Name] = struct{}{}
+
+		feeds = append(feeds, feed)
+	}
+
+	return feeds, nil
+}
+
+func validate(f *entity.Feed) error {
+	switch {
+	case f.Name == "" || len(f.Name) > maxNameLen:
+		return fmt.Errorf("name must be 1 to %d characters", maxNameLen)
+	case f.Source == "":
+		return errors.New("source is required")
+	case f.Interval < minInterval:
+		return fmt.Errorf("interval must be at least %s", minInterval)
+	case f.RiskScore < 0 || f.RiskScore > 100:
+		return errors.New("risk_score must be between 0 and 100")
+	case f.RemoveAfter < 0:
+		return errors.New("remove_after must not be negative")
+	case f.Removal != entity.FeedRemovalRemove && f.Removal != entity.FeedRemovalKeep:
+		return fmt.Errorf("removal must be %q or %q", entity.FeedRemovalRemove, entity.FeedRemovalKeep)
+	}
+
+	_, err := ParserFor(f.Format)
+	return err
+}
@@ -0,0 +1,60 @@
+package feed
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+)
+
+func TestParseConfigDefaults(t *testing.T) {
+	feeds, err := ParseConfig([]byte(`{"feeds": [
+		{"name": "openphish", "format": "openphish", "source": "https://openphish.com/feed.txt"},
+		{"name": "local", "format": "hosts", "source": "/etc/scam/hosts", "interval": "30m",
+		 "risk_score": 70, "removal": "keep", "remove_after": "72h", "scam_type": "fraud"}
+	]}`))
+	if err != nil {
+		t.Fatalf("ParseConfig() unexpected error: %v", err)
+	}
+	if len(feeds) != 2 {
+		t.Fatalf("got %d feeds; want 2", len(feeds))
+	}
+
+	op := feeds[0]
+	if op.Interval != defaultInterval || op.RiskScore != defaultRiskScore || op.Removal != entity.FeedRemovalRemove || op.RemoveAfter != 0 {
+		t.Errorf("defaults = %+v", op)
+	}
+
+	local := feeds[1]
+	if local.Interval != 30*time.Minute || local.RiskScore != 70 || local.Removal != entity.FeedRemovalKeep ||
+		local.RemoveAfter != 72*time.Hour || local.ScamType != "fraud" {
+		t.Errorf("explicit values = %+v", local)
+	}
+}
+
+func TestParseConfigInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+	}{
+		{name: "broken json", raw: `{"feeds": [`},
+		{name: "no name", raw: `{"feeds": [{"format": "plain", "source": "a.txt"}]}`},
+		{name: "no source", raw: `{"feeds": [{"name": "a", "format": "plain"}]}`},
+		{name: "unknown format", raw: `{"feeds": [{"name": "a", "format": "rss", "source": "a.txt"}]}`},
+		{name: "short interval", raw: `{"feeds": [{"name": "a", "format": "plain", "source": "a.txt", "interval": "10s"}]}`},
+		{name: "bad interval", raw: `{"feeds": [{"name": "a", "format": "plain", "source": "a.txt", "interval": "daily"}]}`},
+		{name: "risk over 100", raw: `{"feeds": [{"name": "a", "format": "plain", "source": "a.txt", "risk_score": 120}]}`},
+		{name: "unknown removal", raw: `{"feeds": [{"name": "a", "format": "plain", "source": "a.txt", "removal": "never"}]}`},
+		{name: "duplicate name", raw: `{"feeds": [{"name": "a", "format": "plain", "source": "a.txt"}, {"name": "a", "format": "hosts", "source": "b.txt"}]}`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseConfig([]byte(tc.raw))
+			if !errors.Is(err, ErrInvalidFeed) {
+				t.Errorf("ParseConfig() error = %v; want ErrInvalidFeed", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+	"github.com/ItsXomyak/scam-list/pkg/utils"
+)
+
+// IndexSource returns the domains a feed currently lists.
+type IndexSource interface {
+	FeedDomains(ctx context.Context, feed string) ([]string, error)
+}
+
+// Index is an in-memory set of all feed entries. Lookups read an immutable
+// snapshot without locks, refreshes build a new one and swap it in.
+type Index struct {
+	mu   sync.Mutex // serializes writers
+	snap atomic.Pointer[indexSnapshot]
+}
+
+// indexSnapshot is never modified after it is stored.
+type indexSnapshot struct {
+	feeds map[string]*feedSet
+}
+
+// feedSet holds the domains of one feed keyed by registrable domain.
+type feedSet struct {
+	feed    *entity.Feed
+	domains map[string][]string
+	size    int
+}
+
+var defaultIndex = NewIndex()
+
+func NewIndex() *Index {
+	return &Index{}
+}
+
+// DefaultIndex returns the index shared by the feed service and the blocklist checker.
+func DefaultIndex() *Index {
+	return defaultIndex
+}
+
+// Ready reports whether the index has been loaded at least once.
+func (ix *Index) Ready() bool {
+	return ix.snap.Load() != nil
+}
+
+// Size returns the number of indexed entries over all feeds.
+func (ix *Index) Size() int {
+	snap := ix.snap.Load()
+	if snap == nil {
+		return 0
+	}
+	n := 0
+	for _, set := range snap.feeds {
+		n += set.size
+	}
+	return n
+}
+
+// Load replaces the whole index with the current entries of the given feeds.
+// The old index keeps serving lookups until the new one is complete.
+func (ix *Index) Load(ctx context.Context, src IndexSource, feeds []*entity.Feed) error {
+	sets := make(map[string]*feedSet, len(feeds))
+	for _, feed := range feeds {
+		domains, err := src.FeedDomains(ctx, feed.Name)
+		if err != nil {
+			return fmt.Errorf("failed to load feed %s: %w", feed.Name, err)
+		}
+		sets[feed.Name] = newFeedSet(feed, domains)
+	}
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.snap.Store(&indexSnapshot{feeds: sets})
+
+	return nil
+}
+
+// Reload reloads the index every interval until ctx is done. It keeps the
+// index fresh in processes that do not import feeds themselves. A failed
+// reload keeps the previous entries.
+func (ix *Index) Reload(ctx context.Context, src IndexSource, feeds []*entity.Feed, every time.Duration, log logger.Logger) {
+	ctx = logger.WithAction(ctx, "feed_index_reload")
+
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if err := ix.Load(ctx, src, feeds); err != nil && ctx.Err() == nil {
+			log.Error(logger.ErrorCtx(ctx, err), "failed to reload feed index", err)
+		}
+	}
+}
+
+// Update replaces the entries of a single feed.
+func (ix *Index) Update(feed *entity.Feed, domains []string) {
+	set := newFeedSet(feed, domains)
+
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+
+	sets := make(map[string]*feedSet)
+	if old := ix.snap.Load(); old != nil {
+		for name, s := range old.feeds {
+			sets[name] = s
+		}
+	}
+	sets[feed.Name] = set
+	ix.snap.Store(&indexSnapshot{feeds: sets})
+}
+
+// Lookup returns the feeds listing the domain or any of its parents,
+// sorted by name.
+func (ix *Index) Lookup(domain string) []*entity.Feed {
+	snap := ix.snap.Load()
+	if snap == nil {
+		return nil
+	}
+
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	reg, err := utils.RegistrableDomain(domain)
+	if err != nil {
+		return nil
+	}
+
+	var out []*entity.Feed
+	for _, set := range snap.feeds {
+		for _, listed := range set.domains[reg] {
+			if domain == listed || strings.HasSuffix(domain, "."+listed) {
+				out = append(out, set.feed)
+				break
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+
+	return out
+}
+
+func newFeedSet(feed *entity.Feed, domains []string) *feedSet {
+	set := &feedSet{feed: feed, domains: make(map[string][]string, len(domains))}
+	for _, d := range domains {
+		reg, err := utils.RegistrableDomain(d)
+		if err != nil {
+			continue
+		}
+		set.domains[reg] = append(set.domains[reg], d)
+		set.size++
+	}
+	return set
+}
@@ -0,0 +1,103 @@
+package feed
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+)
+
+type mapSource map[string][]string
+
+func (m mapSource) FeedDomains(_ context.Context, feed string) ([]string, error) {
+	return m[feed], nil
+}
+
+func feedNames(feeds []*entity.Feed) []string {
+	var names []string
+	for _, f := range feeds {
+		names = append(names, f.Name)
+	}
+	return names
+}
+
+func TestIndexLookup(t *testing.T) {
+	index := NewIndex()
+	if index.Ready() || index.Lookup("kaspi-bonus.xyz") != nil {
+		t.Fatal("a new index must be empty and not ready")
+	}
+
+	src := mapSource{
+		"openphish": {"kaspi-bonus.xyz", "login.halyk-online.top"},
+		"urlhaus":   {"kaspi-bonus.xyz", "cdn.payload-host.xyz"},
+	}
+	feeds := []*entity.Feed{{Name: "urlhaus"}, {Name: "openphish"}}
+	if err := index.Load(context.Background(), src, feeds); err != nil {
+		t.Fatalf("Load() unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		domain string
+		want   []string
+	}{
+		{domain: "kaspi-bonus.xyz", want: []string{"openphish", "urlhaus"}},
+		{domain: "pay.KASPI-BONUS.xyz.", want: []string{"openphish", "urlhaus"}},
+		{domain: "login.halyk-online.top", want: []string{"openphish"}},
+		{domain: "a.login.halyk-online.top", want: []string{"openphish"}},
+		// a listed subdomain does not list its parent or siblings
+		{domain: "halyk-online.top", want: nil},
+		{domain: "www.halyk-online.top", want: nil},
+		{domain: "xkaspi-bonus.xyz", want: nil},
+		{domain: "kaspi.kz", want: nil},
+	}
+	for _, tc := range tests {
+		got := feedNames(index.Lookup(tc.domain))
+		if len(got) != len(tc.want) || (len(got) > 0 && got[0] != tc.want[0]) || (len(got) > 1 && got[1] != tc.want[1]) {
+			t.Errorf("Lookup(%q) = %v; want %v", tc.domain, got, tc.want)
+		}
+	}
+	if index.Size() != 4 {
+		t.Errorf("Size() = %d; want 4", index.Size())
+	}
+}
+
+func TestIndexUpdate(t *testing.T) {
+	index := NewIndex()
+	index.Update(&entity.Feed{Name: "a"}, []string{"one.xyz"})
+	index.Update(&entity.Feed{Name: "b"}, []string{"two.xyz"})
+	index.Update(&entity.Feed{Name: "a"}, []string{"three.xyz"})
+
+	if got := index.Lookup("one.xyz"); got != nil {
+		t.Errorf("Lookup(one.xyz) = %v; want the entry replaced", feedNames(got))
+	}
+	if got := feedNames(index.Lookup("two.xyz")); len(got) != 1 || got[0] != "b" {
+		t.Errorf("Lookup(two.xyz) = %v; want [b]", got)
+	}
+	if got := feedNames(index.Lookup("three.xyz")); len(got) != 1 || got[0] != "a" {
+		t.Errorf("Lookup(three.xyz) = %v; want [a]", got)
+	}
+}
+
+func TestIndexConcurrentUpdates(t *testing.T) {
+	index := NewIndex()
+	names := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+
+	wg := &sync.WaitGroup{}
+	for _, name := range names {
+		wg.Add(2)
+		go func(name string) {
+			defer wg.Done()
+			index.Update(&entity.Feed{Name: name}, []string{"shared.xyz"})
+		}(name)
+		go func() {
+			defer wg.Done()
+			index.Lookup("shared.xyz")
+		}()
+	}
+	wg.Wait()
+
+	if got := index.Lookup("shared.xyz"); len(got) != len(names) {
+		t.Errorf("Lookup() found %d feeds; want %d, an update was lost", len(got), len(names))
+	}
+}
@@ -0,0 +1,399 @@
+package feed
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/internal/modules/brands"
+	"github.com/ItsXomyak/scam-list/pkg/utils"
+)
+
+const maxLineSize = 64 << 10
+
+var ErrUnknownFormat = errors.New("unknown feed format")
+
+// sharedHosts serve files and pages of many unrelated users, a bad URL on
+// them says nothing about the host itself.
+var sharedHosts = map[string]struct{}{
+	"github.com": {}, "githubusercontent.com": {}, "gitlab.com": {}, "bitbucket.org": {},
+	"googleusercontent.com": {}, "googleapis.com": {}, "discordapp.com": {}, "discordapp.net": {},
+	"discord.com": {}, "dropbox.com": {}, "dropboxusercontent.com": {}, "mediafire.com": {},
+	"sharepoint.com": {}, "1drv.ms": {}, "amazonaws.com": {}, "cloudfront.net": {},
+	"pastebin.com": {}, "telegra.ph": {}, "sourceforge.net": {}, "4shared.com": {},
+	"yadi.sk": {}, "weebly.com": {}, "wixsite.com": {}, "000webhostapp.com": {},
+	"ngrok.io": {}, "ngrok-free.app": {}, "transfer.sh": {}, "anonfiles.com": {},
+}
+
+// Parser reads the entries of a feed. Lines that are not a valid domain
+// are counted as skipped, an error means the whole feed is unusable.
+type Parser interface {
+	Parse(r io.Reader) (entries []entity.FeedEntry, skipped int, err error)
+}
+
+var parsers = map[string]Parser{
+	entity.FeedFormatHosts:     hostsParser{},
+	entity.FeedFormatAdblock:   adblockParser{},
+	entity.FeedFormatPlain:     plainParser{},
+	entity.FeedFormatCSV:       csvParser{},
+	entity.FeedFormatPhishTank: phishTankParser{},
+	entity.FeedFormatOpenPhish: openPhishParser{},
+	entity.FeedFormatURLhaus:   urlhausParser{},
+}
+
+// ParserFor returns the parser of a feed format.
+func ParserFor(format string) (Parser, error) {
+	p, ok := parsers[format]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+	return p, nil
+}
+
+// collector normalizes and dedups entries, the first entry of a domain wins.
+type collector struct {
+	entries []entity.FeedEntry
+	seen    map[string]struct{}
+	skipped int
+}
+
+func newCollector() *collector {
+	return &collector{seen: make(map[string]struct{})}
+}
+
+// add takes a domain or URL. IP addresses, local names and garbage are skipped.
+func (c *collector) add(raw, listedURL, scamType string) {
+	domain, ok := feedDomain(raw)
+	if !ok {
+		c.skipped++
+		return
+	}
+	c.put(domain, listedURL, scamType)
+}
+
+// addURL takes a URL of a URL feed, which lists a page rather than a site.
+// URLs on brand domains are skipped. On shared hosts only a user site
+// listed as a whole, i.e. the root of a subdomain, is taken.
+func (c *collector) addURL(raw, scamType string) {
+	domain, ok := feedDomain(raw)
+	if !ok {
+		c.skipped++
+		return
+	}
+
+	registrable, _ := utils.RegistrableDomain(domain)
+	if _, ok := brands.OwnedBy(registrable); ok {
+		c.skipped++
+		return
+	}
+	if host, ok := sharedHost(domain); ok && (domain == host || !siteRoot(raw)) {
+		c.skipped++
+		return
+	}
+	c.put(domain, raw, scamType)
+}
+
+// sharedHost returns the sharedHosts entry the domain is, or lives under.
+func sharedHost(domain string) (string, bool) {
+	for host := domain; ; {
+		if _, ok := sharedHosts[host]; ok {
+			return host, true
+		}
+		i := strings.IndexByte(host, '.')
+		if i < 0 {
+			return "", false
+		}
+		host = host[i+1:]
+	}
+}
+
+func (c *collector) put(domain, listedURL, scamType string) {
+	if _, dup := c.seen[domain]; dup {
+		return
+	}
+	c.seen[domain] = struct{}{}
+	c.entries = append(c.entries, entity.FeedEntry{Domain: domain, URL: listedURL, ScamType: scamType})
+}
+
+// siteRoot reports whether the URL points at the root of its site.
+func siteRoot(raw string) bool {
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return false
+	}
+	return (u.Path == "" || u.Path == "/") && u.RawQuery == "" && u.Fragment == ""
+}
+
+func feedDomain(raw string) (string, bool) {
+	domain, err := utils.NormalizeDomain(raw)
+	if err != nil || net.ParseIP(domain) != nil || len(domain) > 253 {
+		return "", false
+	}
+	if _, err := utils.RegistrableDomain(domain); err != nil {
+		return "", false
+	}
+	return domain, true
+}
+
+// lines calls fn for every non-empty line without # comments.
+func lines(r io.Reader, fn func(line string)) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 4096), maxLineSize)
+	for sc.Scan() {
+		line := sc.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			fn(line)
+		}
+	}
+	return sc.Err()
+}
+
+// hostsParser reads hosts files: "0.0.0.0 a.com b.com".
+type hostsParser struct{}
+
+var localHosts = map[string]struct{}{
+	"localhost": {}, "localhost.localdomain": {}, "local": {}, "broadcasthost": {},
+	"ip6-localhost": {}, "ip6-loopback": {}, "0.0.0.0": {},
+}
+
+func (hostsParser) Parse(r io.Reader) ([]entity.FeedEntry, int, error) {
+	c := newCollector()
+	err := lines(r, func(line string) {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || net.ParseIP(fields[0]) == nil {
+			c.skipped++
+			return
+		}
+		for _, host := range fields[1:] {
+			if _, ok := localHosts[strings.ToLower(host)]; ok {
+				continue
+			}
+			c.add(host, "", "")
+		}
+	})
+	return c.entries, c.skipped, err
+}
+
+// adblockParser reads network rules blocking a whole domain: "||a.com^".
+// Exceptions, cosmetic rules and rules with a path are skipped.
+type adblockParser struct{}
+
+func (adblockParser) Parse(r io.Reader) ([]entity.FeedEntry, int, error) {
+	c := newCollector()
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 4096), maxLineSize)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "!") || strings.HasPrefix(line, "[") {
+			continue
+		}
+
+		rule, ok := strings.CutPrefix(line, "||")
+		if !ok {
+			c.skipped++
+			continue
+		}
+		if i := strings.IndexByte(rule, '$'); i >= 0 {
+			rule = rule[:i]
+		}
+		domain, ok := strings.CutSuffix(rule, "^")
+		if !ok || strings.ContainsAny(domain, "/*") {
+			c.skipped++
+			continue
+		}
+		c.add(domain, "", "")
+	}
+	return c.entries, c.skipped, sc.Err()
+}
+
+// plainParser reads one domain or URL per line.
+type plainParser struct{}
+
+func (plainParser) Parse(r io.Reader) ([]entity.FeedEntry, int, error) {
+	c := newCollector()
+	err := lines(r, func(line string) {
+		field := strings.Fields(line)[0]
+		c.add(field, listedURL(field), "")
+	})
+	return c.entries, c.skipped, err
+}
+
+// openPhishParser reads the OpenPhish feed, a phishing URL per line.
+type openPhishParser struct{}
+
+func (openPhishParser) Parse(r io.Reader) ([]entity.FeedEntry, int, error) {
+	c := newCollector()
+	err := lines(r, func(line string) {
+		c.addURL(line, "phishing")
+	})
+	return c.entries, c.skipped, err
+}
+
+// csvParser reads a CSV with a header. The first domain, host or url
+// column is used, an optional scam_type or type column sets the type.
+type csvParser struct{}
+
+func (csvParser) Parse(r io.Reader) ([]entity.FeedEntry, int, error) {
+	cr := newCSVReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	cols := columns(header)
+	value, typeCol := -1, -1
+	for _, name := range []string{"domain", "host", "hostname", "url"} {
+		if i, ok := cols[name]; ok {
+			value = i
+			break
+		}
+	}
+	if value < 0 {
+		return nil, 0, fmt.Errorf("csv has no domain, host or url column: %v", header)
+	}
+	for _, name := range []string{"scam_type", "type", "threat"} {
+		if i, ok := cols[name]; ok {
+			typeCol = i
+			break
+		}
+	}
+
+	c := newCollector()
+	err = readRecords(cr, c, func(rec []string) {
+		if value >= len(rec) {
+			c.skipped++
+			return
+		}
+		c.add(rec[value], listedURL(rec[value]), field(rec, typeCol))
+	})
+	return c.entries, c.skipped, err
+}
+
+// phishTankParser reads online-valid.csv. Only verified entries that are
+// still online are listed.
+type phishTankParser struct{}
+
+func (phishTankParser) Parse(r io.Reader) ([]entity.FeedEntry, int, error) {
+	cr := newCSVReader(r)
+	header, err := cr.Read()
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read phishtank header: %w", err)
+	}
+
+	cols := columns(header)
+	urlCol, ok := cols["url"]
+	if !ok {
+		return nil, 0, fmt.Errorf("phishtank dump has no url column: %v", header)
+	}
+	verified, online := colIndex(cols, "verified"), colIndex(cols, "online")
+
+	c := newCollector()
+	err = readRecords(cr, c, func(rec []string) {
+		if isNo(field(rec, verified)) || isNo(field(rec, online)) {
+			return
+		}
+		c.addURL(field(rec, urlCol), "phishing")
+	})
+	return c.entries, c.skipped, err
+}
+
+// urlhausParser reads the URLhaus csv.txt dump. The header is a # comment:
+// "# id,dateadded,url,url_status,last_online,threat,tags,urlhaus_link,reporter".
+// Offline URLs are not listed.
+type urlhausParser struct{}
+
+func (urlhausParser) Parse(r io.Reader) ([]entity.FeedEntry, int, error) {
+	cr := newCSVReader(r)
+	cr.Comment = '#'
+
+	const (
+		urlCol    = 2
+		statusCol = 3
+	)
+
+	c := newCollector()
+	err := readRecords(cr, c, func(rec []string) {
+		if strings.EqualFold(field(rec, statusCol), "offline") {
+			return
+		}
+		c.addURL(field(rec, urlCol), "malware")
+	})
+	return c.entries, c.skipped, err
+}
+
+func newCSVReader(r io.Reader) *csv.Reader {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	cr.TrimLeadingSpace = true
+	cr.ReuseRecord = true
+	return cr
+}
+
+// readRecords calls fn for every record, a broken record is skipped.
+func readRecords(cr *csv.Reader, c *collector, fn func(rec []string)) error {
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			c.skipped++
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		fn(rec)
+	}
+}
+
+func columns(header []string) map[string]int {
+	cols := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := cols[name]; !ok {
+			cols[name] = i
+		}
+	}
+	return cols
+}
+
+func colIndex(cols map[string]int, name string) int {
+	if i, ok := cols[name]; ok {
+		return i
+	}
+	return -1
+}
+
+func field(rec []string, i int) string {
+	if i < 0 || i >= len(rec) {
+		return ""
+	}
+	return strings.TrimSpace(rec[i])
+}
+
+func isNo(s string) bool {
+	return strings.EqualFold(s, "no") || strings.EqualFold(s, "false")
+}
+
+// listedURL keeps the value when it is a URL rather than a bare domain.
+func listedURL(s string) string {
+	if strings.Contains(s, "://") {
+		return s
+	}
+	return ""
+}
@@ -0,0 +1,161 @@
+package feed
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+)
+
+func TestParsers(t *testing.T) {
+	tests := []struct {
+		format      string
+		fixture     string
+		wantDomains []string
+		wantSkipped int
+		wantType    string
+	}{
+		{
+			format:      entity.FeedFormatHosts,
+			fixture:     "testdata/hosts.txt",
+			wantDomains: []string{"kaspi-bonus.xyz", "halyk-promo.top", "egov-pay.online"},
+			wantSkipped: 2,
+		},
+		{
+			format:      entity.FeedFormatAdblock,
+			fixture:     "testdata/adblock.txt",
+			wantDomains: []string{"kaspi-bonus.xyz", "olx-delivery.site", "binance-airdrop.fun"},
+			wantSkipped: 4,
+		},
+		{
+			format:      entity.FeedFormatPlain,
+			fixture:     "testdata/plain.txt",
+			wantDomains: []string{"kaspi-bonus.xyz", "olx-dostavka.site", "xn----8sbcooqjmlkd.kz"},
+			wantSkipped: 2,
+		},
+		{
+			format:      entity.FeedFormatCSV,
+			fixture:     "testdata/domains.csv",
+			wantDomains: []string{"kaspi-bonus.xyz", "crypto-x2.top", "egov-pay.online"},
+			wantSkipped: 1,
+		},
+		{
+			format:      entity.FeedFormatPhishTank,
+			fixture:     "testdata/phishtank.csv",
+			wantDomains: []string{"kaspi-bonus.xyz", "secure-paypal.verify-acc.com"},
+			wantSkipped: 1,
+			wantType:    "phishing",
+		},
+		{
+			format:      entity.FeedFormatOpenPhish,
+			fixture:     "testdata/openphish.txt",
+			wantDomains: []string{"kaspi-bonus.xyz", "halyk-online.top", "kaspi-gift.weebly.com"},
+			wantSkipped: 3,
+			wantType:    "phishing",
+		},
+		{
+			format:      entity.FeedFormatURLhaus,
+			fixture:     "testdata/urlhaus.txt",
+			wantDomains: []string{"malware-drop.top", "cdn.payload-host.xyz"},
+			wantSkipped: 3,
+			wantType:    "malware",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.format, func(t *testing.T) {
+			f, err := os.Open(tc.fixture)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			p, err := ParserFor(tc.format)
+			if err != nil {
+				t.Fatalf("ParserFor(%q) unexpected error: %v", tc.format, err)
+			}
+			entries, skipped, err := p.Parse(f)
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+
+			var got []string
+			for _, e := range entries {
+				got = append(got, e.Domain)
+				if tc.wantType != "" && e.ScamType != tc.wantType {
+					t.Errorf("%s ScamType = %q; want %q", e.Domain, e.ScamType, tc.wantType)
+				}
+			}
+			if strings.Join(got, ",") != strings.Join(tc.wantDomains, ",") {
+				t.Errorf("domains = %v; want %v", got, tc.wantDomains)
+			}
+			if skipped != tc.wantSkipped {
+				t.Errorf("skipped = %d; want %d", skipped, tc.wantSkipped)
+			}
+		})
+	}
+}
+
+func TestParserKeepsListedURL(t *testing.T) {
+	p, _ := ParserFor(entity.FeedFormatPhishTank)
+	f, err := os.Open("testdata/phishtank.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	entries, _, err := p.Parse(f)
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	if want := "http://secure-paypal.verify-acc.com/signin?x=1,2"; len(entries) < 2 || entries[1].URL != want {
+		t.Errorf("entries = %+v; want second URL %q", entries, want)
+	}
+}
+
+func TestURLFeedSkipsSharedHosts(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://drive.google.com/file/d/abc/view"},
+		{url: "https://www.paypal.com/"},
+		{url: "https://raw.githubusercontent.com/a/b/main/x.ps1"},
+		{url: "https://github.com/"},
+		{url: "https://cdn.discordapp.com/attachments/1/2/a.exe"},
+		{url: "https://phish.weebly.com/?id=1"},
+		{url: "https://phish.weebly.com/", want: "phish.weebly.com"},
+		{url: "http://kaspi-bonus.xyz/login.php", want: "kaspi-bonus.xyz"},
+	}
+
+	for _, tc := range tests {
+		c := newCollector()
+		c.addURL(tc.url, "phishing")
+
+		got := ""
+		if len(c.entries) == 1 {
+			got = c.entries[0].Domain
+		}
+		if got != tc.want {
+			t.Errorf("addURL(%q) listed %q; want %q", tc.url, got, tc.want)
+		}
+	}
+}
+
+func TestCSVParserErrors(t *testing.T) {
+	p, _ := ParserFor(entity.FeedFormatCSV)
+
+	if _, _, err := p.Parse(strings.NewReader("")); err == nil {
+		t.Error("Parse() expected error for an empty csv, got none")
+	}
+	if _, _, err := p.Parse(strings.NewReader("id,name\n1,foo\n")); err == nil {
+		t.Error("Parse() expected error for a csv without a domain column, got none")
+	}
+}
+
+func TestParserForUnknown(t *testing.T) {
+	if _, err := ParserFor("rss"); err == nil {
+		t.Error("ParserFor() expected error for an unknown format, got none")
+	}
+}
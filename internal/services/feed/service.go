@@ -0,0 +1,180 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+)
+
+const defaultMaxSize = 256 << 20
+
+var (
+	// an empty snapshot is most likely a broken download, importing it
+	// would remove every entry of the feed
+	ErrEmptyFeed    = errors.New("feed has no valid entries")
+	ErrFeedTooLarge = errors.New("feed is larger than the size limit")
+)
+
+type Repository interface {
+	IndexSource
+	ImportFeed(ctx context.Context, feed *entity.Feed, entries []entity.FeedEntry, seenAt time.Time) (*entity.FeedImportStats, error)
+}
+
+// Service imports blocklist feeds into the domains list.
+type Service struct {
+	repo    Repository
+	feeds   []*entity.Feed
+	index   *Index
+	client  *http.Client
+	maxSize int64
+	log     logger.Logger
+	now     func() time.Time
+}
+
+// NewService creates a service. Feed URLs are configured by the operator,
+// so a plain client is enough, nil uses one with a minute timeout.
+// maxSize <= 0 allows feeds up to 256 MiB. A non-nil index gets the
+// entries of every imported feed.
+func NewService(repo Repository, feeds []*entity.Feed, index *Index, client *http.Client, maxSize int64, log logger.Logger) *Service {
+	if client == nil {
+		client = &http.Client{Timeout: time.Minute}
+	}
+	if maxSize <= 0 {
+		maxSize = defaultMaxSize
+	}
+
+	return &Service{
+		repo:    repo,
+		feeds:   feeds,
+		index:   index,
+		client:  client,
+		maxSize: maxSize,
+		log:     log,
+		now:     time.Now,
+	}
+}
+
+// Feeds returns the configured feeds.
+func (s *Service) Feeds() []*entity.Feed {
+	return s.feeds
+}
+
+// Refresh downloads, parses and imports a single feed.
+func (s *Service) Refresh(ctx context.Context, feed *entity.Feed) (*entity.FeedImportStats, error) {
+	start := s.now()
+
+	parser, err := ParserFor(feed.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := s.open(ctx, feed.Source)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	entries, skipped, err := parser.Parse(&limitedReader{r: body, left: s.maxSize})
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse feed %s: %w", feed.Name, err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("%s: %w", feed.Name, ErrEmptyFeed)
+	}
+
+	stats, err := s.repo.ImportFeed(ctx, feed, entries, start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import feed %s: %w", feed.Name, err)
+	}
+	stats.Skipped = skipped
+
+	// the stored entries, not the parsed ones: the removal policy may keep more
+	if s.index != nil {
+		domains, err := s.repo.FeedDomains(ctx, feed.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reload index of feed %s: %w", feed.Name, err)
+		}
+		s.index.Update(feed, domains)
+	}
+	stats.Duration = s.now().Sub(start)
+
+	return stats, nil
+}
+
+// RefreshAndLog refreshes the feed and logs the import stats. The cron
+// scheduler runs it for every feed on the feed interval and records the error.
+func (s *Service) RefreshAndLog(ctx context.Context, feed *entity.Feed) error {
+	ctx = logger.WithAction(ctx, "feed_refresh")
+
+	stats, err := s.Refresh(ctx, feed)
+	if err != nil {
+		return err
+	}
+
+	s.log.Info(ctx, "feed refreshed",
+		"feed", stats.Feed,
+		"parsed", stats.Parsed,
+		"skipped", stats.Skipped,
+		"added", stats.Added,
+		"listed", stats.Listed,
+		"removed", stats.Removed,
+		"duration", stats.Duration.String(),
+	)
+
+	return nil
+}
+
+// open returns the feed body from a URL or a local file.
+func (s *Service) open(ctx context.Context, source string) (io.ReadCloser, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		f, err := os.Open(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open feed file: %w", err)
+		}
+		return f, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid feed url: %w", err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download feed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("feed download returned status %d", resp.StatusCode)
+	}
+	return resp.Body, nil
+}
+
+// limitedReader fails with ErrFeedTooLarge instead of cutting the feed,
+// a partial snapshot would remove the entries past the cut.
+type limitedReader struct {
+	r    io.Reader
+	left int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if l.left < 0 {
+		return 0, ErrFeedTooLarge
+	}
+	if int64(len(p)) > l.left+1 {
+		p = p[:l.left+1]
+	}
+	n, err := l.r.Read(p)
+	l.left -= int64(n)
+	if l.left < 0 {
+		return n, ErrFeedTooLarge
+	}
+	return n, err
+}
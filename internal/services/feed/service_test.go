@@ -0,0 +1,139 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+)
+
+var testLogger = logger.InitLogger("feed-test", logger.LevelError)
+
+type fakeRepo struct {
+	entries []entity.FeedEntry
+	calls   int
+}
+
+func (r *fakeRepo) ImportFeed(_ context.Context, feed *entity.Feed, entries []entity.FeedEntry, _ time.Time) (*entity.FeedImportStats, error) {
+	r.calls++
+	r.entries = entries
+	return &entity.FeedImportStats{Feed: feed.Name, Parsed: len(entries), Added: len(entries), Listed: len(entries)}, nil
+}
+
+func (r *fakeRepo) FeedDomains(context.Context, string) ([]string, error) {
+	domains := make([]string, 0, len(r.entries))
+	for _, e := range r.entries {
+		domains = append(domains, e.Domain)
+	}
+	return domains, nil
+}
+
+func TestRefreshFile(t *testing.T) {
+	repo := &fakeRepo{}
+	svc := NewService(repo, nil, nil, nil, 0, testLogger)
+
+	stats, err := svc.Refresh(context.Background(), &entity.Feed{Name: "hosts", Format: entity.FeedFormatHosts, Source: "testdata/hosts.txt"})
+	if err != nil {
+		t.Fatalf("Refresh() unexpected error: %v", err)
+	}
+	if stats.Parsed != 3 || stats.Skipped != 2 {
+		t.Errorf("stats = %+v; want 3 parsed, 2 skipped", stats)
+	}
+	if len(repo.entries) != 3 {
+		t.Errorf("imported %d entries; want 3", len(repo.entries))
+	}
+}
+
+func TestRefreshURL(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/feed.txt":
+			w.Write([]byte("https://kaspi-bonus.xyz/login.php\nhttp://halyk-online.top/auth/\n"))
+		case "/empty.txt":
+			w.Write([]byte("# nothing here\n10.0.0.1\n"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	feed := func(path string) *entity.Feed {
+		return &entity.Feed{Name: "openphish", Format: entity.FeedFormatOpenPhish, Source: srv.URL + path}
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		repo := &fakeRepo{}
+		stats, err := NewService(repo, nil, nil, srv.Client(), 0, testLogger).Refresh(context.Background(), feed("/feed.txt"))
+		if err != nil {
+			t.Fatalf("Refresh() unexpected error: %v", err)
+		}
+		if stats.Parsed != 2 || repo.entries[0].URL != "https://kaspi-bonus.xyz/login.php" {
+			t.Errorf("stats = %+v, entries = %+v", stats, repo.entries)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		repo := &fakeRepo{}
+		_, err := NewService(repo, nil, nil, srv.Client(), 0, testLogger).Refresh(context.Background(), feed("/empty.txt"))
+		if !errors.Is(err, ErrEmptyFeed) {
+			t.Errorf("Refresh() error = %v; want ErrEmptyFeed", err)
+		}
+		if repo.calls != 0 {
+			t.Error("an empty feed must not be imported")
+		}
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		repo := &fakeRepo{}
+		_, err := NewService(repo, nil, nil, srv.Client(), 16, testLogger).Refresh(context.Background(), feed("/feed.txt"))
+		if !errors.Is(err, ErrFeedTooLarge) {
+			t.Errorf("Refresh() error = %v; want ErrFeedTooLarge", err)
+		}
+		if repo.calls != 0 {
+			t.Error("a truncated feed must not be imported")
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo := &fakeRepo{}
+		_, err := NewService(repo, nil, nil, srv.Client(), 0, testLogger).Refresh(context.Background(), feed("/missing.txt"))
+		if err == nil || !strings.Contains(err.Error(), "404") {
+			t.Errorf("Refresh() error = %v; want a 404 status error", err)
+		}
+	})
+}
+
+func TestRefreshUpdatesIndex(t *testing.T) {
+	index := NewIndex()
+	svc := NewService(&fakeRepo{}, nil, index, nil, 0, testLogger)
+
+	feed := &entity.Feed{Name: "hosts", Format: entity.FeedFormatHosts, Source: "testdata/hosts.txt", RiskScore: 90}
+	if _, err := svc.Refresh(context.Background(), feed); err != nil {
+		t.Fatalf("Refresh() unexpected error: %v", err)
+	}
+	if !index.Ready() || index.Size() != 3 {
+		t.Fatalf("index ready = %v, size = %d; want ready with 3 entries", index.Ready(), index.Size())
+	}
+	if got := index.Lookup("login.kaspi-bonus.xyz"); len(got) != 1 || got[0].Name != "hosts" {
+		t.Errorf("Lookup() = %v; want [hosts]", got)
+	}
+}
+
+func TestLimitedReaderExactSize(t *testing.T) {
+	body := "kaspi-bonus.xyz\n"
+	lr := &limitedReader{r: strings.NewReader(body), left: int64(len(body))}
+
+	entries, _, err := plainParser{}.Parse(lr)
+	if err != nil {
+		t.Fatalf("Parse() unexpected error for a feed of exactly the limit: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("got %d entries; want 1", len(entries))
+	}
+}
@@ -0,0 +1,237 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+)
+
+const (
+	defaultPollInterval = time.Second
+	defaultTimeout      = 2 * time.Minute
+	defaultMaxAttempts  = 5
+	defaultRetryBackoff = 10 * time.Second
+	defaultMaxBackoff   = 10 * time.Minute
+)
+
+type Repository interface {
+	CreateJob(ctx context.Context, arg *entity.CreateJobParams) (*entity.VerifyJob, error)
+	GetJob(ctx context.Context, id string) (*entity.VerifyJob, error)
+	ClaimJob(ctx context.Context, visibility time.Duration) (*entity.VerifyJob, error)
+	CompleteJob(ctx context.Context, id string, attempt int, result *entity.VerifyDomainResult) error
+	RetryJob(ctx context.Context, id string, attempt int, reason string, delay time.Duration) error
+	DeadJob(ctx context.Context, id string, attempt int, reason string) error
+	ReleaseJob(ctx context.Context, id string, attempt int) error
+}
+
+type Verifier interface {
+	Verify(ctx context.Context, target string, opts entity.VerifyOptions) (*entity.VerifyDomainResult, error)
+}
+
+// Config controls the workers of a single instance.
+type Config struct {
+	Workers      int           // 0 runs no workers, jobs are only queued
+	PollInterval time.Duration // wait between polls of an empty queue
+	Timeout      time.Duration // deadline for a single verification
+
+	MaxAttempts  int           // attempts before a job goes to the dead-letter state
+	RetryBackoff time.Duration // delay before the first retry, doubled on every next one
+	MaxBackoff   time.Duration
+
+	// a running job not finished within this time is considered abandoned
+	// by a crashed worker and claimed again, it must exceed Timeout
+	VisibilityTimeout time.Duration
+}
+
+// Service queues verifications in Postgres and runs them in the background,
+// so any instance can pick up a job and jobs survive restarts.
+type Service struct {
+	repo     Repository
+	verifier Verifier
+	cfg      Config
+	log      logger.Logger
+}
+
+func NewService(repo Repository, verifier Verifier, cfg Config, log logger.Logger) *Service {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultMaxAttempts
+	}
+	if cfg.RetryBackoff <= 0 {
+		cfg.RetryBackoff = defaultRetryBackoff
+	}
+	if cfg.MaxBackoff < cfg.RetryBackoff {
+		cfg.MaxBackoff = max(defaultMaxBackoff, cfg.RetryBackoff)
+	}
+	if cfg.VisibilityTimeout <= cfg.Timeout {
+		cfg.VisibilityTimeout = 2 * cfg.Timeout
+	}
+
+	return &Service{
+		repo:     repo,
+		verifier: verifier,
+		cfg:      cfg,
+		log:      log,
+	}
+}
+
+// Submit queues a user requested verification of a normalized domain or URL.
+func (s *Service) Submit(ctx context.Context, target string) (*entity.VerifyJob, error) {
+	return s.Enqueue(ctx, target, entity.CheckTriggerUser)
+}
+
+// Enqueue queues a verification on behalf of the given entity.CheckTrigger*.
+// Rechecks run behind user requests, rechecks and admin runs skip the
+// cache so the checkers run even if the domain already has a verdict.
+func (s *Service) Enqueue(ctx context.Context, target, trigger string) (*entity.VerifyJob, error) {
+	priority := entity.JobPriorityUser
+	if trigger == entity.CheckTriggerRecheck {
+		priority = entity.JobPriorityRecheck
+	}
+
+	job, err := s.repo.CreateJob(ctx, &entity.CreateJobParams{
+		Target:      target,
+		Trigger:     trigger,
+		Priority:    priority,
+		MaxAttempts: s.cfg.MaxAttempts,
+		SkipCache:   trigger != entity.CheckTriggerUser,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue job: %w", err)
+	}
+	return job, nil
+}
+
+// Get returns entity.ErrJobNotFound for an unknown id.
+func (s *Service) Get(ctx context.Context, id string) (*entity.VerifyJob, error) {
+	job, err := s.repo.GetJob(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrJobNotFound
+	}
+	return job, err
+}
+
+// Run starts the configured number of workers and blocks until ctx is done.
+func (s *Service) Run(ctx context.Context) {
+	wg := &sync.WaitGroup{}
+	for i := 0; i < s.cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.work(ctx)
+		}()
+	}
+	wg.Wait()
+}
+
+// work drains the queue and polls it again once it is empty.
+func (s *Service) work(ctx context.Context) {
+	ctx = logger.WithAction(ctx, "verify_job_worker")
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		processed, err := s.processNext(ctx)
+		if err != nil && ctx.Err() == nil {
+			s.log.Error(logger.ErrorCtx(ctx, err), "failed to process verify job", err)
+		}
+		if processed && err == nil {
+			timer.Reset(0)
+			continue
+		}
+		timer.Reset(s.cfg.PollInterval)
+	}
+}
+
+// processNext claims and runs a single job. It reports false when no
+// job is ready.
+func (s *Service) processNext(ctx context.Context) (bool, error) {
+	job, err := s.repo.ClaimJob(ctx, s.cfg.VisibilityTimeout)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to claim job: %w", err)
+	}
+
+	// the job outcome is saved even if the worker is stopping
+	saveCtx := context.WithoutCancel(ctx)
+
+	if job.Attempts > job.MaxAttempts {
+		// every attempt was abandoned by a crashed worker
+		err := s.repo.DeadJob(saveCtx, job.ID, job.Attempts, "visibility timeout exceeded on every attempt")
+		if err == nil {
+			s.log.Warn(ctx, "verify job abandoned too many times", "job_id", job.ID, "target", job.Target, "attempts", job.Attempts)
+		}
+		return true, s.saveError(ctx, job, "move to dead-letter", err)
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, s.cfg.Timeout)
+	defer cancel()
+
+	result, err := s.verifier.Verify(runCtx, job.Target, entity.VerifyOptions{
+		SkipCache: job.SkipCache,
+		Trigger:   job.Trigger,
+	})
+
+	switch {
+	case err == nil:
+		err = s.saveError(ctx, job, "complete", s.repo.CompleteJob(saveCtx, job.ID, job.Attempts, result))
+	case ctx.Err() != nil:
+		// interrupted by shutdown, another worker will run it
+		err = s.saveError(ctx, job, "release", s.repo.ReleaseJob(saveCtx, job.ID, job.Attempts))
+	case job.Attempts >= job.MaxAttempts:
+		s.log.Warn(ctx, "verify job failed, no attempts left", "job_id", job.ID, "target", job.Target, "attempts", job.Attempts, "error", err.Error())
+		err = s.saveError(ctx, job, "move to dead-letter", s.repo.DeadJob(saveCtx, job.ID, job.Attempts, err.Error()))
+	default:
+		delay := s.backoff(job.Attempts)
+		s.log.Warn(ctx, "verify job failed, retrying", "job_id", job.ID, "target", job.Target, "attempts", job.Attempts, "retry_in", delay.String(), "error", err.Error())
+		err = s.saveError(ctx, job, "retry", s.repo.RetryJob(saveCtx, job.ID, job.Attempts, err.Error(), delay))
+	}
+
+	return true, err
+}
+
+// saveError wraps a failure to save the job outcome. A lost lease is not
+// a failure: the job outlived its visibility timeout and the worker that
+// claimed it again owns the outcome, so this one is dropped.
+func (s *Service) saveError(ctx context.Context, job *entity.VerifyJob, action string, err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, entity.ErrJobLeaseLost):
+		s.log.Warn(ctx, "verify job was claimed again by another worker, outcome dropped", "job_id", job.ID, "target", job.Target, "attempts", job.Attempts, "action", action)
+		return nil
+	default:
+		return fmt.Errorf("failed to %s job %s: %w", action, job.ID, err)
+	}
+}
+
+// backoff returns the delay before the retry following the given attempt:
+// RetryBackoff, doubled for every next attempt, at most MaxBackoff.
+func (s *Service) backoff(attempt int) time.Duration {
+	delay := s.cfg.RetryBackoff
+	for i := 1; i < attempt && delay < s.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, s.cfg.MaxBackoff)
+}
@@ -0,0 +1,450 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+)
+
+var testLogger = logger.InitLogger("job-test", logger.LevelError)
+
+// memRepo is an in-memory queue with the semantics of JobRepository.
+type memRepo struct {
+	mu   sync.Mutex
+	jobs []*entity.VerifyJob
+	now  time.Time
+}
+
+func newMemRepo() *memRepo {
+	return &memRepo{now: time.Now()}
+}
+
+func (r *memRepo) CreateJob(_ context.Context, arg *entity.CreateJobParams) (*entity.VerifyJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, j := range r.jobs {
+		if j.Target == arg.Target && (j.Status == entity.JobStatusQueued || j.Status == entity.JobStatusRunning) {
+			if arg.Priority < j.Priority {
+				j.Priority = arg.Priority
+				j.Trigger = arg.Trigger
+			}
+			j.SkipCache = j.SkipCache || arg.SkipCache
+			return j, nil
+		}
+	}
+	j := &entity.VerifyJob{
+		ID:          fmt.Sprintf("job-%d", len(r.jobs)+1),
+		Target:      arg.Target,
+		Status:      entity.JobStatusQueued,
+		Trigger:     arg.Trigger,
+		Priority:    arg.Priority,
+		SkipCache:   arg.SkipCache,
+		MaxAttempts: arg.MaxAttempts,
+		CreatedAt:   r.now,
+		VisibleAt:   r.now,
+	}
+	r.jobs = append(r.jobs, j)
+	return j, nil
+}
+
+func (r *memRepo) GetJob(_ context.Context, id string) (*entity.VerifyJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, j := range r.jobs {
+		if j.ID == id {
+			return j, nil
+		}
+	}
+	return nil, pgx.ErrNoRows
+}
+
+func (r *memRepo) ClaimJob(_ context.Context, visibility time.Duration) (*entity.VerifyJob, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var ready []*entity.VerifyJob
+	for _, j := range r.jobs {
+		active := j.Status == entity.JobStatusQueued || j.Status == entity.JobStatusRunning
+		if active && !j.VisibleAt.After(r.now) {
+			ready = append(ready, j)
+		}
+	}
+	if len(ready) == 0 {
+		return nil, pgx.ErrNoRows
+	}
+	sort.SliceStable(ready, func(a, b int) bool {
+		if ready[a].Priority != ready[b].Priority {
+			return ready[a].Priority < ready[b].Priority
+		}
+		return ready[a].VisibleAt.Before(ready[b].VisibleAt)
+	})
+
+	j := ready[0]
+	j.Status = entity.JobStatusRunning
+	j.Attempts++
+	j.VisibleAt = r.now.Add(visibility)
+	return j, nil
+}
+
+func (r *memRepo) CompleteJob(_ context.Context, id string, attempt int, result *entity.VerifyDomainResult) error {
+	return r.set(id, attempt, func(j *entity.VerifyJob) { j.Status, j.Result = entity.JobStatusDone, result })
+}
+
+func (r *memRepo) RetryJob(_ context.Context, id string, attempt int, reason string, delay time.Duration) error {
+	return r.set(id, attempt, func(j *entity.VerifyJob) {
+		j.Status, j.Error, j.VisibleAt = entity.JobStatusQueued, reason, r.now.Add(delay)
+	})
+}
+
+func (r *memRepo) DeadJob(_ context.Context, id string, attempt int, reason string) error {
+	return r.set(id, attempt, func(j *entity.VerifyJob) { j.Status, j.Error = entity.JobStatusDead, reason })
+}
+
+func (r *memRepo) ReleaseJob(_ context.Context, id string, attempt int) error {
+	return r.set(id, attempt, func(j *entity.VerifyJob) {
+		j.Status, j.Attempts, j.VisibleAt = entity.JobStatusQueued, j.Attempts-1, r.now
+	})
+}
+
+// set updates a job still running under the given claim.
+func (r *memRepo) set(id string, attempt int, fn func(j *entity.VerifyJob)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, j := range r.jobs {
+		if j.ID == id {
+			if j.Status != entity.JobStatusRunning || j.Attempts != attempt {
+				return entity.ErrJobLeaseLost
+			}
+			fn(j)
+			return nil
+		}
+	}
+	return pgx.ErrNoRows
+}
+
+// advance moves the repository clock forward.
+func (r *memRepo) advance(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.now = r.now.Add(d)
+}
+
+type verifierFunc func(ctx context.Context, target string, opts entity.VerifyOptions) (*entity.VerifyDomainResult, error)
+
+func (f verifierFunc) Verify(ctx context.Context, target string, opts entity.VerifyOptions) (*entity.VerifyDomainResult, error) {
+	return f(ctx, target, opts)
+}
+
+func okVerifier(ctx context.Context, target string, _ entity.VerifyOptions) (*entity.VerifyDomainResult, error) {
+	return &entity.VerifyDomainResult{Domain: target, Status: entity.StatusScam}, nil
+}
+
+func failingVerifier(context.Context, string, entity.VerifyOptions) (*entity.VerifyDomainResult, error) {
+	return nil, errors.New("failed to look up domain: connection refused")
+}
+
+func TestSubmitDeduplicatesActiveJobs(t *testing.T) {
+	svc := NewService(newMemRepo(), verifierFunc(okVerifier), Config{}, testLogger)
+	ctx := context.Background()
+
+	recheck, err := svc.Enqueue(ctx, "kaspi-bonus.xyz", entity.CheckTriggerRecheck)
+	if err != nil {
+		t.Fatalf("Enqueue() unexpected error: %v", err)
+	}
+	user, _ := svc.Submit(ctx, "kaspi-bonus.xyz")
+	if user.ID != recheck.ID {
+		t.Errorf("Submit() of a queued target returned a new job %s; want %s", user.ID, recheck.ID)
+	}
+	if user.Priority != entity.JobPriorityUser || user.Trigger != entity.CheckTriggerUser {
+		t.Errorf("Priority, Trigger = %d, %s; want the queued job raised to %d, %s",
+			user.Priority, user.Trigger, entity.JobPriorityUser, entity.CheckTriggerUser)
+	}
+	if !user.SkipCache {
+		t.Error("SkipCache = false; want the recheck to still skip the cache")
+	}
+}
+
+func TestClaimOrder(t *testing.T) {
+	repo := newMemRepo()
+	var order []string
+	svc := NewService(repo, verifierFunc(func(ctx context.Context, target string, _ entity.VerifyOptions) (*entity.VerifyDomainResult, error) {
+		order = append(order, target)
+		return okVerifier(ctx, target, entity.VerifyOptions{})
+	}), Config{}, testLogger)
+	ctx := context.Background()
+
+	svc.Enqueue(ctx, "recheck-1.xyz", entity.CheckTriggerRecheck)
+	svc.Enqueue(ctx, "recheck-2.xyz", entity.CheckTriggerRecheck)
+	svc.Submit(ctx, "user-1.xyz")
+	svc.Submit(ctx, "user-2.xyz")
+
+	for {
+		processed, err := svc.processNext(ctx)
+		if err != nil {
+			t.Fatalf("processNext() unexpected error: %v", err)
+		}
+		if !processed {
+			break
+		}
+	}
+
+	want := []string{"user-1.xyz", "user-2.xyz", "recheck-1.xyz", "recheck-2.xyz"}
+	if fmt.Sprint(order) != fmt.Sprint(want) {
+		t.Errorf("processed %v; want %v", order, want)
+	}
+}
+
+func TestProcessNextDone(t *testing.T) {
+	svc := NewService(newMemRepo(), verifierFunc(okVerifier), Config{}, testLogger)
+	ctx := context.Background()
+
+	submitted, _ := svc.Submit(ctx, "kaspi-bonus.xyz")
+	if processed, err := svc.processNext(ctx); !processed || err != nil {
+		t.Fatalf("processNext() = %v, %v; want true, nil", processed, err)
+	}
+
+	job, err := svc.Get(ctx, submitted.ID)
+	if err != nil {
+		t.Fatalf("Get() unexpected error: %v", err)
+	}
+	if job.Status != entity.JobStatusDone || job.Result == nil || job.Result.Domain != "kaspi-bonus.xyz" {
+		t.Errorf("job = %+v; want done with the verdict", job)
+	}
+}
+
+func TestRetriesThenDeadLetter(t *testing.T) {
+	repo := newMemRepo()
+	svc := NewService(repo, verifierFunc(failingVerifier), Config{
+		MaxAttempts:  3,
+		RetryBackoff: time.Second,
+		MaxBackoff:   time.Minute,
+	}, testLogger)
+	ctx := context.Background()
+
+	submitted, _ := svc.Submit(ctx, "kaspi-bonus.xyz")
+	wantStatus := []string{entity.JobStatusQueued, entity.JobStatusQueued, entity.JobStatusDead}
+	wantDelay := []time.Duration{time.Second, 2 * time.Second}
+
+	for attempt, want := range wantStatus {
+		if processed, _ := svc.processNext(ctx); !processed {
+			t.Fatalf("attempt %d: no job claimed", attempt+1)
+		}
+		if processed, _ := svc.processNext(ctx); processed {
+			t.Fatalf("attempt %d: job claimed again before its backoff", attempt+1)
+		}
+
+		job, _ := svc.Get(ctx, submitted.ID)
+		if job.Status != want {
+			t.Fatalf("attempt %d: Status = %q; want %q", attempt+1, job.Status, want)
+		}
+		if job.Error == "" {
+			t.Errorf("attempt %d: the error is not recorded", attempt+1)
+		}
+		if attempt < len(wantDelay) {
+			if delay := job.VisibleAt.Sub(repo.now); delay != wantDelay[attempt] {
+				t.Errorf("attempt %d: retry in %s; want %s", attempt+1, delay, wantDelay[attempt])
+			}
+			repo.advance(wantDelay[attempt])
+		}
+	}
+}
+
+func TestAbandonedJob(t *testing.T) {
+	repo := newMemRepo()
+	svc := NewService(repo, verifierFunc(okVerifier), Config{MaxAttempts: 2, Timeout: time.Minute, VisibilityTimeout: 5 * time.Minute}, testLogger)
+	ctx := context.Background()
+
+	submitted, _ := svc.Submit(ctx, "kaspi-bonus.xyz")
+
+	// two workers claim the job and crash
+	for i := 0; i < 2; i++ {
+		if _, err := repo.ClaimJob(ctx, svc.cfg.VisibilityTimeout); err != nil {
+			t.Fatalf("claim %d: %v", i+1, err)
+		}
+		if processed, _ := svc.processNext(ctx); processed {
+			t.Fatalf("claim %d: a running job was claimed before its visibility timeout", i+1)
+		}
+		repo.advance(svc.cfg.VisibilityTimeout)
+	}
+
+	if processed, err := svc.processNext(ctx); !processed || err != nil {
+		t.Fatalf("processNext() = %v, %v; want the abandoned job claimed", processed, err)
+	}
+	job, _ := svc.Get(ctx, submitted.ID)
+	if job.Status != entity.JobStatusDead {
+		t.Errorf("Status = %q; want %q after every attempt was abandoned", job.Status, entity.JobStatusDead)
+	}
+}
+
+func TestLateWorkerLosesLease(t *testing.T) {
+	repo := newMemRepo()
+	cfg := Config{MaxAttempts: 3, Timeout: time.Minute, VisibilityTimeout: 5 * time.Minute}
+	fast := NewService(repo, verifierFunc(okVerifier), cfg, testLogger)
+	ctx := context.Background()
+
+	// the slow worker outlives its visibility timeout, meanwhile the job
+	// is claimed again and completed by another worker
+	slow := NewService(repo, verifierFunc(func(ctx context.Context, target string, opts entity.VerifyOptions) (*entity.VerifyDomainResult, error) {
+		repo.advance(cfg.VisibilityTimeout)
+		if processed, err := fast.processNext(ctx); !processed || err != nil {
+			t.Fatalf("fast processNext() = %v, %v; want the job claimed again", processed, err)
+		}
+		return failingVerifier(ctx, target, opts)
+	}), cfg, testLogger)
+
+	submitted, _ := slow.Submit(ctx, "kaspi-bonus.xyz")
+	if processed, err := slow.processNext(ctx); !processed || err != nil {
+		t.Fatalf("slow processNext() = %v, %v; want true, nil", processed, err)
+	}
+
+	job, _ := slow.Get(ctx, submitted.ID)
+	if job.Status != entity.JobStatusDone {
+		t.Errorf("Status = %q; want the late retry dropped and the job %q", job.Status, entity.JobStatusDone)
+	}
+}
+
+func TestProcessNextEmptyQueue(t *testing.T) {
+	svc := NewService(newMemRepo(), verifierFunc(okVerifier), Config{}, testLogger)
+
+	processed, err := svc.processNext(context.Background())
+	if processed || err != nil {
+		t.Errorf("processNext() = %v, %v; want false, nil", processed, err)
+	}
+}
+
+func TestProcessNextReleasesOnShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	svc := NewService(newMemRepo(), verifierFunc(func(ctx context.Context, _ string, _ entity.VerifyOptions) (*entity.VerifyDomainResult, error) {
+		cancel()
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}), Config{}, testLogger)
+
+	submitted, _ := svc.Submit(ctx, "kaspi-bonus.xyz")
+	if _, err := svc.processNext(ctx); err != nil {
+		t.Fatalf("processNext() unexpected error: %v", err)
+	}
+
+	job, _ := svc.Get(context.Background(), submitted.ID)
+	if job.Status != entity.JobStatusQueued || job.Attempts != 0 {
+		t.Errorf("job = %+v; want it back in the queue without a spent attempt", job)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	svc := NewService(newMemRepo(), verifierFunc(okVerifier), Config{RetryBackoff: 10 * time.Second, MaxBackoff: time.Minute}, testLogger)
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: 10 * time.Second},
+		{attempt: 2, want: 20 * time.Second},
+		{attempt: 3, want: 40 * time.Second},
+		{attempt: 4, want: time.Minute},
+		{attempt: 40, want: time.Minute},
+	}
+	for _, tc := range tests {
+		if got := svc.backoff(tc.attempt); got != tc.want {
+			t.Errorf("backoff(%d) = %s; want %s", tc.attempt, got, tc.want)
+		}
+	}
+}
+
+func TestVisibilityTimeoutExceedsJobTimeout(t *testing.T) {
+	svc := NewService(newMemRepo(), verifierFunc(okVerifier), Config{Timeout: 2 * time.Minute, VisibilityTimeout: time.Minute}, testLogger)
+
+	if svc.cfg.VisibilityTimeout <= svc.cfg.Timeout {
+		t.Errorf("VisibilityTimeout = %s; want more than the %s job timeout", svc.cfg.VisibilityTimeout, svc.cfg.Timeout)
+	}
+}
+
+func TestGetUnknownJob(t *testing.T) {
+	svc := NewService(newMemRepo(), verifierFunc(okVerifier), Config{}, testLogger)
+
+	if _, err := svc.Get(context.Background(), "missing"); !errors.Is(err, entity.ErrJobNotFound) {
+		t.Errorf("Get() error = %v; want ErrJobNotFound", err)
+	}
+}
+
+func TestRunDrainsQueue(t *testing.T) {
+	repo := newMemRepo()
+	svc := NewService(repo, verifierFunc(okVerifier), Config{Workers: 3, PollInterval: 10 * time.Millisecond}, testLogger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	for _, target := range []string{"a.xyz", "b.xyz", "c.xyz", "d.xyz", "e.xyz"} {
+		svc.Submit(ctx, target)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		svc.Run(ctx)
+		close(done)
+	}()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if countStatus(repo, entity.JobStatusDone) == 5 {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	cancel()
+	<-done
+
+	if got := countStatus(repo, entity.JobStatusDone); got != 5 {
+		t.Errorf("%d jobs done; want 5", got)
+	}
+}
+
+func countStatus(r *memRepo, status string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := 0
+	for _, j := range r.jobs {
+		if j.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+func TestJobPassesVerifyOptions(t *testing.T) {
+	var got []entity.VerifyOptions
+	svc := NewService(newMemRepo(), verifierFunc(func(ctx context.Context, target string, opts entity.VerifyOptions) (*entity.VerifyDomainResult, error) {
+		got = append(got, opts)
+		return okVerifier(ctx, target, opts)
+	}), Config{}, testLogger)
+	ctx := context.Background()
+
+	svc.Submit(ctx, "user.xyz")
+	svc.Enqueue(ctx, "admin.xyz", entity.CheckTriggerAdmin)
+	svc.Enqueue(ctx, "recheck.xyz", entity.CheckTriggerRecheck)
+	for i := 0; i < 3; i++ {
+		if _, err := svc.processNext(ctx); err != nil {
+			t.Fatalf("processNext() unexpected error: %v", err)
+		}
+	}
+
+	want := []entity.VerifyOptions{
+		{Trigger: entity.CheckTriggerUser},
+		{SkipCache: true, Trigger: entity.CheckTriggerAdmin},
+		{SkipCache: true, Trigger: entity.CheckTriggerRecheck},
+	}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Verify() options = %+v; want %+v", got, want)
+	}
+}
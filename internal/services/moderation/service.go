@@ -0,0 +1,164 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+)
+
+type Repository interface {
+	CreateModerationTask(ctx context.Context, arg *entity.CreateModerationTaskParams) (*entity.ModerationTask, error)
+	GetModerationTask(ctx context.Context, domain string) (*entity.ModerationTask, error)
+	GetModerationTasks(ctx context.Context, arg *entity.GetModerationTasksParams) ([]*entity.ModerationTask, error)
+	ClaimModerationTask(ctx context.Context, domain, moderator string) (*entity.ModerationTask, error)
+	ResolveModerationTask(ctx context.Context, arg *entity.ResolveModerationTaskParams) (*entity.ModerationTask, error)
+	UpdateModerationTaskPriority(ctx context.Context, domain string, priority int) (*entity.ModerationTask, error)
+	CloseModerationTask(ctx context.Context, domain, resolution string) error
+}
+
+// Service keeps suspicious domains in the moderation queue. Moderators
+// claim tasks, then resolve them with a manual verified or scam verdict.
+type Service struct {
+	repo Repository
+	log  logger.Logger
+}
+
+func NewService(repo Repository, log logger.Logger) *Service {
+	return &Service{
+		repo: repo,
+		log:  log,
+	}
+}
+
+// Track brings the queue in line with a saved verdict: a suspicious domain
+// is queued, a domain that became verified or scam has its open task
+// closed. The verdict is already saved, so a failure is only logged, the
+// next verdict of the domain tries again.
+func (s *Service) Track(ctx context.Context, d *entity.Domain, checkID string) {
+	if d.Status != entity.StatusSuspicious {
+		if err := s.repo.CloseModerationTask(ctx, d.Domain, d.Status); err != nil {
+			s.log.Error(logger.ErrorCtx(ctx, err), "failed to close moderation task", err, "domain", d.Domain)
+		}
+		return
+	}
+
+	arg := &entity.CreateModerationTaskParams{
+		Domain:        d.Domain,
+		Reasons:       d.Reasons,
+		SourceModules: sourceModules(d.Metadata),
+		Priority:      priority(d.RiskScore),
+	}
+	if checkID != "" {
+		arg.CheckID = &checkID
+	}
+
+	task, err := s.repo.CreateModerationTask(ctx, arg)
+	if err != nil {
+		s.log.Error(logger.ErrorCtx(ctx, err), "failed to queue domain for moderation", err, "domain", d.Domain)
+		return
+	}
+	s.log.Info(ctx, "domain queued for moderation", "domain", d.Domain, "priority", task.Priority, "status", task.Status)
+}
+
+// List returns tasks matching arg.
+func (s *Service) List(ctx context.Context, arg *entity.GetModerationTasksParams) ([]*entity.ModerationTask, error) {
+	return s.repo.GetModerationTasks(ctx, arg)
+}
+
+// Get returns entity.ErrModerationNotFound for a domain without a task.
+func (s *Service) Get(ctx context.Context, domain string) (*entity.ModerationTask, error) {
+	task, err := s.repo.GetModerationTask(ctx, domain)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, entity.ErrModerationNotFound
+	}
+	return task, err
+}
+
+// Claim assigns a pending task to the moderator. A task claimed by
+// someone else gives entity.ErrModerationClaimed.
+func (s *Service) Claim(ctx context.Context, domain, moderator string) (*entity.ModerationTask, error) {
+	task, err := s.repo.ClaimModerationTask(ctx, domain, moderator)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, s.refusal(ctx, domain, entity.ErrModerationClaimed)
+	}
+	return task, err
+}
+
+// Resolve closes a task claimed by the moderator and gives the domain
+// the moderator verdict.
+func (s *Service) Resolve(ctx context.Context, arg *entity.ResolveModerationTaskParams) (*entity.ModerationTask, error) {
+	task, err := s.repo.ResolveModerationTask(ctx, arg)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, s.refusal(ctx, arg.Domain, entity.ErrModerationNotClaimed)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info(ctx, "moderation task resolved", "domain", arg.Domain, "moderator", arg.Moderator, "status", arg.Resolution)
+	return task, nil
+}
+
+// SetPriority changes the priority of an open task.
+func (s *Service) SetPriority(ctx context.Context, domain string, priority int) (*entity.ModerationTask, error) {
+	task, err := s.repo.UpdateModerationTaskPriority(ctx, domain, priority)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, s.refusal(ctx, domain, entity.ErrModerationResolved)
+	}
+	return task, err
+}
+
+// refusal explains why a conditional update matched no task: there is
+// none, it is resolved, or the given reason.
+func (s *Service) refusal(ctx context.Context, domain string, reason error) error {
+	task, err := s.Get(ctx, domain)
+	switch {
+	case err != nil:
+		return err
+	case task.Status == entity.ModerationStatusResolved:
+		return entity.ErrModerationResolved
+	default:
+		return reason
+	}
+}
+
+// priority maps the risk score to a task priority, riskier domains are
+// reviewed first: 60 gives 4, 35 gives 7.
+func priority(score *float64) int {
+	if score == nil {
+		return entity.ModerationPriorityDefault
+	}
+	p := entity.ModerationPriorityLowest - int(math.Floor(*score/10))
+	return min(max(p, entity.ModerationPriorityHighest), entity.ModerationPriorityLowest)
+}
+
+// sourceModules returns the modules that added to the risk of the domain,
+// riskiest first. Metadata holds their results.
+func sourceModules(metadata []json.RawMessage) []string {
+	var results []entity.ModuleResult
+	for _, raw := range metadata {
+		var mr entity.ModuleResult
+		if err := json.Unmarshal(raw, &mr); err != nil {
+			continue
+		}
+		if mr.Status == entity.ModuleStatusOK && mr.RiskScore > 0 {
+			results = append(results, mr)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].RiskScore > results[j].RiskScore
+	})
+
+	modules := make([]string, 0, len(results))
+	for _, mr := range results {
+		modules = append(modules, mr.ModuleName)
+	}
+	return modules
+}
@@ -0,0 +1,235 @@
+package moderation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+)
+
+var testLogger = logger.InitLogger("moderation-test", logger.LevelError)
+
+// memRepo has the semantics of ModerationRepository.
+type memRepo struct {
+	tasks  map[string]*entity.ModerationTask
+	closed []string
+	err    error
+}
+
+func newMemRepo(tasks ...*entity.ModerationTask) *memRepo {
+	r := &memRepo{tasks: make(map[string]*entity.ModerationTask)}
+	for _, t := range tasks {
+		r.tasks[t.Domain] = t
+	}
+	return r
+}
+
+func (r *memRepo) CreateModerationTask(_ context.Context, arg *entity.CreateModerationTaskParams) (*entity.ModerationTask, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	t, ok := r.tasks[arg.Domain]
+	if !ok || t.Status == entity.ModerationStatusResolved {
+		t = &entity.ModerationTask{Domain: arg.Domain, Status: entity.ModerationStatusPending, Priority: arg.Priority}
+		r.tasks[arg.Domain] = t
+	}
+	if arg.CheckID != nil {
+		t.CheckID = arg.CheckID
+	}
+	t.Reasons, t.SourceModules = arg.Reasons, arg.SourceModules
+	return t, nil
+}
+
+func (r *memRepo) GetModerationTask(_ context.Context, domain string) (*entity.ModerationTask, error) {
+	t, ok := r.tasks[domain]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	return t, nil
+}
+
+func (r *memRepo) GetModerationTasks(context.Context, *entity.GetModerationTasksParams) ([]*entity.ModerationTask, error) {
+	return nil, nil
+}
+
+func (r *memRepo) ClaimModerationTask(_ context.Context, domain, moderator string) (*entity.ModerationTask, error) {
+	t, ok := r.tasks[domain]
+	if !ok || !(t.Status == entity.ModerationStatusPending ||
+		t.Status == entity.ModerationStatusInProgress && *t.AssignedTo == moderator) {
+		return nil, pgx.ErrNoRows
+	}
+	t.Status, t.AssignedTo = entity.ModerationStatusInProgress, &moderator
+	return t, nil
+}
+
+func (r *memRepo) ResolveModerationTask(_ context.Context, arg *entity.ResolveModerationTaskParams) (*entity.ModerationTask, error) {
+	t, ok := r.tasks[arg.Domain]
+	if !ok || t.Status != entity.ModerationStatusInProgress || *t.AssignedTo != arg.Moderator {
+		return nil, pgx.ErrNoRows
+	}
+	t.Status, t.Resolution, t.ResolvedBy = entity.ModerationStatusResolved, &arg.Resolution, &arg.Moderator
+	return t, nil
+}
+
+func (r *memRepo) UpdateModerationTaskPriority(_ context.Context, domain string, priority int) (*entity.ModerationTask, error) {
+	t, ok := r.tasks[domain]
+	if !ok || t.Status == entity.ModerationStatusResolved {
+		return nil, pgx.ErrNoRows
+	}
+	t.Priority = priority
+	return t, nil
+}
+
+func (r *memRepo) CloseModerationTask(_ context.Context, domain, resolution string) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.closed = append(r.closed, domain+":"+resolution)
+	return nil
+}
+
+func moduleResult(t *testing.T, name, status string, score float64) json.RawMessage {
+	raw, err := json.Marshal(entity.ModuleResult{ModuleName: name, Status: status, RiskScore: score})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return raw
+}
+
+func TestTrackQueuesSuspicious(t *testing.T) {
+	repo := newMemRepo()
+	svc := NewService(repo, testLogger)
+	score := 62.0
+
+	svc.Track(context.Background(), &entity.Domain{
+		Domain:    "kaspi-bonus.xyz",
+		Status:    entity.StatusSuspicious,
+		RiskScore: &score,
+		Reasons:   []string{"domain registered 3 days ago"},
+		Metadata: []json.RawMessage{
+			moduleResult(t, "lexical", entity.ModuleStatusOK, 40),
+			moduleResult(t, "whois", entity.ModuleStatusOK, 0),
+			moduleResult(t, "domainage", entity.ModuleStatusOK, 90),
+			moduleResult(t, "dns", entity.ModuleStatusTimeout, 0),
+			json.RawMessage(`{"module_name": "status_override", "risk_score": 62}`),
+		},
+	}, "check-1")
+
+	task := repo.tasks["kaspi-bonus.xyz"]
+	if task == nil {
+		t.Fatal("suspicious domain was not queued")
+	}
+	if task.Priority != 4 || task.CheckID == nil || *task.CheckID != "check-1" {
+		t.Errorf("task = %+v; want priority 4 and the check", task)
+	}
+	if fmt.Sprint(task.SourceModules) != "[domainage lexical]" {
+		t.Errorf("SourceModules = %v; want the scoring modules, riskiest first", task.SourceModules)
+	}
+}
+
+func TestTrackClosesOtherStatuses(t *testing.T) {
+	repo := newMemRepo()
+	svc := NewService(repo, testLogger)
+
+	svc.Track(context.Background(), &entity.Domain{Domain: "kaspi.kz", Status: entity.StatusVerified}, "")
+
+	if len(repo.tasks) != 0 || fmt.Sprint(repo.closed) != "[kaspi.kz:verified]" {
+		t.Errorf("tasks = %v, closed = %v; want the open task closed as verified", repo.tasks, repo.closed)
+	}
+}
+
+func TestTrackFailureIsNotFatal(t *testing.T) {
+	svc := NewService(&memRepo{err: errors.New("connection refused")}, testLogger)
+
+	// logged only, the verdict is already saved
+	svc.Track(context.Background(), &entity.Domain{Domain: "kaspi-bonus.xyz", Status: entity.StatusSuspicious}, "")
+	svc.Track(context.Background(), &entity.Domain{Domain: "kaspi-bonus.xyz", Status: entity.StatusScam}, "")
+}
+
+func TestClaim(t *testing.T) {
+	alice := "alice"
+	resolved := &entity.ModerationTask{Domain: "done.xyz", Status: entity.ModerationStatusResolved}
+	claimed := &entity.ModerationTask{Domain: "taken.xyz", Status: entity.ModerationStatusInProgress, AssignedTo: &alice}
+	pending := &entity.ModerationTask{Domain: "new.xyz", Status: entity.ModerationStatusPending}
+	svc := NewService(newMemRepo(resolved, claimed, pending), testLogger)
+	ctx := context.Background()
+
+	tests := []struct {
+		domain    string
+		moderator string
+		wantErr   error
+	}{
+		{domain: "new.xyz", moderator: "bob"},
+		{domain: "new.xyz", moderator: "alice", wantErr: entity.ErrModerationClaimed},
+		{domain: "taken.xyz", moderator: "alice"},
+		{domain: "done.xyz", moderator: "bob", wantErr: entity.ErrModerationResolved},
+		{domain: "unknown.xyz", moderator: "bob", wantErr: entity.ErrModerationNotFound},
+	}
+
+	for _, tc := range tests {
+		task, err := svc.Claim(ctx, tc.domain, tc.moderator)
+		if !errors.Is(err, tc.wantErr) {
+			t.Errorf("Claim(%s, %s) error = %v; want %v", tc.domain, tc.moderator, err, tc.wantErr)
+			continue
+		}
+		if err == nil && (task.Status != entity.ModerationStatusInProgress || *task.AssignedTo != tc.moderator) {
+			t.Errorf("Claim(%s, %s) = %+v", tc.domain, tc.moderator, task)
+		}
+	}
+}
+
+func TestResolveAndPriority(t *testing.T) {
+	alice := "alice"
+	repo := newMemRepo(&entity.ModerationTask{Domain: "taken.xyz", Status: entity.ModerationStatusInProgress, AssignedTo: &alice})
+	svc := NewService(repo, testLogger)
+	ctx := context.Background()
+
+	if _, err := svc.SetPriority(ctx, "taken.xyz", 2); err != nil {
+		t.Fatalf("SetPriority() unexpected error: %v", err)
+	}
+
+	arg := &entity.ResolveModerationTaskParams{Domain: "taken.xyz", Moderator: "bob", Resolution: entity.StatusScam}
+	if _, err := svc.Resolve(ctx, arg); !errors.Is(err, entity.ErrModerationNotClaimed) {
+		t.Errorf("Resolve() by another moderator error = %v; want ErrModerationNotClaimed", err)
+	}
+
+	arg.Moderator = "alice"
+	task, err := svc.Resolve(ctx, arg)
+	if err != nil {
+		t.Fatalf("Resolve() unexpected error: %v", err)
+	}
+	if task.Status != entity.ModerationStatusResolved || *task.Resolution != entity.StatusScam || task.Priority != 2 {
+		t.Errorf("Resolve() = %+v", task)
+	}
+
+	if _, err := svc.SetPriority(ctx, "taken.xyz", 1); !errors.Is(err, entity.ErrModerationResolved) {
+		t.Errorf("SetPriority() of a resolved task error = %v; want ErrModerationResolved", err)
+	}
+}
+
+func TestPriority(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		score *float64
+		want  int
+	}{
+		{score: nil, want: entity.ModerationPriorityDefault},
+		{score: score(0), want: 10},
+		{score: score(35), want: 7},
+		{score: score(69.99), want: 4},
+		{score: score(100), want: 1},
+	}
+
+	for _, tc := range tests {
+		if got := priority(tc.score); got != tc.want {
+			t.Errorf("priority(%v) = %d; want %d", tc.score, got, tc.want)
+		}
+	}
+}
@@ -3,128 +3,442 @@ package pipeline
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/internal/services/status"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+	"github.com/ItsXomyak/scam-list/pkg/utils"
+)
+
+const (
+	defaultCheckerTimeout = 15 * time.Second
+	defaultMinSuccessful  = 1
+
+	// modules scoring at least this much are listed in scam_sources
+	scamSourceMinScore = 70.0
 )
 
 type ScamChecker interface {
 	Check(ctx context.Context, domain string) (*entity.CheckerResult, error) // core функция чекеров с модулей
+	Name() string                                                            // уникальное имя модуля, по нему берется вес
 	Info() string                                                            // полная инфа с чекера
 }
 
+// TimeoutChecker is implemented by checkers that need their own deadline
+// instead of Config.CheckerTimeout.
+type TimeoutChecker interface {
+	Timeout() time.Duration
+}
+
 type DomainService interface {
 	GetDomain(ctx context.Context, domain string) (*entity.Domain, error)
+	UpsertDomain(ctx context.Context, params *entity.UpsertDomainParams) (*entity.Domain, error)
+}
+
+// CheckRecorder keeps the history of pipeline runs.
+type CheckRecorder interface {
+	CreateCheck(ctx context.Context, check *entity.DomainCheck) (*entity.DomainCheck, error)
+}
+
+// Config controls how checkers are run.
+type Config struct {
+	CheckerTimeout time.Duration // deadline for a single checker
+	MinSuccessful  int           // fewer successful checkers give an insufficient data verdict
+
+	// how long a fresh verdict of each status stays valid before it is
+	// rechecked, a status without an entry never expires
+	RecheckAfter map[string]time.Duration
 }
 
 type DomainPipeline struct {
 	checkers  []ScamChecker
 	domainSvc DomainService
+	scorer    *RiskScorer
+	statuses  *status.Policy
+	redirects RedirectFollower
+	checks    CheckRecorder
+	cfg       Config
+	log       logger.Logger
 }
 
-func NewDomainPipeline(checkers []ScamChecker, domainSvc DomainService) *DomainPipeline {
+// NewDomainPipeline creates a pipeline. A nil redirects follower checks
+// the submitted domain as is, a nil check recorder keeps no history.
+func NewDomainPipeline(checkers []ScamChecker, domainSvc DomainService, scorer *RiskScorer, statuses *status.Policy, redirects RedirectFollower, checks CheckRecorder, cfg Config, log logger.Logger) *DomainPipeline {
+	if scorer == nil {
+		scorer = defaultScorer
+	}
+	if statuses == nil {
+		statuses = status.Default()
+	}
+	if cfg.CheckerTimeout <= 0 {
+		cfg.CheckerTimeout = defaultCheckerTimeout
+	}
+	if cfg.MinSuccessful <= 0 {
+		cfg.MinSuccessful = defaultMinSuccessful
+	}
+
 	return &DomainPipeline{
 		checkers:  checkers,
 		domainSvc: domainSvc,
+		scorer:    scorer,
+		statuses:  statuses,
+		redirects: redirects,
+		checks:    checks,
+		cfg:       cfg,
+		log:       log,
 	}
 }
 
+// ProcessDomain returns the stored verdict for a known domain
+// and runs all checkers for an unknown one.
 func (p *DomainPipeline) ProcessDomain(ctx context.Context, url string) (*entity.VerifyDomainResult, error) {
-	domain, err := p.domainSvc.GetDomain(ctx, url)
+	return p.Verify(ctx, url, entity.VerifyOptions{})
+}
+
+// Verify is ProcessDomain with explicit options. A fresh verdict is saved to the list.
+// The target may be a domain or a URL. Its redirects are followed and a
+// chain leading to another site gets the verdict of the landing domain.
+// A bare domain with a stored verdict is answered without following them.
+// Every run of the checkers is recorded as a check, a stored verdict is not.
+func (p *DomainPipeline) Verify(ctx context.Context, target string, opts entity.VerifyOptions) (*entity.VerifyDomainResult, error) {
+	startedAt := time.Now()
+
+	domain, err := utils.NormalizeDomain(target)
 	if err != nil {
-		return nil, err // TODO: обработать
+		return nil, fmt.Errorf("invalid target %q: %w", target, err)
 	}
 
-	if domain != nil {
-		return nil, errors.New("domain not found") // если домен уже есть в базе, возвращаем его
+	// a forced run still loads the stored verdict to report a status change
+	var stored *entity.Domain
+	bare := !strings.Contains(target, "/")
+	if bare {
+		if stored, err = p.storedDomain(ctx, domain); err != nil {
+			return nil, err
+		}
+		if stored != nil && !opts.SkipCache {
+			return fromStoredDomain(stored), nil
+		}
 	}
 
-	wg := &sync.WaitGroup{}
-	resCh := make(chan *entity.CheckerResult, len(p.checkers))
-	errCh := make(chan error, len(p.checkers))
+	chain := p.followRedirects(ctx, target)
+	if landing := landingDomain(domain, chain); !bare || landing != domain {
+		domain = landing
+		if stored, err = p.storedDomain(ctx, domain); err != nil {
+			return nil, err
+		}
+		if stored != nil && !opts.SkipCache {
+			return withRedirects(fromStoredDomain(stored), chain), nil
+		}
+	}
 
-	for _, checker := range p.checkers {
-		wg.Add(1)
-		go func(checker ScamChecker) {
-			defer wg.Done()
-			result, err := checker.Check(ctx, url)
-			if err != nil {
-				errCh <- err
-				return
-			}
+	result := withRedirects(p.analyze(ctx, domain, redirectResult(chain)), chain)
+	result.CheckID = p.recordCheck(ctx, result, opts.Trigger, startedAt)
+	if result.Status == entity.StatusInsufficientData {
+		// nothing trustworthy to store
+		return result, nil
+	}
 
-			resCh <- result
-		}(checker)
+	params := toUpsertParams(result, opts.OverwriteManual, p.expiresAt(result.Status))
+	params.CheckID = result.CheckID
+	saved, err := p.domainSvc.UpsertDomain(ctx, params)
+	switch {
+	case err == nil:
+		result.Status = saved.Status
+		if stored != nil && stored.Status != saved.Status {
+			result.PreviousStatus = stored.Status
+			p.log.Warn(ctx, "domain status changed",
+				"domain", domain,
+				"previous_status", stored.Status,
+				"status", saved.Status,
+				"risk_score", result.RiskScore,
+			)
+		}
+	case errors.Is(err, entity.ErrManualVerdictLocked):
+		// moderator verdict wins over the automatic one
+		stored, err := p.domainSvc.GetDomain(ctx, domain)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load manual verdict: %w", err)
+		}
+		return withRedirects(fromStoredDomain(stored), chain), nil
+	default:
+		return nil, fmt.Errorf("failed to save verdict: %w", err)
+	}
 
+	return result, nil
+}
+
+// storedDomain returns the saved verdict of the domain, nil if there is none.
+func (p *DomainPipeline) storedDomain(ctx context.Context, domain string) (*entity.Domain, error) {
+	stored, err := p.domainSvc.GetDomain(ctx, domain)
+	switch {
+	case err == nil:
+		return stored, nil
+	case errors.Is(err, entity.ErrDomainNotFound):
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("failed to look up domain: %w", err)
 	}
+}
 
-	go func() {
-		wg.Wait()
-		close(resCh)
-		close(errCh)
-	}()
+// recordCheck saves the run to the check history and returns its id.
+// The history is for debugging, a failure to save it does not fail the
+// verification.
+func (p *DomainPipeline) recordCheck(ctx context.Context, result *entity.VerifyDomainResult, trigger string, startedAt time.Time) string {
+	if p.checks == nil {
+		return ""
+	}
+	if trigger == "" {
+		trigger = entity.CheckTriggerUser
+	}
+
+	check := &entity.DomainCheck{
+		Domain:        result.Domain,
+		Trigger:       trigger,
+		Status:        result.Status,
+		Reasons:       result.Reasons,
+		ModuleResults: result.ModuleResults,
+		StartedAt:     startedAt,
+		FinishedAt:    time.Now(),
+	}
+	if result.Status != entity.StatusInsufficientData {
+		score := result.RiskScore
+		check.RiskScore = &score
+	}
+
+	saved, err := p.checks.CreateCheck(ctx, check)
+	if err != nil {
+		p.log.Error(logger.ErrorCtx(ctx, err), "failed to record domain check", err, "domain", result.Domain)
+		return ""
+	}
+	return saved.ID
+}
+
+// expiresAt returns when a verdict with the given status saved now is due
+// for a recheck, nil if it never is.
+func (p *DomainPipeline) expiresAt(status string) *time.Time {
+	after, ok := p.cfg.RecheckAfter[status]
+	if !ok || after <= 0 {
+		return nil
+	}
+	t := time.Now().Add(after)
+	return &t
+}
+
+// checkerOutcome is the result of a single checker run.
+type checkerOutcome struct {
+	result   *entity.CheckerResult
+	status   string
+	err      error
+	duration time.Duration
+}
+
+// analyze runs all checkers concurrently, each under its own deadline,
+// and builds a fresh verdict from the ones that succeeded. A non-nil
+// redirect result is scored with them but does not count as a checker.
+func (p *DomainPipeline) analyze(ctx context.Context, url string, redirect *entity.CheckerResult) *entity.VerifyDomainResult {
+	outcomes := make([]checkerOutcome, len(p.checkers))
+
+	wg := &sync.WaitGroup{}
+	for i, checker := range p.checkers {
+		wg.Add(1)
+		go func(i int, checker ScamChecker) {
+			defer wg.Done()
+			outcomes[i] = p.runChecker(ctx, checker, url)
+		}(i, checker)
+	}
+	wg.Wait()
 
 	var results []*entity.CheckerResult
-	for {
-		select {
-		case result, ok := <-resCh:
-			if !ok {
-				resCh = nil
-			} else {
-				results = append(results, result)
-			}
-		case err, ok := <-errCh:
-			if !ok {
-				errCh = nil
-			} else {
-				// TODO: логировать ошибку или собирать их
-				_ = err
-			}
+	moduleResults := make([]*entity.ModuleResult, 0, len(p.checkers))
+	for i, checker := range p.checkers {
+		o := outcomes[i]
+
+		mr := &entity.ModuleResult{
+			ModuleName:  checker.Name(),
+			Description: checker.Info(),
+			Status:      o.status,
+			DurationMs:  o.duration.Milliseconds(),
 		}
 
-		if resCh == nil && errCh == nil {
-			break
+		if o.err != nil {
+			mr.Error = o.err.Error()
+			p.log.Warn(ctx, "checker failed", "module", checker.Name(), "status", o.status, "error", o.err.Error(), "domain", url)
+		} else {
+			mr.RiskScore = o.result.TotalScore
+			mr.Reasons = o.result.Reasons
+			mr.Metadata = o.result.Evidence
+			results = append(results, o.result)
 		}
+
+		moduleResults = append(moduleResults, mr)
 	}
 
 	verifyResult := &entity.VerifyDomainResult{
 		Domain:        url,
-		Status:        "unknown",
-		ScamType:      "unknown",
-		RiskScore:     CalculateRiskScore(results),
-		CompanyName:   "unknown",
-		Country:       "unknown",
-		VerifiedBy:    "bauka",
+		ScamType:      unknownValue,
+		CompanyName:   unknownValue,
+		Country:       unknownValue,
+		VerifiedBy:    pipelineVerifier,
 		VerifiedAt:    time.Now(),
-		ModuleResults: nil,
+		VerdictSource: entity.VerdictSourceAnalysis,
+		ModuleResults: moduleResults,
+	}
+
+	if len(results) < p.cfg.MinSuccessful {
+		verifyResult.Status = entity.StatusInsufficientData
+		verifyResult.Reasons = []string{
+			fmt.Sprintf("only %d of %d checkers succeeded, at least %d required", len(results), len(p.checkers), p.cfg.MinSuccessful),
+		}
+		return verifyResult
 	}
 
-	return verifyResult, nil
+	if redirect != nil {
+		results = append(results, redirect)
+		verifyResult.ModuleResults = append(verifyResult.ModuleResults, &entity.ModuleResult{
+			ModuleName:  RedirectModule,
+			Description: "redirect chain of the submitted link",
+			Status:      entity.ModuleStatusOK,
+			RiskScore:   redirect.TotalScore,
+			Reasons:     redirect.Reasons,
+			Metadata:    redirect.Evidence,
+		})
+	}
+
+	verifyResult.RiskScore = p.scorer.Score(results)
+	verifyResult.Status = p.statuses.ForScore(verifyResult.RiskScore)
+	verifyResult.Reasons = p.collectReasons(results)
+	verifyResult.ScamSources = p.collectSources(results)
+	verifyResult.CompanyName, verifyResult.Country = p.collectAttributes(results)
+	if verifyResult.Status != entity.StatusVerified {
+		verifyResult.ScamType = p.pickScamType(results)
+	}
+
+	return verifyResult
 }
 
-func CalculateRiskScore(s []*entity.CheckerResult) float64 {
-	return 0.0
+// runChecker runs a checker under its deadline. A checker that ignores
+// its context is abandoned when the deadline passes.
+func (p *DomainPipeline) runChecker(ctx context.Context, checker ScamChecker, url string) checkerOutcome {
+	timeout := p.cfg.CheckerTimeout
+	if tc, ok := checker.(TimeoutChecker); ok && tc.Timeout() > 0 {
+		timeout = tc.Timeout()
+	}
+
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type checkResult struct {
+		result *entity.CheckerResult
+		err    error
+	}
+	done := make(chan checkResult, 1)
+
+	start := time.Now()
+	go func() {
+		result, err := checker.Check(checkCtx, url)
+		done <- checkResult{result: result, err: err}
+	}()
+
+	var res checkResult
+	select {
+	case res = <-done:
+	case <-checkCtx.Done():
+		res.err = checkCtx.Err()
+	}
+
+	out := checkerOutcome{duration: time.Since(start)}
+
+	switch {
+	case res.err == nil && res.result == nil:
+		out.status = entity.ModuleStatusError
+		out.err = errors.New("checker returned no result")
+	case res.err == nil:
+		res.result.Module = checker.Name()
+		out.status = entity.ModuleStatusOK
+		out.result = res.result
+	case errors.Is(checkCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil:
+		out.status = entity.ModuleStatusTimeout
+		out.err = fmt.Errorf("timed out after %s", timeout)
+	default:
+		out.status = entity.ModuleStatusError
+		out.err = res.err
+	}
+
+	return out
+}
+
+// collectReasons merges reasons of all modules without duplicates.
+func (p *DomainPipeline) collectReasons(results []*entity.CheckerResult) []string {
+	var reasons []string
+	seen := make(map[string]struct{})
+	for _, result := range results {
+		for _, reason := range result.Reasons {
+			if _, ok := seen[reason]; ok || reason == "" {
+				continue
+			}
+			seen[reason] = struct{}{}
+			reasons = append(reasons, reason)
+		}
+	}
+	return reasons
 }
 
-// func (p *DomainPipeline) collectReasons(results []CheckResult) []string {
-// 	var reasons []string
-// 	for _, result := range results {
-// 		if len(result.Reasons) > 0 {
-// 			reasons = append(reasons, result.Reasons...)
-// 		}
-// 	}
-// 	return reasons
-// }
+// collectSources returns external sources reported by the modules
+// plus the modules that consider the domain a scam themselves.
+func (p *DomainPipeline) collectSources(results []*entity.CheckerResult) []string {
+	var sources []string
+	seen := make(map[string]struct{})
+	add := func(source string) {
+		if _, ok := seen[source]; ok || source == "" {
+			return
+		}
+		seen[source] = struct{}{}
+		sources = append(sources, source)
+	}
+
+	for _, result := range results {
+		if result.TotalScore >= scamSourceMinScore && result.Confidence > 0 {
+			add(result.Module)
+		}
+		for _, source := range result.ScamSources {
+			add(source)
+		}
+	}
+	return sources
+}
 
-// func (p *DomainPipeline) collectSources(results []CheckResult) []string {
-// 	sources := make([]string, len(results))
-// 	for i, result := range results {
-// 		sources[i] = result.Source
-// 	}
-// 	return sources
-// }
+// pickScamType takes the scam type suggested by the module
+// with the biggest contribution to the risk score.
+func (p *DomainPipeline) pickScamType(results []*entity.CheckerResult) string {
+	scamType, best := unknownValue, 0.0
+	for _, result := range results {
+		if result.ScamType == "" {
+			continue
+		}
+		contribution := p.scorer.Weight(result.Module) * result.Confidence * result.TotalScore
+		if contribution > best {
+			scamType, best = result.ScamType, contribution
+		}
+	}
+	return scamType
+}
 
-func stringPtr(s string) *string {
-	return &s
+// collectAttributes takes company and country from the most trusted module that found them.
+func (p *DomainPipeline) collectAttributes(results []*entity.CheckerResult) (company, country string) {
+	company, country = unknownValue, unknownValue
+	var companyTrust, countryTrust float64
+	for _, result := range results {
+		trust := p.scorer.Weight(result.Module) * result.Confidence
+		if result.CompanyName != "" && trust > companyTrust {
+			company, companyTrust = result.CompanyName, trust
+		}
+		if result.Country != "" && trust > countryTrust {
+			country, countryTrust = strings.ToUpper(result.Country), trust
+		}
+	}
+	return company, country
 }
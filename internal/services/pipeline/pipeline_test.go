@@ -0,0 +1,581 @@
+package pipeline
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+)
+
+type fakeDomainService struct {
+	domains map[string]*entity.Domain
+	err     error
+	upserts []*entity.UpsertDomainParams
+}
+
+func (f *fakeDomainService) UpsertDomain(_ context.Context, params *entity.UpsertDomainParams) (*entity.Domain, error) {
+	f.upserts = append(f.upserts, params)
+
+	if cur, ok := f.domains[params.Domain]; ok && !params.OverwriteManual &&
+		cur.VerificationMethod != nil && *cur.VerificationMethod == entity.VerificationManual {
+		return nil, entity.ErrManualVerdictLocked
+	}
+
+	d := &entity.Domain{
+		Domain:             params.Domain,
+		Status:             params.Status,
+		RiskScore:          params.RiskScore,
+		Reasons:            params.Reasons,
+		ScamSources:        params.ScamSources,
+		VerificationMethod: params.VerificationMethod,
+		Metadata:           params.Metadata,
+	}
+	if f.domains == nil {
+		f.domains = make(map[string]*entity.Domain)
+	}
+	f.domains[d.Domain] = d
+	return d, nil
+}
+
+func (f *fakeDomainService) GetDomain(_ context.Context, domain string) (*entity.Domain, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	d, ok := f.domains[domain]
+	if !ok {
+		return nil, entity.ErrDomainNotFound
+	}
+	return d, nil
+}
+
+var testLogger = logger.InitLogger("pipeline-test", logger.LevelError)
+
+func newTestPipeline(checkers []ScamChecker, svc DomainService) *DomainPipeline {
+	return NewDomainPipeline(checkers, svc, nil, nil, nil, nil, Config{CheckerTimeout: 50 * time.Millisecond}, testLogger)
+}
+
+type fakeChecker struct {
+	name   string
+	result *entity.CheckerResult
+	err    error
+	delay  time.Duration // sleeps ignoring ctx, like a stuck checker
+	calls  int
+	domain string // last checked domain
+}
+
+func (f *fakeChecker) Check(_ context.Context, domain string) (*entity.CheckerResult, error) {
+	f.calls++
+	f.domain = domain
+	time.Sleep(f.delay)
+	return f.result, f.err
+}
+
+func (f *fakeChecker) Name() string { return f.name }
+func (f *fakeChecker) Info() string { return "fake " + f.name }
+
+func TestProcessDomainStoredVerdict(t *testing.T) {
+	score := 95.5
+	scamType := "phishing"
+	svc := &fakeDomainService{domains: map[string]*entity.Domain{
+		"kaspi-bonus.xyz": {
+			Domain:      "kaspi-bonus.xyz",
+			Status:      "scam",
+			ScamType:    &scamType,
+			RiskScore:   &score,
+			Reasons:     []string{"reported by users"},
+			ScamSources: []string{"phishtank"},
+			Metadata: []json.RawMessage{
+				[]byte(`{"module_name":"scamdetector","risk_score":90,"metadata":{"trust":10}}`),
+				[]byte(`{"module":"dns-check","result":"blacklisted"}`),
+			},
+		},
+	}}
+	checker := &fakeChecker{name: "a", result: &entity.CheckerResult{TotalScore: 10, Confidence: 1}}
+
+	res, err := newTestPipeline([]ScamChecker{checker}, svc).ProcessDomain(context.Background(), "kaspi-bonus.xyz")
+	if err != nil {
+		t.Fatalf("ProcessDomain() unexpected error: %v", err)
+	}
+	if checker.calls != 0 {
+		t.Errorf("checker was called %d times for a stored domain", checker.calls)
+	}
+	if res.VerdictSource != entity.VerdictSourceCache {
+		t.Errorf("VerdictSource = %q; want %q", res.VerdictSource, entity.VerdictSourceCache)
+	}
+	if res.Status != "scam" || res.RiskScore != score || res.ScamType != scamType {
+		t.Errorf("unexpected verdict: %+v", res)
+	}
+	if res.CompanyName != unknownValue {
+		t.Errorf("CompanyName = %q; want %q", res.CompanyName, unknownValue)
+	}
+	if len(res.Reasons) != 1 || len(res.ScamSources) != 1 {
+		t.Errorf("Reasons = %v, ScamSources = %v; want stored values", res.Reasons, res.ScamSources)
+	}
+	if len(res.ModuleResults) != 2 {
+		t.Fatalf("ModuleResults = %d entries; want 2", len(res.ModuleResults))
+	}
+	if res.ModuleResults[0].ModuleName != "scamdetector" || res.ModuleResults[0].RiskScore != 90 {
+		t.Errorf("ModuleResults[0] = %+v", res.ModuleResults[0])
+	}
+	if res.ModuleResults[1].ModuleName != "dns-check" {
+		t.Errorf("ModuleResults[1].ModuleName = %q; want %q", res.ModuleResults[1].ModuleName, "dns-check")
+	}
+}
+
+func TestProcessDomainFreshAnalysis(t *testing.T) {
+	svc := &fakeDomainService{}
+	checker := &fakeChecker{name: "a", result: &entity.CheckerResult{TotalScore: 40, Confidence: 1}}
+
+	res, err := newTestPipeline([]ScamChecker{checker}, svc).ProcessDomain(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("ProcessDomain() unexpected error: %v", err)
+	}
+	if checker.calls != 1 {
+		t.Errorf("checker was called %d times; want 1", checker.calls)
+	}
+	if res.VerdictSource != entity.VerdictSourceAnalysis {
+		t.Errorf("VerdictSource = %q; want %q", res.VerdictSource, entity.VerdictSourceAnalysis)
+	}
+	if res.RiskScore != 40 {
+		t.Errorf("RiskScore = %v; want 40", res.RiskScore)
+	}
+
+	if len(svc.upserts) != 1 {
+		t.Fatalf("UpsertDomain called %d times; want 1", len(svc.upserts))
+	}
+	saved := svc.upserts[0]
+	if saved.Status != "suspicious" || *saved.RiskScore != 40 {
+		t.Errorf("saved status = %q, risk = %v; want suspicious, 40", saved.Status, *saved.RiskScore)
+	}
+	if *saved.VerificationMethod != entity.VerificationAutomatic {
+		t.Errorf("saved verification method = %q; want %q", *saved.VerificationMethod, entity.VerificationAutomatic)
+	}
+	if len(saved.Metadata) != 1 {
+		t.Fatalf("saved metadata = %d entries; want 1", len(saved.Metadata))
+	}
+	var mr entity.ModuleResult
+	if err := json.Unmarshal(saved.Metadata[0], &mr); err != nil || mr.ModuleName != "a" || mr.RiskScore != 40 {
+		t.Errorf("saved metadata[0] = %s", saved.Metadata[0])
+	}
+}
+
+func TestVerifyKeepsManualVerdict(t *testing.T) {
+	manual := entity.VerificationManual
+	svc := &fakeDomainService{domains: map[string]*entity.Domain{
+		"kaspi.kz": {Domain: "kaspi.kz", Status: "verified", VerificationMethod: &manual},
+	}}
+	checker := &fakeChecker{name: "a", result: &entity.CheckerResult{TotalScore: 95, Confidence: 1}}
+	p := newTestPipeline([]ScamChecker{checker}, svc)
+
+	res, err := p.Verify(context.Background(), "kaspi.kz", entity.VerifyOptions{SkipCache: true})
+	if err != nil {
+		t.Fatalf("Verify() unexpected error: %v", err)
+	}
+	if res.Status != "verified" || res.VerdictSource != entity.VerdictSourceCache {
+		t.Errorf("Verify() = %q from %q; want the manual verdict", res.Status, res.VerdictSource)
+	}
+
+	res, err = p.Verify(context.Background(), "kaspi.kz", entity.VerifyOptions{SkipCache: true, OverwriteManual: true})
+	if err != nil {
+		t.Fatalf("Verify() unexpected error: %v", err)
+	}
+	if res.Status != "scam" || svc.domains["kaspi.kz"].Status != "scam" {
+		t.Errorf("Verify() with OverwriteManual = %q; want scam to be saved", res.Status)
+	}
+}
+
+func TestVerifyRecheck(t *testing.T) {
+	automatic := entity.VerificationAutomatic
+	tests := []struct {
+		name         string
+		stored       string
+		score        float64
+		wantPrevious string
+		wantExpiry   time.Duration // 0 means the verdict never expires
+	}{
+		{name: "status changed", stored: "verified", score: 95, wantPrevious: "verified", wantExpiry: 24 * time.Hour},
+		{name: "status kept", stored: "suspicious", score: 40, wantExpiry: 6 * time.Hour},
+		{name: "no interval for status", stored: "verified", score: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &fakeDomainService{domains: map[string]*entity.Domain{
+				"kaspi-bonus.xyz": {Domain: "kaspi-bonus.xyz", Status: tt.stored, VerificationMethod: &automatic},
+			}}
+			checker := &fakeChecker{name: "a", result: &entity.CheckerResult{TotalScore: tt.score, Confidence: 1}}
+			p := NewDomainPipeline([]ScamChecker{checker}, svc, nil, nil, nil, nil, Config{
+				CheckerTimeout: 50 * time.Millisecond,
+				RecheckAfter:   map[string]time.Duration{"scam": 24 * time.Hour, "suspicious": 6 * time.Hour},
+			}, testLogger)
+
+			before := time.Now()
+			res, err := p.Verify(context.Background(), "kaspi-bonus.xyz", entity.VerifyOptions{SkipCache: true})
+			if err != nil {
+				t.Fatalf("Verify() unexpected error: %v", err)
+			}
+			if res.PreviousStatus != tt.wantPrevious {
+				t.Errorf("PreviousStatus = %q; want %q", res.PreviousStatus, tt.wantPrevious)
+			}
+
+			saved := svc.upserts[0]
+			switch {
+			case tt.wantExpiry == 0 && saved.ExpiresAt != nil:
+				t.Errorf("saved ExpiresAt = %v; want nil", saved.ExpiresAt)
+			case tt.wantExpiry == 0:
+			case saved.ExpiresAt == nil:
+				t.Error("saved ExpiresAt = nil; want a recheck time")
+			case saved.ExpiresAt.Before(before.Add(tt.wantExpiry)) || saved.ExpiresAt.After(time.Now().Add(tt.wantExpiry)):
+				t.Errorf("saved ExpiresAt = %v; want about %v from now", saved.ExpiresAt, tt.wantExpiry)
+			}
+		})
+	}
+}
+
+type fakeRecorder struct {
+	checks []*entity.DomainCheck
+	err    error
+}
+
+func (f *fakeRecorder) CreateCheck(_ context.Context, check *entity.DomainCheck) (*entity.DomainCheck, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.checks = append(f.checks, check)
+	saved := *check
+	saved.ID = fmt.Sprintf("check-%d", len(f.checks))
+	return &saved, nil
+}
+
+func TestVerifyRecordsChecks(t *testing.T) {
+	svc := &fakeDomainService{}
+	checks := &fakeRecorder{}
+	scorer := &fakeChecker{name: "a", result: &entity.CheckerResult{TotalScore: 95, Confidence: 1}}
+	broken := &fakeChecker{name: "b", err: errors.New("connection refused")}
+	p := NewDomainPipeline([]ScamChecker{scorer, broken}, svc, nil, nil, nil, checks, Config{}, testLogger)
+	ctx := context.Background()
+
+	res, err := p.Verify(ctx, "kaspi-bonus.xyz", entity.VerifyOptions{})
+	if err != nil {
+		t.Fatalf("Verify() unexpected error: %v", err)
+	}
+	if res.CheckID != "check-1" || svc.upserts[0].CheckID != "check-1" {
+		t.Errorf("CheckID = %q; want the recorded check, also saved with the verdict", res.CheckID)
+	}
+	check := checks.checks[0]
+	if check.Domain != "kaspi-bonus.xyz" || check.Trigger != entity.CheckTriggerUser || check.Status != entity.StatusScam ||
+		check.RiskScore == nil || *check.RiskScore != res.RiskScore {
+		t.Errorf("recorded check = %+v; want the scam verdict of a user run", check)
+	}
+	if len(check.ModuleResults) != 2 || check.ModuleResults[1].Error == "" {
+		t.Errorf("recorded module results = %+v; want both modules with the error", check.ModuleResults)
+	}
+	if check.StartedAt.IsZero() || check.FinishedAt.Before(check.StartedAt) {
+		t.Errorf("recorded started_at, finished_at = %v, %v", check.StartedAt, check.FinishedAt)
+	}
+
+	// a stored verdict runs no checkers and records nothing
+	if res, _ := p.Verify(ctx, "kaspi-bonus.xyz", entity.VerifyOptions{}); res.CheckID != "" || len(checks.checks) != 1 {
+		t.Errorf("stored verdict recorded a check, CheckID = %q", res.CheckID)
+	}
+
+	res, err = p.Verify(ctx, "kaspi-bonus.xyz", entity.VerifyOptions{SkipCache: true, Trigger: entity.CheckTriggerRecheck})
+	if err != nil {
+		t.Fatalf("Verify() unexpected error: %v", err)
+	}
+	if len(checks.checks) != 2 || checks.checks[1].Trigger != entity.CheckTriggerRecheck {
+		t.Errorf("recheck recorded %d checks; want one with the recheck trigger", len(checks.checks)-1)
+	}
+
+	// insufficient data is recorded although it is not stored
+	scorer.err = errors.New("timeout")
+	res, err = p.Verify(ctx, "other.xyz", entity.VerifyOptions{})
+	if err != nil {
+		t.Fatalf("Verify() unexpected error: %v", err)
+	}
+	if res.Status != entity.StatusInsufficientData || res.CheckID != "check-3" || checks.checks[2].RiskScore != nil {
+		t.Errorf("insufficient data check = %+v; want recorded without a score", checks.checks[2])
+	}
+}
+
+func TestVerifyCheckRecordFailure(t *testing.T) {
+	svc := &fakeDomainService{}
+	checker := &fakeChecker{name: "a", result: &entity.CheckerResult{TotalScore: 40, Confidence: 1}}
+	p := NewDomainPipeline([]ScamChecker{checker}, svc, nil, nil, nil, &fakeRecorder{err: errors.New("connection refused")}, Config{}, testLogger)
+
+	res, err := p.Verify(context.Background(), "example.com", entity.VerifyOptions{})
+	if err != nil {
+		t.Fatalf("Verify() unexpected error: %v", err)
+	}
+	if res.CheckID != "" || len(svc.upserts) != 1 {
+		t.Errorf("CheckID = %q, upserts = %d; want the verdict saved without a check", res.CheckID, len(svc.upserts))
+	}
+}
+
+func TestProcessDomainLookupError(t *testing.T) {
+	svc := &fakeDomainService{err: errors.New("connection refused")}
+
+	if _, err := newTestPipeline(nil, svc).ProcessDomain(context.Background(), "example.com"); err == nil {
+		t.Error("ProcessDomain() expected error, got none")
+	}
+}
+
+func TestVerifyPartialResults(t *testing.T) {
+	svc := &fakeDomainService{}
+	checkers := []ScamChecker{
+		&fakeChecker{name: "ok", result: &entity.CheckerResult{TotalScore: 80, Confidence: 1}},
+		&fakeChecker{name: "slow", result: &entity.CheckerResult{TotalScore: 0, Confidence: 1}, delay: time.Second},
+		&fakeChecker{name: "broken", err: errors.New("connection refused")},
+	}
+
+	start := time.Now()
+	res, err := newTestPipeline(checkers, svc).ProcessDomain(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("ProcessDomain() unexpected error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("ProcessDomain() took %s; slow checker was not bounded", elapsed)
+	}
+	if res.RiskScore != 80 || res.Status != entity.StatusScam {
+		t.Errorf("verdict = %v/%q; want 80/scam from the only successful checker", res.RiskScore, res.Status)
+	}
+
+	want := map[string]string{
+		"ok":     entity.ModuleStatusOK,
+		"slow":   entity.ModuleStatusTimeout,
+		"broken": entity.ModuleStatusError,
+	}
+	if len(res.ModuleResults) != len(want) {
+		t.Fatalf("ModuleResults = %d entries; want %d", len(res.ModuleResults), len(want))
+	}
+	for _, mr := range res.ModuleResults {
+		if mr.Status != want[mr.ModuleName] {
+			t.Errorf("module %q status = %q; want %q", mr.ModuleName, mr.Status, want[mr.ModuleName])
+		}
+		if mr.Status != entity.ModuleStatusOK && mr.Error == "" {
+			t.Errorf("module %q has no error message", mr.ModuleName)
+		}
+	}
+}
+
+func TestVerifyInsufficientData(t *testing.T) {
+	svc := &fakeDomainService{}
+	checkers := []ScamChecker{
+		&fakeChecker{name: "broken", err: errors.New("connection refused")},
+	}
+
+	res, err := newTestPipeline(checkers, svc).ProcessDomain(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("ProcessDomain() unexpected error: %v", err)
+	}
+	if res.Status != entity.StatusInsufficientData {
+		t.Errorf("Status = %q; want %q", res.Status, entity.StatusInsufficientData)
+	}
+	if len(res.Reasons) == 0 {
+		t.Error("insufficient data verdict has no reason")
+	}
+	if len(svc.upserts) != 0 {
+		t.Errorf("insufficient data verdict was saved %d times", len(svc.upserts))
+	}
+}
+
+func TestVerifyAggregatesModuleDetails(t *testing.T) {
+	svc := &fakeDomainService{}
+	checkers := []ScamChecker{
+		&fakeChecker{name: "feeds", result: &entity.CheckerResult{
+			TotalScore:  100,
+			Confidence:  1,
+			Reasons:     []string{"listed in phishtank"},
+			ScamSources: []string{"phishtank"},
+			ScamType:    "phishing",
+			Evidence:    map[string]any{"feeds": []string{"phishtank"}},
+		}},
+		&fakeChecker{name: "content", result: &entity.CheckerResult{
+			TotalScore: 60,
+			Confidence: 0.5,
+			Reasons:    []string{"password form", "listed in phishtank"},
+			ScamType:   "fraud",
+		}},
+		&fakeChecker{name: "whois", result: &entity.CheckerResult{
+			TotalScore:  20,
+			Confidence:  1,
+			CompanyName: "Kaspi Bonus LLC",
+			Country:     "kz",
+		}},
+	}
+
+	res, err := newTestPipeline(checkers, svc).ProcessDomain(context.Background(), "kaspi-bonus.xyz")
+	if err != nil {
+		t.Fatalf("ProcessDomain() unexpected error: %v", err)
+	}
+
+	wantReasons := []string{"listed in phishtank", "password form"}
+	if len(res.Reasons) != len(wantReasons) || res.Reasons[0] != wantReasons[0] || res.Reasons[1] != wantReasons[1] {
+		t.Errorf("Reasons = %v; want %v", res.Reasons, wantReasons)
+	}
+	wantSources := []string{"feeds", "phishtank"}
+	if len(res.ScamSources) != len(wantSources) || res.ScamSources[0] != wantSources[0] || res.ScamSources[1] != wantSources[1] {
+		t.Errorf("ScamSources = %v; want %v", res.ScamSources, wantSources)
+	}
+	if res.ScamType != "phishing" {
+		t.Errorf("ScamType = %q; want phishing", res.ScamType)
+	}
+	if res.CompanyName != "Kaspi Bonus LLC" || res.Country != "KZ" {
+		t.Errorf("CompanyName, Country = %q, %q; want Kaspi Bonus LLC, KZ", res.CompanyName, res.Country)
+	}
+	if res.ModuleResults[0].Metadata["feeds"] == nil || len(res.ModuleResults[1].Reasons) != 2 {
+		t.Errorf("module details were not copied: %+v, %+v", res.ModuleResults[0], res.ModuleResults[1])
+	}
+
+	saved := svc.upserts[0]
+	if saved.ScamType == nil || *saved.ScamType != "phishing" || saved.Country == nil || *saved.Country != "KZ" {
+		t.Errorf("saved scam type / country = %v / %v", saved.ScamType, saved.Country)
+	}
+	if len(saved.Reasons) != 2 || len(saved.ScamSources) != 2 {
+		t.Errorf("saved reasons / sources = %v / %v", saved.Reasons, saved.ScamSources)
+	}
+}
+
+type fakeFollower struct {
+	chain *entity.RedirectChain
+	err   error
+	calls int
+}
+
+func (f *fakeFollower) Follow(context.Context, string) (*entity.RedirectChain, error) {
+	f.calls++
+	return f.chain, f.err
+}
+
+func TestVerifyFollowsRedirects(t *testing.T) {
+	chain := &entity.RedirectChain{Hops: []entity.RedirectHop{
+		{URL: "https://bit.ly/abc", Host: "bit.ly", StatusCode: 301, Kind: entity.RedirectHTTP},
+		{URL: "http://x.top/go", Host: "x.top", StatusCode: 200, Kind: entity.RedirectMeta},
+		{URL: "http://kaspi-bonus.xyz/", Host: "kaspi-bonus.xyz", StatusCode: 200},
+	}}
+
+	tests := []struct {
+		name       string
+		follower   RedirectFollower
+		wantDomain string
+		wantPath   string
+		wantScore  float64
+	}{
+		{
+			name:       "cross-site chain",
+			follower:   &fakeFollower{chain: chain},
+			wantDomain: "kaspi-bonus.xyz",
+			wantPath:   "bit.ly → x.top → kaspi-bonus.xyz",
+			// checker 40 and redirect 40+10+10 with confidence 0.7, equal weights
+			wantScore: (40 + 60*redirectConfidence) / (1 + redirectConfidence),
+		},
+		{
+			name: "same-site chain",
+			follower: &fakeFollower{chain: &entity.RedirectChain{Hops: []entity.RedirectHop{
+				{URL: "https://bit.ly/", Host: "bit.ly", Kind: entity.RedirectHTTP},
+				{URL: "https://www.bit.ly/", Host: "www.bit.ly"},
+			}}},
+			wantDomain: "bit.ly",
+			wantPath:   "bit.ly → www.bit.ly",
+			wantScore:  40,
+		},
+		{
+			name:       "follower failed",
+			follower:   &fakeFollower{err: errors.New("connection refused")},
+			wantDomain: "bit.ly",
+			wantScore:  40,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &fakeDomainService{}
+			checker := &fakeChecker{name: "a", result: &entity.CheckerResult{TotalScore: 40, Confidence: 1}}
+			p := NewDomainPipeline([]ScamChecker{checker}, svc, nil, nil, tc.follower, nil, Config{}, testLogger)
+
+			res, err := p.ProcessDomain(context.Background(), "https://bit.ly/abc")
+			if err != nil {
+				t.Fatalf("ProcessDomain() unexpected error: %v", err)
+			}
+
+			if checker.domain != tc.wantDomain || res.Domain != tc.wantDomain {
+				t.Errorf("checked %q, result domain %q; want %q", checker.domain, res.Domain, tc.wantDomain)
+			}
+			if res.RedirectPath != tc.wantPath {
+				t.Errorf("RedirectPath = %q; want %q", res.RedirectPath, tc.wantPath)
+			}
+			if math.Abs(res.RiskScore-tc.wantScore) > 0.01 {
+				t.Errorf("RiskScore = %v; want %v", res.RiskScore, tc.wantScore)
+			}
+			if len(svc.upserts) != 1 || svc.upserts[0].Domain != tc.wantDomain {
+				t.Errorf("verdict was not saved for %q", tc.wantDomain)
+			}
+		})
+	}
+}
+
+func TestVerifyRedirectToStoredDomain(t *testing.T) {
+	score := 90.0
+	svc := &fakeDomainService{domains: map[string]*entity.Domain{
+		"kaspi-bonus.xyz": {Domain: "kaspi-bonus.xyz", Status: entity.StatusScam, RiskScore: &score},
+	}}
+	chain := &entity.RedirectChain{Hops: []entity.RedirectHop{
+		{URL: "https://bit.ly/abc", Host: "bit.ly", Kind: entity.RedirectHTTP},
+		{URL: "https://kaspi-bonus.xyz/", Host: "kaspi-bonus.xyz"},
+	}}
+	p := NewDomainPipeline(nil, svc, nil, nil, &fakeFollower{chain: chain}, nil, Config{}, testLogger)
+
+	res, err := p.ProcessDomain(context.Background(), "bit.ly/abc")
+	if err != nil {
+		t.Fatalf("ProcessDomain() unexpected error: %v", err)
+	}
+	if res.VerdictSource != entity.VerdictSourceCache || res.Status != entity.StatusScam {
+		t.Errorf("verdict = %s from %s; want the stored scam verdict", res.Status, res.VerdictSource)
+	}
+	if res.SubmittedDomain != "bit.ly" || res.RedirectPath != "bit.ly → kaspi-bonus.xyz" {
+		t.Errorf("SubmittedDomain = %q, RedirectPath = %q", res.SubmittedDomain, res.RedirectPath)
+	}
+}
+
+func TestVerifyStoredDomainSkipsRedirects(t *testing.T) {
+	score := 10.0
+	chain := &entity.RedirectChain{Hops: []entity.RedirectHop{
+		{URL: "https://shop.kz/", Host: "shop.kz", Kind: entity.RedirectHTTP},
+		{URL: "https://shop-kz.top/", Host: "shop-kz.top"},
+	}}
+
+	tests := []struct {
+		name      string
+		target    string
+		opts      entity.VerifyOptions
+		wantCalls int
+	}{
+		{name: "bare domain", target: "shop.kz"},
+		{name: "url", target: "https://shop.kz/", wantCalls: 1},
+		{name: "forced run", target: "shop.kz", opts: entity.VerifyOptions{SkipCache: true}, wantCalls: 1},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &fakeDomainService{domains: map[string]*entity.Domain{
+				"shop.kz": {Domain: "shop.kz", Status: entity.StatusVerified, RiskScore: &score},
+			}}
+			follower := &fakeFollower{chain: chain}
+			checker := &fakeChecker{name: "a", result: &entity.CheckerResult{TotalScore: 40, Confidence: 1}}
+			p := NewDomainPipeline([]ScamChecker{checker}, svc, nil, nil, follower, nil, Config{}, testLogger)
+
+			if _, err := p.Verify(context.Background(), tc.target, tc.opts); err != nil {
+				t.Fatalf("Verify() unexpected error: %v", err)
+			}
+			if follower.calls != tc.wantCalls {
+				t.Errorf("redirects followed %d times; want %d", follower.calls, tc.wantCalls)
+			}
+		})
+	}
+}
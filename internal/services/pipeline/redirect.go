@@ -0,0 +1,123 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/utils"
+)
+
+// RedirectModule names the redirect signal in weights and module results.
+const RedirectModule = "redirect"
+
+const (
+	redirectConfidence   = 0.7
+	crossDomainScore     = 40.0
+	shortenerScore       = 10.0
+	clientRedirectScore  = 10.0
+	redirectLimitScore   = 20.0
+	maxRedirectHopsScore = 30.0
+)
+
+// shorteners hide the real destination, legitimate sites rarely need them
+var shorteners = map[string]struct{}{
+	"bit.ly": {}, "tinyurl.com": {}, "t.co": {}, "goo.gl": {}, "ow.ly": {}, "is.gd": {},
+	"cutt.ly": {}, "rb.gy": {}, "shorturl.at": {}, "tiny.cc": {}, "clck.ru": {}, "goo.su": {},
+	"surl.li": {}, "u.to": {}, "vk.cc": {}, "bit.do": {}, "rebrand.ly": {}, "t.ly": {},
+}
+
+// RedirectFollower resolves a submitted URL to its landing page.
+type RedirectFollower interface {
+	Follow(ctx context.Context, rawURL string) (*entity.RedirectChain, error)
+}
+
+// followRedirects returns the chain of the submitted URL, nil when
+// redirects are not followed or the URL cannot be opened.
+func (p *DomainPipeline) followRedirects(ctx context.Context, target string) *entity.RedirectChain {
+	if p.redirects == nil {
+		return nil
+	}
+
+	chain, err := p.redirects.Follow(ctx, target)
+	if err != nil {
+		// the checkers still get the submitted domain
+		p.log.Warn(ctx, "failed to follow redirects", "target", target, "error", err.Error())
+		return nil
+	}
+	return chain
+}
+
+// landingDomain returns the domain to check for the chain: the landing
+// host when the chain leaves the submitted site, the submitted one otherwise.
+func landingDomain(submitted string, chain *entity.RedirectChain) string {
+	if !chain.Redirected() || registrable(chain.Hops[0].Host) == registrable(chain.Final().Host) {
+		return submitted
+	}
+	return chain.Final().Host
+}
+
+// redirectResult scores a chain that leaves the submitted registrable domain.
+// It returns nil when the chain stays on the same site.
+func redirectResult(chain *entity.RedirectChain) *entity.CheckerResult {
+	if !chain.Redirected() {
+		return nil
+	}
+
+	first, final := registrable(chain.Hops[0].Host), registrable(chain.Final().Host)
+	if first == final && !chain.LimitReached {
+		return nil
+	}
+
+	res := &entity.CheckerResult{
+		Module:     RedirectModule,
+		Confidence: redirectConfidence,
+		Evidence: map[string]any{
+			"path":       chain.Path(),
+			"hops":       len(chain.Hops),
+			"submitted":  first,
+			"landing":    final,
+			"limit_hits": chain.LimitReached,
+		},
+	}
+
+	if first != final {
+		res.TotalScore += crossDomainScore
+		res.Reasons = append(res.Reasons, fmt.Sprintf("link redirects to another site: %s", chain.Path()))
+	}
+
+	var hopsScore float64
+	for _, hop := range chain.Hops {
+		if _, ok := shorteners[hop.Host]; ok && hop.Kind != "" {
+			hopsScore += shortenerScore
+			res.Reasons = append(res.Reasons, fmt.Sprintf("link goes through the %s URL shortener", hop.Host))
+		}
+		if hop.Kind == entity.RedirectMeta || hop.Kind == entity.RedirectJS {
+			hopsScore += clientRedirectScore
+			res.Reasons = append(res.Reasons, fmt.Sprintf("%s redirects with %s instead of HTTP", hop.Host, hopKindName(hop.Kind)))
+		}
+	}
+	res.TotalScore += min(hopsScore, maxRedirectHopsScore)
+
+	if chain.LimitReached {
+		res.TotalScore += redirectLimitScore
+		res.Reasons = append(res.Reasons, "redirect chain loops or is too long")
+	}
+
+	res.TotalScore = min(res.TotalScore, maxRiskScore)
+	return res
+}
+
+func hopKindName(kind string) string {
+	if kind == entity.RedirectMeta {
+		return "a meta refresh"
+	}
+	return "JavaScript"
+}
+
+func registrable(host string) string {
+	if r, err := utils.RegistrableDomain(host); err == nil {
+		return r
+	}
+	return host
+}
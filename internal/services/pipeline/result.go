@@ -0,0 +1,135 @@
+package pipeline
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+)
+
+const (
+	unknownValue     = "unknown"
+	pipelineVerifier = "pipeline"
+)
+
+// fromStoredDomain builds a verdict from a domain that is already in the list.
+func fromStoredDomain(d *entity.Domain) *entity.VerifyDomainResult {
+	var verifiedAt time.Time
+	switch {
+	case d.UpdatedAt != nil:
+		verifiedAt = *d.UpdatedAt
+	case d.CreatedAt != nil:
+		verifiedAt = *d.CreatedAt
+	}
+
+	var riskScore float64
+	if d.RiskScore != nil {
+		riskScore = *d.RiskScore
+	}
+
+	return &entity.VerifyDomainResult{
+		Domain:        d.Domain,
+		Status:        d.Status,
+		ScamType:      valueOr(d.ScamType, unknownValue),
+		RiskScore:     riskScore,
+		CompanyName:   valueOr(d.CompanyName, unknownValue),
+		Country:       valueOr(d.Country, unknownValue),
+		VerifiedBy:    valueOr(d.VerifiedBy, unknownValue),
+		VerifiedAt:    verifiedAt,
+		Reasons:       d.Reasons,
+		ScamSources:   d.ScamSources,
+		VerdictSource: entity.VerdictSourceCache,
+		ModuleResults: decodeModuleResults(d.Metadata),
+	}
+}
+
+// decodeModuleResults reads module results stored in the metadata JSONB array.
+// Entries that are not module results are kept as raw metadata.
+func decodeModuleResults(metadata []json.RawMessage) []*entity.ModuleResult {
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	out := make([]*entity.ModuleResult, 0, len(metadata))
+	for _, raw := range metadata {
+		var mr entity.ModuleResult
+		if err := json.Unmarshal(raw, &mr); err == nil && mr.ModuleName != "" {
+			out = append(out, &mr)
+			continue
+		}
+
+		var fields map[string]any
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			continue
+		}
+		name, _ := fields["module"].(string)
+		out = append(out, &entity.ModuleResult{
+			ModuleName: name,
+			Metadata:   fields,
+		})
+	}
+
+	return out
+}
+
+// withRedirects adds the chain of a submitted URL that left its host.
+func withRedirects(r *entity.VerifyDomainResult, chain *entity.RedirectChain) *entity.VerifyDomainResult {
+	if !chain.Redirected() {
+		return r
+	}
+
+	if first := chain.Hops[0].Host; first != r.Domain {
+		r.SubmittedDomain = first
+	}
+	r.RedirectPath = chain.Path()
+	r.RedirectChain = chain
+	return r
+}
+
+func valueOr(s *string, def string) string {
+	if s == nil || *s == "" {
+		return def
+	}
+	return *s
+}
+
+// toUpsertParams converts a fresh verdict into a row for the domains table.
+// Module results are stored one per element of the metadata JSONB array.
+func toUpsertParams(r *entity.VerifyDomainResult, overwriteManual bool, expiresAt *time.Time) *entity.UpsertDomainParams {
+	metadata := make([]json.RawMessage, 0, len(r.ModuleResults))
+	for _, mr := range r.ModuleResults {
+		raw, err := json.Marshal(mr)
+		if err != nil {
+			continue
+		}
+		metadata = append(metadata, raw)
+	}
+
+	riskScore := r.RiskScore
+	verificationMethod := entity.VerificationAutomatic
+
+	return &entity.UpsertDomainParams{
+		CreateDomainParams: entity.CreateDomainParams{
+			Domain:             r.Domain,
+			Status:             r.Status,
+			CompanyName:        knownOrNil(r.CompanyName),
+			Country:            knownOrNil(r.Country),
+			ScamSources:        r.ScamSources,
+			ScamType:           knownOrNil(r.ScamType),
+			VerifiedBy:         knownOrNil(r.VerifiedBy),
+			VerificationMethod: &verificationMethod,
+			RiskScore:          &riskScore,
+			Reasons:            r.Reasons,
+			Metadata:           metadata,
+		},
+		OverwriteManual: overwriteManual,
+		ExpiresAt:       expiresAt,
+	}
+}
+
+func knownOrNil(s string) *string {
+	if s == "" || s == unknownValue {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,89 @@
+package pipeline
+
+import (
+	"math"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+)
+
+const (
+	minRiskScore = 0.0
+	maxRiskScore = 100.0
+)
+
+// defaultScorer weights every checker equally.
+var defaultScorer = NewRiskScorer(nil, 1)
+
+// RiskScorer combines checker results into a single risk score.
+//
+// Every result contributes its score multiplied by the checker weight and
+// its own confidence. The sum is normalized by the total contributing weight,
+// so missing or failed checkers do not drag the score towards zero.
+type RiskScorer struct {
+	weights       map[string]float64
+	defaultWeight float64
+}
+
+// NewRiskScorer creates a scorer with per-checker weights. Checkers without
+// an explicit weight use defaultWeight.
+func NewRiskScorer(weights map[string]float64, defaultWeight float64) *RiskScorer {
+	w := make(map[string]float64, len(weights))
+	for name, v := range weights {
+		w[name] = v
+	}
+
+	return &RiskScorer{
+		weights:       w,
+		defaultWeight: defaultWeight,
+	}
+}
+
+// Weight returns the weight used for the given checker.
+func (s *RiskScorer) Weight(module string) float64 {
+	if w, ok := s.weights[module]; ok {
+		return w
+	}
+	return s.defaultWeight
+}
+
+// Score returns a risk score from 0 to 100 rounded to 2 decimal places,
+// which fits the risk_score DECIMAL(5,2) column.
+// Nil results and results with zero weight or confidence are ignored.
+// If nothing contributes, the score is 0.
+func (s *RiskScorer) Score(results []*entity.CheckerResult) float64 {
+	var weighted, total float64
+
+	for _, r := range results {
+		if r == nil || math.IsNaN(r.TotalScore) || math.IsNaN(r.Confidence) {
+			continue
+		}
+
+		w := s.Weight(r.Module)
+		c := clamp(r.Confidence, 0, 1)
+		if w <= 0 || c == 0 {
+			continue
+		}
+
+		weighted += w * c * clamp(r.TotalScore, minRiskScore, maxRiskScore)
+		total += w * c
+	}
+
+	if total == 0 {
+		return 0
+	}
+
+	return roundScore(weighted / total)
+}
+
+// CalculateRiskScore scores results with equal weights for all checkers.
+func CalculateRiskScore(results []*entity.CheckerResult) float64 {
+	return defaultScorer.Score(results)
+}
+
+func clamp(v, lo, hi float64) float64 {
+	return math.Max(lo, math.Min(hi, v))
+}
+
+func roundScore(v float64) float64 {
+	return math.Round(clamp(v, minRiskScore, maxRiskScore)*100) / 100
+}
@@ -0,0 +1,123 @@
+package pipeline
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+)
+
+func TestRiskScorerScore(t *testing.T) {
+	res := func(module string, score, confidence float64) *entity.CheckerResult {
+		return &entity.CheckerResult{Module: module, TotalScore: score, Confidence: confidence}
+	}
+
+	tests := []struct {
+		name          string
+		weights       map[string]float64
+		defaultWeight float64
+		results       []*entity.CheckerResult
+		want          float64
+	}{
+		{
+			name:          "no results",
+			defaultWeight: 1,
+			want:          0,
+		},
+		{
+			name:          "only failed checkers",
+			defaultWeight: 1,
+			results:       []*entity.CheckerResult{nil, nil},
+			want:          0,
+		},
+		{
+			name:          "single checker",
+			defaultWeight: 1,
+			results:       []*entity.CheckerResult{res("scamdetector", 80, 1)},
+			want:          80,
+		},
+		{
+			name:          "equal weights",
+			defaultWeight: 1,
+			results:       []*entity.CheckerResult{res("a", 80, 1), res("b", 20, 1)},
+			want:          50,
+		},
+		{
+			name:          "configured weights",
+			weights:       map[string]float64{"scamdetector": 3, "lexical": 1},
+			defaultWeight: 1,
+			results:       []*entity.CheckerResult{res("scamdetector", 90, 1), res("lexical", 10, 1)},
+			want:          70,
+		},
+		{
+			name:          "confidence lowers influence",
+			defaultWeight: 1,
+			results:       []*entity.CheckerResult{res("a", 100, 0.5), res("b", 0, 1)},
+			want:          33.33,
+		},
+		{
+			name:          "zero confidence is ignored",
+			defaultWeight: 1,
+			results:       []*entity.CheckerResult{res("a", 100, 0), res("b", 40, 1)},
+			want:          40,
+		},
+		{
+			name:          "missing checker does not pull score down",
+			defaultWeight: 1,
+			results:       []*entity.CheckerResult{nil, res("a", 70, 1)},
+			want:          70,
+		},
+		{
+			name:          "out of range values are clamped",
+			defaultWeight: 1,
+			results:       []*entity.CheckerResult{res("a", 150, 2), res("b", -10, 1)},
+			want:          50,
+		},
+		{
+			name:          "unknown module uses default weight",
+			weights:       map[string]float64{"a": 2},
+			defaultWeight: 0.5,
+			results:       []*entity.CheckerResult{res("a", 60, 1), res("b", 0, 1)},
+			want:          48,
+		},
+		{
+			name:          "zero weight disables checker",
+			weights:       map[string]float64{"a": 0},
+			defaultWeight: 1,
+			results:       []*entity.CheckerResult{res("a", 100, 1), res("b", 30, 1)},
+			want:          30,
+		},
+		{
+			name:          "NaN score is ignored",
+			defaultWeight: 1,
+			results:       []*entity.CheckerResult{res("a", math.NaN(), 1), res("b", 25, 1)},
+			want:          25,
+		},
+		{
+			name:          "rounded to two decimals",
+			weights:       map[string]float64{"b": 2},
+			defaultWeight: 1,
+			results:       []*entity.CheckerResult{res("a", 100, 1), res("b", 0, 1)},
+			want:          33.33,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NewRiskScorer(tc.weights, tc.defaultWeight).Score(tc.results)
+			if got != tc.want {
+				t.Errorf("Score() = %v; want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCalculateRiskScore(t *testing.T) {
+	got := CalculateRiskScore([]*entity.CheckerResult{
+		{Module: "a", TotalScore: 10, Confidence: 1},
+		{Module: "b", TotalScore: 30, Confidence: 1},
+	})
+	if got != 20 {
+		t.Errorf("CalculateRiskScore() = %v; want 20", got)
+	}
+}
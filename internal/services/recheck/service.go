@@ -0,0 +1,88 @@
+package recheck
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+)
+
+const (
+	defaultBatch = 100
+	defaultLease = time.Hour
+)
+
+type Repository interface {
+	GetDomainsForRecheck(ctx context.Context, arg *entity.GetDomainsForRecheckParams) ([]*entity.Domain, error)
+}
+
+// Queue runs the rechecks, the pipeline saves their verdicts and reports
+// domains whose status changed.
+type Queue interface {
+	Enqueue(ctx context.Context, target, trigger string) (*entity.VerifyJob, error)
+}
+
+type Config struct {
+	Batch int // domains queued per run at most
+	// a queued domain is not selected again for this long, so a recheck
+	// that fails or is still queued is retried only after it
+	Lease time.Duration
+}
+
+// Service queues background rechecks of automatic verdicts whose
+// expires_at has passed. Manual verdicts and feed imports do not expire.
+// RunOnce is run by the cron scheduler, a single batch per run keeps a
+// large backlog from flooding the queue ahead of user requests.
+type Service struct {
+	repo  Repository
+	queue Queue
+	cfg   Config
+	log   logger.Logger
+}
+
+func NewService(repo Repository, queue Queue, cfg Config, log logger.Logger) *Service {
+	if cfg.Batch <= 0 {
+		cfg.Batch = defaultBatch
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = defaultLease
+	}
+
+	return &Service{
+		repo:  repo,
+		queue: queue,
+		cfg:   cfg,
+		log:   log,
+	}
+}
+
+// RunOnce queues rechecks of up to Batch expired domains and returns
+// how many were queued.
+func (s *Service) RunOnce(ctx context.Context) (int, error) {
+	ctx = logger.WithAction(ctx, "domain_recheck")
+
+	domains, err := s.repo.GetDomainsForRecheck(ctx, &entity.GetDomainsForRecheckParams{
+		Now:   time.Now(),
+		Lease: s.cfg.Lease,
+		Limit: int32(s.cfg.Batch),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to select domains for recheck: %w", err)
+	}
+
+	queued := 0
+	for _, d := range domains {
+		if _, err := s.queue.Enqueue(ctx, d.Domain, entity.CheckTriggerRecheck); err != nil {
+			// the remaining domains are selected again once their lease expires
+			return queued, fmt.Errorf("failed to queue recheck of %s: %w", d.Domain, err)
+		}
+		queued++
+	}
+	if queued > 0 {
+		s.log.Info(ctx, "domain rechecks queued", "queued", queued)
+	}
+
+	return queued, nil
+}
@@ -0,0 +1,110 @@
+package recheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+	"github.com/ItsXomyak/scam-list/pkg/logger"
+)
+
+var testLogger = logger.InitLogger("recheck-test", logger.LevelError)
+
+type fakeRepo struct {
+	domains []*entity.Domain
+	err     error
+	params  []*entity.GetDomainsForRecheckParams
+}
+
+func (r *fakeRepo) GetDomainsForRecheck(_ context.Context, arg *entity.GetDomainsForRecheckParams) ([]*entity.Domain, error) {
+	r.params = append(r.params, arg)
+	if r.err != nil {
+		return nil, r.err
+	}
+	n := min(len(r.domains), int(arg.Limit))
+	out := r.domains[:n]
+	r.domains = r.domains[n:]
+	return out, nil
+}
+
+type fakeQueue struct {
+	jobs   []*entity.VerifyJob
+	failOn string
+}
+
+func (q *fakeQueue) Enqueue(_ context.Context, target, trigger string) (*entity.VerifyJob, error) {
+	if target == q.failOn {
+		return nil, errors.New("connection refused")
+	}
+	job := &entity.VerifyJob{Target: target, Trigger: trigger}
+	q.jobs = append(q.jobs, job)
+	return job, nil
+}
+
+func domains(names ...string) []*entity.Domain {
+	out := make([]*entity.Domain, len(names))
+	for i, name := range names {
+		out[i] = &entity.Domain{Domain: name, Status: entity.StatusSuspicious}
+	}
+	return out
+}
+
+func TestRunOnce(t *testing.T) {
+	repo := &fakeRepo{domains: domains("a.xyz", "b.xyz", "c.xyz")}
+	queue := &fakeQueue{}
+	svc := NewService(repo, queue, Config{Batch: 2, Lease: 30 * time.Minute}, testLogger)
+
+	queued, err := svc.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("RunOnce() unexpected error: %v", err)
+	}
+	if queued != 2 {
+		t.Errorf("RunOnce() queued %d; want a batch of 2", queued)
+	}
+	for _, job := range queue.jobs {
+		if job.Trigger != entity.CheckTriggerRecheck {
+			t.Errorf("queued %+v; want the recheck trigger", job)
+		}
+	}
+	if p := repo.params[0]; p.Limit != 2 || p.Lease != 30*time.Minute || p.Now.IsZero() {
+		t.Errorf("GetDomainsForRecheck() params = %+v", p)
+	}
+
+	queued, err = svc.RunOnce(context.Background())
+	if err != nil || queued != 1 {
+		t.Errorf("second RunOnce() = %d, %v; want the remaining domain", queued, err)
+	}
+}
+
+func TestRunOnceErrors(t *testing.T) {
+	t.Run("select", func(t *testing.T) {
+		svc := NewService(&fakeRepo{err: errors.New("connection refused")}, &fakeQueue{}, Config{}, testLogger)
+		if _, err := svc.RunOnce(context.Background()); err == nil {
+			t.Error("RunOnce() expected error, got none")
+		}
+	})
+
+	t.Run("enqueue", func(t *testing.T) {
+		queue := &fakeQueue{failOn: "b.xyz"}
+		svc := NewService(&fakeRepo{domains: domains("a.xyz", "b.xyz", "c.xyz")}, queue, Config{}, testLogger)
+
+		queued, err := svc.RunOnce(context.Background())
+		if err == nil {
+			t.Fatal("RunOnce() expected error, got none")
+		}
+		if queued != 1 || len(queue.jobs) != 1 {
+			t.Errorf("RunOnce() queued %d; want 1 before the failure", queued)
+		}
+	})
+}
+
+func TestNewServiceDefaults(t *testing.T) {
+	svc := NewService(&fakeRepo{}, &fakeQueue{}, Config{}, testLogger)
+
+	want := Config{Batch: defaultBatch, Lease: defaultLease}
+	if svc.cfg != want {
+		t.Errorf("cfg = %+v; want %+v", svc.cfg, want)
+	}
+}
@@ -0,0 +1,97 @@
+package status
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+)
+
+// OverrideModule is the module name of the metadata entry that records
+// an admin override of the derived status.
+const OverrideModule = "status_override"
+
+var ErrInvalidThresholds = errors.New("status thresholds must satisfy 0 <= verified < suspicious <= 100")
+
+// Policy maps risk_score to domain status.
+// score <= VerifiedMax is verified, score <= SuspiciousMax is suspicious, the rest is scam.
+type Policy struct {
+	verifiedMax   float64
+	suspiciousMax float64
+}
+
+var defaultPolicy = &Policy{verifiedMax: 30, suspiciousMax: 70}
+
+// NewPolicy creates a policy with the given upper bounds of the verified and suspicious bands.
+func NewPolicy(verifiedMax, suspiciousMax float64) (*Policy, error) {
+	if verifiedMax < 0 || verifiedMax >= suspiciousMax || suspiciousMax > 100 {
+		return nil, fmt.Errorf("%w: got %v and %v", ErrInvalidThresholds, verifiedMax, suspiciousMax)
+	}
+
+	return &Policy{verifiedMax: verifiedMax, suspiciousMax: suspiciousMax}, nil
+}
+
+// Default returns the bands from docs/DataBase/tables.md: 0-30 verified, 30-70 suspicious, 70-100 scam.
+func Default() *Policy {
+	return defaultPolicy
+}
+
+// ForScore returns the status for a risk score.
+func (p *Policy) ForScore(score float64) string {
+	switch {
+	case score <= p.verifiedMax:
+		return entity.StatusVerified
+	case score <= p.suspiciousMax:
+		return entity.StatusSuspicious
+	default:
+		return entity.StatusScam
+	}
+}
+
+// Resolve picks the status to store for an admin request. Without a risk
+// score the requested status is taken as is. With a risk score the derived
+// status is used, and a different requested status is accepted only with
+// override set. overridden reports that the derived status was replaced.
+func (p *Policy) Resolve(requested string, score *float64, override bool) (status string, overridden bool, err error) {
+	if score == nil {
+		if requested == "" {
+			return "", false, entity.ErrStatusRequired
+		}
+		return requested, false, nil
+	}
+
+	derived := p.ForScore(*score)
+	switch {
+	case requested == "" || requested == derived:
+		return derived, false, nil
+	case override:
+		return requested, true, nil
+	default:
+		return "", false, fmt.Errorf("%w: risk_score %.2f gives %q, set status_override to store %q",
+			entity.ErrStatusMismatch, *score, derived, requested)
+	}
+}
+
+// OverrideRecord builds the metadata entry kept next to the module results
+// when an admin overrides the derived status.
+func (p *Policy) OverrideRecord(status string, score float64, by string, at time.Time) (json.RawMessage, error) {
+	record := entity.ModuleResult{
+		ModuleName:  OverrideModule,
+		Description: "status set by admin instead of the one derived from risk_score",
+		RiskScore:   score,
+		Metadata: map[string]any{
+			"status":         status,
+			"derived_status": p.ForScore(score),
+			"overridden_by":  by,
+			"overridden_at":  at.UTC().Format(time.RFC3339),
+		},
+	}
+
+	raw, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal status override: %w", err)
+	}
+	return raw, nil
+}
@@ -0,0 +1,126 @@
+package status
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ItsXomyak/scam-list/internal/domain/entity"
+)
+
+func ptr(f float64) *float64 { return &f }
+
+func TestNewPolicy(t *testing.T) {
+	tests := []struct {
+		verified, suspicious float64
+		wantErr              bool
+	}{
+		{verified: 30, suspicious: 70},
+		{verified: 0, suspicious: 100},
+		{verified: 50, suspicious: 50, wantErr: true},
+		{verified: 70, suspicious: 30, wantErr: true},
+		{verified: -1, suspicious: 70, wantErr: true},
+		{verified: 30, suspicious: 101, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		_, err := NewPolicy(tc.verified, tc.suspicious)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("NewPolicy(%v, %v) error = %v; wantErr %v", tc.verified, tc.suspicious, err, tc.wantErr)
+		}
+		if err != nil && !errors.Is(err, ErrInvalidThresholds) {
+			t.Errorf("NewPolicy(%v, %v) error = %v; want %v", tc.verified, tc.suspicious, err, ErrInvalidThresholds)
+		}
+	}
+}
+
+func TestForScore(t *testing.T) {
+	tests := []struct {
+		score float64
+		want  string
+	}{
+		{score: 0, want: entity.StatusVerified},
+		{score: 20, want: entity.StatusVerified},
+		{score: 30, want: entity.StatusVerified},
+		{score: 30.01, want: entity.StatusSuspicious},
+		{score: 70, want: entity.StatusSuspicious},
+		{score: 70.01, want: entity.StatusScam},
+		{score: 100, want: entity.StatusScam},
+	}
+
+	for _, tc := range tests {
+		if got := Default().ForScore(tc.score); got != tc.want {
+			t.Errorf("ForScore(%v) = %q; want %q", tc.score, got, tc.want)
+		}
+	}
+
+	strict, err := NewPolicy(10, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strict.ForScore(25); got != entity.StatusSuspicious {
+		t.Errorf("ForScore(25) with 10/40 bands = %q; want %q", got, entity.StatusSuspicious)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	tests := []struct {
+		name       string
+		requested  string
+		score      *float64
+		override   bool
+		want       string
+		overridden bool
+		wantErr    error
+	}{
+		{name: "status only", requested: entity.StatusScam, want: entity.StatusScam},
+		{name: "nothing", wantErr: entity.ErrStatusRequired},
+		{name: "derived", score: ptr(55), want: entity.StatusSuspicious},
+		{name: "matching status", requested: entity.StatusVerified, score: ptr(10), want: entity.StatusVerified},
+		{name: "matching status with override", requested: entity.StatusVerified, score: ptr(10), override: true, want: entity.StatusVerified},
+		{name: "mismatch", requested: entity.StatusVerified, score: ptr(95), wantErr: entity.ErrStatusMismatch},
+		{name: "override", requested: entity.StatusVerified, score: ptr(95), override: true, want: entity.StatusVerified, overridden: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, overridden, err := Default().Resolve(tc.requested, tc.score, tc.override)
+			if tc.wantErr != nil {
+				if !errors.Is(err, tc.wantErr) {
+					t.Fatalf("Resolve() error = %v; want %v", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve() unexpected error: %v", err)
+			}
+			if got != tc.want || overridden != tc.overridden {
+				t.Errorf("Resolve() = %q, %v; want %q, %v", got, overridden, tc.want, tc.overridden)
+			}
+		})
+	}
+}
+
+func TestOverrideRecord(t *testing.T) {
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	raw, err := Default().OverrideRecord(entity.StatusVerified, 95, "moderator", at)
+	if err != nil {
+		t.Fatalf("OverrideRecord() unexpected error: %v", err)
+	}
+
+	var got entity.ModuleResult
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatalf("record is not a module result: %v", err)
+	}
+	if got.ModuleName != OverrideModule || got.RiskScore != 95 {
+		t.Errorf("record = %+v", got)
+	}
+	if got.Metadata["status"] != entity.StatusVerified || got.Metadata["derived_status"] != entity.StatusScam {
+		t.Errorf("record metadata = %v", got.Metadata)
+	}
+	if got.Metadata["overridden_by"] != "moderator" || got.Metadata["overridden_at"] != "2025-03-01T12:00:00Z" {
+		t.Errorf("record metadata = %v", got.Metadata)
+	}
+}
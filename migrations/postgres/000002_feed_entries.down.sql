@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_feed_entries_feed_last_seen;
+DROP INDEX IF EXISTS idx_feed_entries_domain;
+
+DROP TABLE IF EXISTS feed_entries;
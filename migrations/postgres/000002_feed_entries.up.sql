@@ -0,0 +1,18 @@
+-- Записи сторонних блоклистов (фидов)
+-- Версия: 1.1
+
+-- Какой фид и когда видел домен, нужно для дедупликации и политики удаления
+CREATE TABLE feed_entries (
+    feed VARCHAR(100) NOT NULL,
+    domain VARCHAR(253) NOT NULL,
+    url TEXT,
+    scam_type VARCHAR(100),
+
+    first_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (feed, domain)
+);
+
+CREATE INDEX idx_feed_entries_domain ON feed_entries(domain);
+CREATE INDEX idx_feed_entries_feed_last_seen ON feed_entries(feed, last_seen_at);
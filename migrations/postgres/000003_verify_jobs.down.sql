@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_verify_jobs_active_target;
+DROP INDEX IF EXISTS idx_verify_jobs_queued;
+
+DROP TABLE IF EXISTS verify_jobs;
@@ -0,0 +1,28 @@
+-- Асинхронные проверки доменов
+-- Версия: 1.2
+
+-- gen_random_uuid() встроен начиная с PostgreSQL 13, для старых версий нужен pgcrypto
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+CREATE TABLE verify_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    target TEXT NOT NULL, -- домен или URL как его прислал пользователь
+    status VARCHAR(20) NOT NULL DEFAULT 'queued'
+        CHECK (status IN ('queued', 'running', 'done', 'failed')),
+
+    result JSONB, -- VerifyDomainResult
+    error TEXT,
+    attempts INTEGER NOT NULL DEFAULT 0,
+
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- очередь для воркеров
+CREATE INDEX idx_verify_jobs_queued ON verify_jobs(created_at) WHERE status = 'queued';
+
+-- повторная отправка той же цели возвращает уже активную задачу
+CREATE UNIQUE INDEX idx_verify_jobs_active_target ON verify_jobs(target)
+    WHERE status IN ('queued', 'running');
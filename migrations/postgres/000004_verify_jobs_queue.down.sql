@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_verify_jobs_claim;
+CREATE INDEX idx_verify_jobs_queued ON verify_jobs(created_at) WHERE status = 'queued';
+
+UPDATE verify_jobs SET status = 'failed' WHERE status = 'dead';
+ALTER TABLE verify_jobs DROP CONSTRAINT verify_jobs_status_check;
+ALTER TABLE verify_jobs ADD CONSTRAINT verify_jobs_status_check
+    CHECK (status IN ('queued', 'running', 'done', 'failed'));
+
+ALTER TABLE verify_jobs DROP COLUMN visible_at;
+ALTER TABLE verify_jobs DROP COLUMN max_attempts;
+ALTER TABLE verify_jobs DROP COLUMN priority;
@@ -0,0 +1,18 @@
+-- Очередь проверок: приоритеты, повторы с backoff, dead-letter и visibility timeout
+-- Версия: 1.3
+
+-- меньшее число = выше приоритет: запросы пользователей (1) раньше фоновых перепроверок (5)
+ALTER TABLE verify_jobs ADD COLUMN priority SMALLINT NOT NULL DEFAULT 5;
+ALTER TABLE verify_jobs ADD COLUMN max_attempts INTEGER NOT NULL DEFAULT 5;
+-- queued: не раньше этого времени (backoff), running: воркер считается упавшим после него
+ALTER TABLE verify_jobs ADD COLUMN visible_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+-- исчерпавшие попытки задачи остаются в dead для разбора
+UPDATE verify_jobs SET status = 'dead' WHERE status = 'failed';
+ALTER TABLE verify_jobs DROP CONSTRAINT verify_jobs_status_check;
+ALTER TABLE verify_jobs ADD CONSTRAINT verify_jobs_status_check
+    CHECK (status IN ('queued', 'running', 'done', 'dead'));
+
+DROP INDEX IF EXISTS idx_verify_jobs_queued;
+CREATE INDEX idx_verify_jobs_claim ON verify_jobs(priority, visible_at)
+    WHERE status IN ('queued', 'running');
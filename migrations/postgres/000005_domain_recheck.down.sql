@@ -0,0 +1,6 @@
+ALTER TABLE verify_jobs DROP COLUMN skip_cache;
+
+DROP INDEX IF EXISTS idx_domains_expires_at;
+
+ALTER TABLE domains DROP COLUMN expires_at;
+ALTER TABLE domains DROP COLUMN last_check_at;
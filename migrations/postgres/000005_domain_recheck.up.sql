@@ -0,0 +1,15 @@
+-- Перепроверка доменов: когда проверяли в последний раз и когда вердикт устаревает
+-- Версия: 1.4
+
+ALTER TABLE domains ADD COLUMN last_check_at TIMESTAMP WITH TIME ZONE;
+-- NULL = вердикт не устаревает (ручные и импортированные из фидов)
+ALTER TABLE domains ADD COLUMN expires_at TIMESTAMP WITH TIME ZONE;
+
+-- автоматические вердикты, сохранённые до этой версии, перепроверяются первыми
+UPDATE domains SET last_check_at = updated_at, expires_at = updated_at
+WHERE verification_method = 'automatic';
+
+CREATE INDEX idx_domains_expires_at ON domains(expires_at) WHERE expires_at IS NOT NULL;
+
+-- перепроверка запускает чекеры заново, даже если домен уже в списке
+ALTER TABLE verify_jobs ADD COLUMN skip_cache BOOLEAN NOT NULL DEFAULT false;
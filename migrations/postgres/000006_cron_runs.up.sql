@@ -0,0 +1,14 @@
+-- Последний запуск фоновых задач планировщика (internal/app/cron)
+-- Версия: 1.5
+
+CREATE TABLE cron_runs (
+    name VARCHAR(100) PRIMARY KEY, -- имя задачи, одна строка на задачу
+    trigger VARCHAR(20) NOT NULL CHECK (trigger IN ('schedule', 'manual')),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('running', 'succeeded', 'failed')),
+    -- слот последнего запуска по расписанию, второй раз этот слот не запускается
+    scheduled_at TIMESTAMP WITH TIME ZONE,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    error TEXT,
+    instance VARCHAR(255) NOT NULL -- хост/pid реплики, выполнившей запуск
+);
@@ -0,0 +1,4 @@
+ALTER TABLE verify_jobs DROP COLUMN trigger;
+
+DROP INDEX IF EXISTS idx_domain_checks_domain;
+DROP TABLE IF EXISTS domain_checks;
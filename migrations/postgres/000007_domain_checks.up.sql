@@ -0,0 +1,24 @@
+-- История проверок: одна запись на каждый прогон пайплайна
+-- Версия: 1.6
+
+-- без внешнего ключа на domains: вердикты insufficient_data туда не попадают,
+-- а история нужна и после удаления домена
+CREATE TABLE domain_checks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    domain VARCHAR(253) NOT NULL,
+    trigger VARCHAR(20) NOT NULL CHECK (trigger IN ('user', 'recheck', 'admin')),
+    status VARCHAR(20) NOT NULL
+        CHECK (status IN ('verified', 'scam', 'suspicious', 'insufficient_data')),
+    risk_score DECIMAL(5,2) CHECK (risk_score >= 0 AND risk_score <= 100),
+    reasons TEXT[],
+    module_results JSONB NOT NULL, -- []ModuleResult, с ошибками упавших модулей
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_domain_checks_domain ON domain_checks(domain, started_at DESC);
+
+-- кто поставил задачу, записывается в domain_checks
+ALTER TABLE verify_jobs ADD COLUMN trigger VARCHAR(20) NOT NULL DEFAULT 'user'
+    CHECK (trigger IN ('user', 'recheck', 'admin'));
+UPDATE verify_jobs SET trigger = 'recheck' WHERE skip_cache;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_pending_moderation_assigned_to;
+DROP INDEX IF EXISTS idx_pending_moderation_queue;
+DROP TABLE IF EXISTS pending_moderation;
@@ -0,0 +1,30 @@
+-- Очередь ручной модерации подозрительных доменов
+-- Версия: 1.7
+
+-- одна задача на домен: решенная задача открывается заново,
+-- если домен снова становится suspicious
+CREATE TABLE pending_moderation (
+    domain VARCHAR(253) PRIMARY KEY REFERENCES domains(domain) ON DELETE CASCADE,
+    -- проверка, после которой домен стал suspicious, NULL если статус выставил админ
+    check_id UUID REFERENCES domain_checks(id) ON DELETE SET NULL,
+    reasons TEXT[] NOT NULL DEFAULT '{}',
+    source_modules VARCHAR(100)[] NOT NULL DEFAULT '{}',
+    priority INTEGER NOT NULL DEFAULT 5 CHECK (priority >= 1 AND priority <= 10),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'in_progress', 'resolved')),
+    assigned_to VARCHAR(100),
+    resolution VARCHAR(20) CHECK (resolution IN ('verified', 'scam')),
+    resolved_by VARCHAR(100), -- модератор или 'system', если статус сменила перепроверка
+    moderator_notes TEXT,
+    submitted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    claimed_at TIMESTAMP WITH TIME ZONE,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- открытые задачи в порядке разбора
+CREATE INDEX idx_pending_moderation_queue ON pending_moderation(priority, submitted_at)
+    WHERE status IN ('pending', 'in_progress');
+CREATE INDEX idx_pending_moderation_assigned_to ON pending_moderation(assigned_to)
+    WHERE assigned_to IS NOT NULL;
@@ -56,10 +56,15 @@ RETURNING *;
 DELETE FROM domains WHERE domain = $1;
 
 -- name: GetDomainsForRecheck :many
-SELECT * FROM domains 
-WHERE last_check_at IS NULL 
-OR last_check_at < $1 
-ORDER BY last_check_at ASC NULLS FIRST
-LIMIT $2;
+UPDATE domains SET expires_at = $1::timestamptz + make_interval(secs => $2)
+WHERE domain IN (
+    SELECT domain FROM domains
+    WHERE expires_at <= $1
+    AND verification_method IS DISTINCT FROM 'manual'
+    ORDER BY expires_at
+    LIMIT $3
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING *;
 
 
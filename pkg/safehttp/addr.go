@@ -0,0 +1,70 @@
+package safehttp
+
+import "net/netip"
+
+// blockedPrefixes are ranges an outbound request must never reach:
+// private networks, loopback, link-local (cloud metadata lives there),
+// carrier-grade NAT and reserved or documentation ranges.
+var blockedPrefixes = mustPrefixes(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.0.2.0/24",
+	"192.88.99.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"198.51.100.0/24",
+	"203.0.113.0/24",
+	"224.0.0.0/4",
+	"240.0.0.0/4",
+	"::/128",
+	"::1/128",
+	"100::/64",
+	"2001:db8::/32",
+	"fc00::/7",
+	"fe80::/10",
+	"ff00::/8",
+)
+
+// embedding ranges carry an IPv4 address inside an IPv6 one
+var (
+	nat64Prefix = netip.MustParsePrefix("64:ff9b::/96")
+	sixToFour   = netip.MustParsePrefix("2002::/16")
+)
+
+// IsBlocked reports whether addr is in a range outbound requests must not reach.
+// IPv4 addresses mapped or embedded in IPv6 are checked as IPv4.
+func IsBlocked(addr netip.Addr) bool {
+	if !addr.IsValid() {
+		return true
+	}
+	addr = addr.Unmap()
+
+	switch {
+	case nat64Prefix.Contains(addr):
+		b := addr.As16()
+		return IsBlocked(netip.AddrFrom4([4]byte{b[12], b[13], b[14], b[15]}))
+	case sixToFour.Contains(addr):
+		b := addr.As16()
+		return IsBlocked(netip.AddrFrom4([4]byte{b[2], b[3], b[4], b[5]}))
+	}
+
+	for _, p := range blockedPrefixes {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func mustPrefixes(ss ...string) []netip.Prefix {
+	out := make([]netip.Prefix, 0, len(ss))
+	for _, s := range ss {
+		out = append(out, netip.MustParsePrefix(s))
+	}
+	return out
+}
@@ -0,0 +1,124 @@
+// Package safehttp is the outbound HTTP client for user supplied hosts.
+// It refuses private, loopback, link-local and metadata addresses after
+// DNS resolution, pins the checked address, caps the response size and time
+// and sets the User-Agent.
+package safehttp
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/netip"
+	"time"
+)
+
+const (
+	DefaultUserAgent   = "Mozilla/5.0 (compatible; scam-list/1.0; +https://github.com/ItsXomyak/scam-list)"
+	DefaultTimeout     = 10 * time.Second
+	DefaultMaxBodySize = 5 << 20
+
+	maxHeaderBytes = 64 << 10
+)
+
+var ErrBodyTooLarge = errors.New("response body is too large")
+
+// Config of the outbound client, zero values use the defaults.
+type Config struct {
+	Timeout     time.Duration // whole request including the body
+	MaxBodySize int64
+	UserAgent   string
+	Allow       []netip.Prefix // blocked ranges that are reachable anyway
+	Resolver    Resolver       // nil uses the system resolver
+}
+
+// New creates a client. Redirects are dialed through the same checks.
+func New(cfg Config) *http.Client {
+	cfg = cfg.withDefaults()
+	return newClient(cfg, NewDialer(cfg.Resolver, cfg.Allow, cfg.Timeout))
+}
+
+func (cfg Config) withDefaults() Config {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+	if cfg.MaxBodySize <= 0 {
+		cfg.MaxBodySize = DefaultMaxBodySize
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = DefaultUserAgent
+	}
+	return cfg
+}
+
+func newClient(cfg Config, dialer *Dialer) *http.Client {
+	transport := &http.Transport{
+		// a proxy from the environment would bypass the address checks
+		Proxy:                  nil,
+		DialContext:            dialer.DialContext,
+		ForceAttemptHTTP2:      true,
+		MaxIdleConns:           100,
+		IdleConnTimeout:        90 * time.Second,
+		TLSHandshakeTimeout:    cfg.Timeout,
+		ResponseHeaderTimeout:  cfg.Timeout,
+		MaxResponseHeaderBytes: maxHeaderBytes,
+	}
+
+	return &http.Client{
+		Timeout: cfg.Timeout,
+		Transport: &roundTripper{
+			next:        transport,
+			userAgent:   cfg.UserAgent,
+			maxBodySize: cfg.MaxBodySize,
+		},
+	}
+}
+
+// roundTripper sets the User-Agent and limits the response body.
+type roundTripper struct {
+	next        http.RoundTripper
+	userAgent   string
+	maxBodySize int64
+}
+
+func (t *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("User-Agent") == "" {
+		req = req.Clone(req.Context())
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.ContentLength > t.maxBodySize {
+		resp.Body.Close()
+		return nil, ErrBodyTooLarge
+	}
+
+	resp.Body = &limitedBody{ReadCloser: resp.Body, left: t.maxBodySize}
+	return resp, nil
+}
+
+// limitedBody fails with ErrBodyTooLarge instead of silently truncating.
+type limitedBody struct {
+	io.ReadCloser
+	left int64
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.left <= 0 {
+		// one more byte tells an exact fit from an overflow
+		var one [1]byte
+		n, err := b.ReadCloser.Read(one[:])
+		if n > 0 {
+			return 0, ErrBodyTooLarge
+		}
+		return 0, err
+	}
+	if int64(len(p)) > b.left {
+		p = p[:b.left]
+	}
+	n, err := b.ReadCloser.Read(p)
+	b.left -= int64(n)
+	return n, err
+}
@@ -0,0 +1,119 @@
+package safehttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+var ErrBlockedAddress = errors.New("address is not allowed for outbound requests")
+
+// Resolver looks up host addresses, *net.Resolver implements it.
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+// Dialer resolves the host once, refuses blocked addresses and connects to
+// the address it checked. Dialing the checked IP instead of the name means a
+// second DNS answer cannot point the connection somewhere else (rebinding).
+type Dialer struct {
+	resolver Resolver
+	allow    []netip.Prefix
+	timeout  time.Duration
+
+	// dial connects to an IP address, replaced in tests
+	dial func(ctx context.Context, network, address string) (net.Conn, error)
+}
+
+// NewDialer creates a dialer. allow lists ranges that are reachable even
+// though they are blocked by default, e.g. an internal proxy.
+func NewDialer(resolver Resolver, allow []netip.Prefix, timeout time.Duration) *Dialer {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+
+	d := &Dialer{resolver: resolver, allow: allow, timeout: timeout}
+	nd := &net.Dialer{Timeout: timeout, Control: d.control}
+	d.dial = nd.DialContext
+
+	return d
+}
+
+func (d *Dialer) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := d.resolve(ctx, network, host)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	for _, addr := range addrs {
+		conn, err := d.dial(ctx, network, net.JoinHostPort(addr.String(), port))
+		if err == nil {
+			return conn, nil
+		}
+		errs = append(errs, err)
+	}
+	return nil, errors.Join(errs...)
+}
+
+// resolve returns the addresses of host. A host with any blocked address
+// is refused as a whole, it is pointed at us on purpose.
+func (d *Dialer) resolve(ctx context.Context, network, host string) ([]netip.Addr, error) {
+	var addrs []netip.Addr
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addrs = []netip.Addr{addr}
+	} else {
+		ipNetwork := "ip"
+		switch network {
+		case "tcp4", "udp4":
+			ipNetwork = "ip4"
+		case "tcp6", "udp6":
+			ipNetwork = "ip6"
+		}
+		addrs, err = d.resolver.LookupNetIP(ctx, ipNetwork, host)
+		if err != nil {
+			return nil, err
+		}
+		if len(addrs) == 0 {
+			return nil, fmt.Errorf("no addresses for %s", host)
+		}
+	}
+
+	for _, addr := range addrs {
+		if !d.allowed(addr) {
+			return nil, fmt.Errorf("%w: %s resolves to %s", ErrBlockedAddress, host, addr)
+		}
+	}
+	return addrs, nil
+}
+
+// control checks the address the socket is about to connect to.
+func (d *Dialer) control(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, address)
+	}
+	if !d.allowed(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrBlockedAddress, addrPort.Addr())
+	}
+	return nil
+}
+
+func (d *Dialer) allowed(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, p := range d.allow {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return !IsBlocked(addr)
+}
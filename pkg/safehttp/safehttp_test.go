@@ -0,0 +1,202 @@
+package safehttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIsBlocked(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "127.0.0.1", want: true},
+		{addr: "10.1.2.3", want: true},
+		{addr: "172.20.0.1", want: true},
+		{addr: "192.168.1.1", want: true},
+		{addr: "169.254.169.254", want: true},
+		{addr: "100.100.100.200", want: true},
+		{addr: "0.0.0.0", want: true},
+		{addr: "255.255.255.255", want: true},
+		{addr: "::1", want: true},
+		{addr: "::ffff:127.0.0.1", want: true},
+		{addr: "fd00:ec2::254", want: true},
+		{addr: "fe80::1", want: true},
+		{addr: "64:ff9b::a9fe:a9fe", want: true},
+		{addr: "2002:7f00:1::", want: true},
+		{addr: "93.184.216.34", want: false},
+		{addr: "2a00:1450:4001:80b::200e", want: false},
+		{addr: "64:ff9b::808:808", want: false},
+	}
+
+	for _, tc := range tests {
+		if got := IsBlocked(netip.MustParseAddr(tc.addr)); got != tc.want {
+			t.Errorf("IsBlocked(%s) = %v; want %v", tc.addr, got, tc.want)
+		}
+	}
+}
+
+// fakeResolver answers from a list, one answer per lookup, and repeats the last.
+type fakeResolver struct {
+	mu      sync.Mutex
+	answers [][]string
+	calls   int
+}
+
+func (r *fakeResolver) LookupNetIP(_ context.Context, _, host string) ([]netip.Addr, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	answer := r.answers[min(r.calls, len(r.answers)-1)]
+	r.calls++
+
+	var out []netip.Addr
+	for _, a := range answer {
+		out = append(out, netip.MustParseAddr(a))
+	}
+	return out, nil
+}
+
+func TestClientBlocksPrivateTargets(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+	}))
+	defer srv.Close()
+	port := srv.Listener.Addr().(*net.TCPAddr).Port
+
+	resolver := &fakeResolver{answers: [][]string{{"127.0.0.1"}}}
+	client := New(Config{Timeout: 2 * time.Second, Resolver: resolver})
+
+	targets := []string{
+		srv.URL,
+		fmt.Sprintf("http://localhost:%d/", port),
+		fmt.Sprintf("http://[::1]:%d/", port),
+		fmt.Sprintf("http://[::ffff:127.0.0.1]:%d/", port),
+		"http://169.254.169.254/latest/meta-data/",
+		fmt.Sprintf("http://rebind.example:%d/", port),
+	}
+	for _, target := range targets {
+		_, err := client.Get(target)
+		if !errors.Is(err, ErrBlockedAddress) {
+			t.Errorf("Get(%s) error = %v; want %v", target, err, ErrBlockedAddress)
+		}
+	}
+	if hits != 0 {
+		t.Errorf("server got %d requests; want none", hits)
+	}
+}
+
+func TestClientBlocksRedirectToPrivate(t *testing.T) {
+	internal := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("internal server was reached through a redirect")
+	}))
+	defer internal.Close()
+
+	// the public site is reached by its pinned address, then sends us home
+	public := httptest.NewServer(http.RedirectHandler(internal.URL, http.StatusFound))
+	defer public.Close()
+
+	resolver := &fakeResolver{answers: [][]string{{"93.184.216.34"}}}
+	client := pinnedClient(Config{Resolver: resolver}, public.Listener.Addr().String(), nil)
+
+	_, err := client.Get("http://public.example/")
+	if !errors.Is(err, ErrBlockedAddress) {
+		t.Errorf("Get() error = %v; want %v", err, ErrBlockedAddress)
+	}
+}
+
+func TestClientPinsResolvedAddress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.Header.Get("User-Agent"))
+	}))
+	defer srv.Close()
+
+	// DNS rebinding: a public answer passes the check, the next one points inside
+	resolver := &fakeResolver{answers: [][]string{{"93.184.216.34"}, {"127.0.0.1"}}}
+	var dialed []string
+	client := pinnedClient(Config{UserAgent: "scam-list-test", Resolver: resolver}, srv.Listener.Addr().String(), &dialed)
+	client.Transport.(*roundTripper).next.(*http.Transport).DisableKeepAlives = true
+
+	resp, err := client.Get("http://rebind.example/")
+	if err != nil {
+		t.Fatalf("Get() unexpected error: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+
+	if len(dialed) != 1 || dialed[0] != "93.184.216.34:80" {
+		t.Errorf("dialed %v; want the checked address 93.184.216.34:80", dialed)
+	}
+	if string(body) != "scam-list-test" {
+		t.Errorf("User-Agent = %q; want scam-list-test", body)
+	}
+
+	if _, err := client.Get("http://rebind.example/"); !errors.Is(err, ErrBlockedAddress) {
+		t.Errorf("second Get() error = %v; want %v after rebinding", err, ErrBlockedAddress)
+	}
+	if len(dialed) != 1 {
+		t.Errorf("dialed %v; the rebound address must not be dialed", dialed)
+	}
+}
+
+func TestClientLimitsBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chunked" {
+			w.(http.Flusher).Flush()
+		}
+		fmt.Fprint(w, strings.Repeat("a", 2048))
+	}))
+	defer srv.Close()
+
+	client := New(Config{MaxBodySize: 1024, Allow: []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")}})
+
+	if _, err := client.Get(srv.URL + "/sized"); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("Get() error = %v; want %v for a large Content-Length", err, ErrBodyTooLarge)
+	}
+
+	resp, err := client.Get(srv.URL + "/chunked")
+	if err != nil {
+		t.Fatalf("Get() unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if _, err := io.ReadAll(resp.Body); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("ReadAll() error = %v; want %v for a chunked body", err, ErrBodyTooLarge)
+	}
+
+	small := New(Config{MaxBodySize: 2048, Allow: []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8")}})
+	resp, err = small.Get(srv.URL + "/chunked")
+	if err != nil {
+		t.Fatalf("Get() unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+	if body, err := io.ReadAll(resp.Body); err != nil || len(body) != 2048 {
+		t.Errorf("ReadAll() = %d bytes, %v; want an exact fit to pass", len(body), err)
+	}
+}
+
+// pinnedClient is a client whose checked connections all go to addr.
+// The addresses the dialer asked for are recorded in dialed.
+func pinnedClient(cfg Config, addr string, dialed *[]string) *http.Client {
+	cfg.Timeout = 2 * time.Second
+	cfg = cfg.withDefaults()
+	d := NewDialer(cfg.Resolver, cfg.Allow, cfg.Timeout)
+	d.dial = func(ctx context.Context, network, address string) (net.Conn, error) {
+		if dialed != nil {
+			*dialed = append(*dialed, address)
+		}
+		var nd net.Dialer
+		return nd.DialContext(ctx, network, addr)
+	}
+	return newClient(cfg, d)
+}
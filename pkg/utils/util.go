@@ -2,9 +2,14 @@ package utils
 
 import (
 	"errors"
+	"fmt"
+	"net"
 	"net/url"
 	"regexp"
 	"strings"
+
+	"golang.org/x/net/idna"
+	"golang.org/x/net/publicsuffix"
 )
 
 var schemeRe = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9+.-]*://`)
@@ -43,3 +48,43 @@ func ExtractDomain(raw string) (string, error) {
 
 	return host, nil
 }
+
+// NormalizeDomain как ExtractDomain, но переводит интернациональные имена
+// в ASCII (punycode) форму по IDNA, в которой домены хранятся и проверяются.
+// Примеры:
+//   - "https://Пример.РФ/path" -> "xn--e1afmkfd.xn--p1ai"
+//   - "xn--e1afmkfd.xn--p1ai"  -> "xn--e1afmkfd.xn--p1ai"
+func NormalizeDomain(raw string) (string, error) {
+	host, err := ExtractDomain(raw)
+	if err != nil {
+		return "", err
+	}
+	if net.ParseIP(host) != nil {
+		return host, nil
+	}
+
+	ascii, err := idna.Lookup.ToASCII(host)
+	if err != nil {
+		return "", fmt.Errorf("invalid international domain name: %w", err)
+	}
+
+	return ascii, nil
+}
+
+// RegistrableDomain возвращает домен, который можно зарегистрировать (eTLD+1),
+// по Public Suffix List.
+// Примеры:
+//   - "login.kaspi.kz"      -> "kaspi.kz"
+//   - "shop.example.com.kz" -> "example.com.kz"
+//   - "a.b.github.io"       -> "b.github.io"
+func RegistrableDomain(host string) (string, error) {
+	host = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(host), "."))
+	if host == "" {
+		return "", errors.New("empty host")
+	}
+	if net.ParseIP(host) != nil {
+		return "", errors.New("host is an IP address")
+	}
+
+	return publicsuffix.EffectiveTLDPlusOne(host)
+}
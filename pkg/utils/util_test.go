@@ -67,3 +67,70 @@ func TestExtractDomain(t *testing.T) {
 		}
 	}
 }
+
+func TestRegistrableDomain(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "kaspi.kz", want: "kaspi.kz"},
+		{in: "login.kaspi.kz", want: "kaspi.kz"},
+		{in: "Shop.Example.com.kz.", want: "example.com.kz"},
+		{in: "kaspi.kz.secure-login.xyz", want: "secure-login.xyz"},
+		{in: "a.b.github.io", want: "b.github.io"},
+		{in: "kz", wantErr: true},
+		{in: "127.0.0.1", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		got, err := RegistrableDomain(tc.in)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("RegistrableDomain(%q) expected error, got %q", tc.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("RegistrableDomain(%q) unexpected error: %v", tc.in, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("RegistrableDomain(%q) = %q; want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestNormalizeDomain(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{in: "Kaspi.KZ", want: "kaspi.kz"},
+		{in: "https://Пример.РФ/path", want: "xn--e1afmkfd.xn--p1ai"},
+		{in: "xn--e1afmkfd.xn--p1ai", want: "xn--e1afmkfd.xn--p1ai"},
+		{in: "kаspi.kz", want: "xn--kspi-53d.kz"}, // Cyrillic "а"
+		{in: "127.0.0.1", want: "127.0.0.1"},
+		{in: "xn--zz.kz", wantErr: true},
+		{in: "", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		got, err := NormalizeDomain(tc.in)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("NormalizeDomain(%q) expected error, got %q", tc.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("NormalizeDomain(%q) unexpected error: %v", tc.in, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("NormalizeDomain(%q) = %q; want %q", tc.in, got, tc.want)
+		}
+	}
+}